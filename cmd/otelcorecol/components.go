@@ -5,6 +5,7 @@ package main
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
+	failoverconnector "go.opentelemetry.io/collector/connector/failoverconnector"
 	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
 	"go.opentelemetry.io/collector/exporter"
 	debugexporter "go.opentelemetry.io/collector/exporter/debugexporter"
@@ -12,7 +13,9 @@ import (
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
+	healthextension "go.opentelemetry.io/collector/extension/healthextension"
 	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
+	tapextension "go.opentelemetry.io/collector/extension/tapextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
@@ -20,6 +23,7 @@ import (
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
 	queuebatchprocessor "go.opentelemetry.io/collector/processor/queuebatchprocessor"
 	"go.opentelemetry.io/collector/receiver"
+	internaltelemetryreceiver "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
 	nopreceiver "go.opentelemetry.io/collector/receiver/nopreceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
 	otelconftelemetry "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
@@ -46,18 +50,23 @@ func components() (otelcol.Factories, error) {
 	}
 
 	factories.Extensions, err = otelcol.MakeFactoryMap[extension.Factory](
+		healthextension.NewFactory(),
 		memorylimiterextension.NewFactory(),
+		tapextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ExtensionModules = makeModulesMap(factories.Extensions, map[component.Type]string{
+		healthextension.NewFactory().Type():        "go.opentelemetry.io/collector/extension/healthextension v0.159.0",
 		memorylimiterextension.NewFactory().Type(): "go.opentelemetry.io/collector/extension/memorylimiterextension v0.159.0",
+		tapextension.NewFactory().Type():           "go.opentelemetry.io/collector/extension/tapextension v0.159.0",
 		zpagesextension.NewFactory().Type():        "go.opentelemetry.io/collector/extension/zpagesextension v0.159.0",
 	})
 
 	factories.Receivers, err = otelcol.MakeFactoryMap[receiver.Factory](
+		internaltelemetryreceiver.NewFactory(),
 		nopreceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
 	)
@@ -65,8 +74,9 @@ func components() (otelcol.Factories, error) {
 		return otelcol.Factories{}, err
 	}
 	factories.ReceiverModules = makeModulesMap(factories.Receivers, map[component.Type]string{
-		nopreceiver.NewFactory().Type():  "go.opentelemetry.io/collector/receiver/nopreceiver v0.159.0",
-		otlpreceiver.NewFactory().Type(): "go.opentelemetry.io/collector/receiver/otlpreceiver v0.159.0",
+		internaltelemetryreceiver.NewFactory().Type(): "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver v0.159.0",
+		nopreceiver.NewFactory().Type():               "go.opentelemetry.io/collector/receiver/nopreceiver v0.159.0",
+		otlpreceiver.NewFactory().Type():              "go.opentelemetry.io/collector/receiver/otlpreceiver v0.159.0",
 	})
 
 	factories.Exporters, err = otelcol.MakeFactoryMap[exporter.Factory](
@@ -100,13 +110,15 @@ func components() (otelcol.Factories, error) {
 	})
 
 	factories.Connectors, err = otelcol.MakeFactoryMap[connector.Factory](
+		failoverconnector.NewFactory(),
 		forwardconnector.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ConnectorModules = makeModulesMap(factories.Connectors, map[component.Type]string{
-		forwardconnector.NewFactory().Type(): "go.opentelemetry.io/collector/connector/forwardconnector v0.159.0",
+		failoverconnector.NewFactory().Type(): "go.opentelemetry.io/collector/connector/failoverconnector v0.159.0",
+		forwardconnector.NewFactory().Type():  "go.opentelemetry.io/collector/connector/forwardconnector v0.159.0",
 	})
 
 	return factories, nil
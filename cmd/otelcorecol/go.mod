@@ -7,12 +7,15 @@ go 1.25.0
 require (
 	go.opentelemetry.io/collector/component v1.65.0
 	go.opentelemetry.io/collector/confmap v1.65.0
+	go.opentelemetry.io/collector/confmap/provider/dirprovider v0.159.0
 	go.opentelemetry.io/collector/confmap/provider/envprovider v1.65.0
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v1.65.0
 	go.opentelemetry.io/collector/confmap/provider/httpprovider v1.65.0
 	go.opentelemetry.io/collector/confmap/provider/httpsprovider v1.65.0
+	go.opentelemetry.io/collector/confmap/provider/secretprovider v0.159.0
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.65.0
 	go.opentelemetry.io/collector/connector v0.159.0
+	go.opentelemetry.io/collector/connector/failoverconnector v0.159.0
 	go.opentelemetry.io/collector/connector/forwardconnector v0.159.0
 	go.opentelemetry.io/collector/exporter v1.65.0
 	go.opentelemetry.io/collector/exporter/debugexporter v0.159.0
@@ -20,7 +23,9 @@ require (
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.159.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.159.0
 	go.opentelemetry.io/collector/extension v1.65.0
+	go.opentelemetry.io/collector/extension/healthextension v0.159.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.159.0
+	go.opentelemetry.io/collector/extension/tapextension v0.159.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.159.0
 	go.opentelemetry.io/collector/otelcol v0.159.0
 	go.opentelemetry.io/collector/processor v1.65.0
@@ -28,6 +33,7 @@ require (
 	go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.159.0
 	go.opentelemetry.io/collector/processor/queuebatchprocessor v0.159.0
 	go.opentelemetry.io/collector/receiver v1.65.0
+	go.opentelemetry.io/collector/receiver/internaltelemetryreceiver v0.159.0
 	go.opentelemetry.io/collector/receiver/nopreceiver v0.159.0
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.159.0
 	go.opentelemetry.io/collector/service v0.159.0
@@ -240,12 +246,16 @@ replace go.opentelemetry.io/collector/confmap/xconfmap => ../../confmap/xconfmap
 
 replace go.opentelemetry.io/collector/confmap/provider/envprovider => ../../confmap/provider/envprovider
 
+replace go.opentelemetry.io/collector/confmap/provider/dirprovider => ../../confmap/provider/dirprovider
+
 replace go.opentelemetry.io/collector/confmap/provider/fileprovider => ../../confmap/provider/fileprovider
 
 replace go.opentelemetry.io/collector/confmap/provider/httpprovider => ../../confmap/provider/httpprovider
 
 replace go.opentelemetry.io/collector/confmap/provider/httpsprovider => ../../confmap/provider/httpsprovider
 
+replace go.opentelemetry.io/collector/confmap/provider/secretprovider => ../../confmap/provider/secretprovider
+
 replace go.opentelemetry.io/collector/confmap/provider/yamlprovider => ../../confmap/provider/yamlprovider
 
 replace go.opentelemetry.io/collector/consumer => ../../consumer
@@ -264,6 +274,8 @@ replace go.opentelemetry.io/collector/connector/connectortest => ../../connector
 
 replace go.opentelemetry.io/collector/connector/xconnector => ../../connector/xconnector
 
+replace go.opentelemetry.io/collector/connector/failoverconnector => ../../connector/failoverconnector
+
 replace go.opentelemetry.io/collector/connector/forwardconnector => ../../connector/forwardconnector
 
 replace go.opentelemetry.io/collector/exporter => ../../exporter
@@ -298,8 +310,12 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
 
+replace go.opentelemetry.io/collector/extension/healthextension => ../../extension/healthextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
+replace go.opentelemetry.io/collector/extension/tapextension => ../../extension/tapextension
+
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
@@ -350,6 +366,8 @@ replace go.opentelemetry.io/collector/processor/processorhelper => ../../process
 
 replace go.opentelemetry.io/collector/receiver => ../../receiver
 
+replace go.opentelemetry.io/collector/receiver/internaltelemetryreceiver => ../../receiver/internaltelemetryreceiver
+
 replace go.opentelemetry.io/collector/receiver/nopreceiver => ../../receiver/nopreceiver
 
 replace go.opentelemetry.io/collector/receiver/receiverhelper => ../../receiver/receiverhelper
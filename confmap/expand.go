@@ -5,12 +5,16 @@ package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"go.opentelemetry.io/collector/confmap/internal"
+	"go.opentelemetry.io/collector/confmap/internal/metadata"
 )
 
 // schemePattern defines the regexp pattern for scheme names.
@@ -190,6 +194,11 @@ func (mr *Resolver) expandURI(ctx context.Context, input string) (*Retrieved, er
 	// strip ${ and }
 	uri := input[2 : len(input)-1]
 
+	var mods modifiers
+	if metadata.ConfmapEnableExpansionModifiersFeatureGate.IsEnabled() {
+		uri, mods = parseModifiers(uri)
+	}
+
 	if !strings.Contains(uri, ":") {
 		uri = fmt.Sprintf("%s:%s", mr.defaultScheme, uri)
 	}
@@ -202,17 +211,132 @@ func (mr *Resolver) expandURI(ctx context.Context, input string) (*Retrieved, er
 	if strings.Contains(lURI.opaqueValue, "$") {
 		return nil, fmt.Errorf("the uri %q contains unsupported characters ('$')", lURI.asString())
 	}
+	if _, ok := mr.providers[lURI.scheme]; !ok {
+		return nil, fmt.Errorf("scheme %q is not supported for uri %q", lURI.scheme, lURI.asString())
+	}
 	ret, err := mr.retrieveValue(ctx, lURI)
-	if err != nil {
+	if err != nil && !mods.hasFallback() {
 		return nil, err
 	}
-	mr.closers = append(mr.closers, ret.Close)
-	return ret, nil
+	if ret != nil {
+		mr.closers = append(mr.closers, ret.Close)
+	}
+	return mods.apply(lURI, ret, err)
+}
+
+// modifiers are the default value, the required value message and the transforms of an expansion, e.g.
+// ${env:NAME:-default|trim|upper}.
+type modifiers struct {
+	defaultValue *string
+	required     *string
+	transforms   []string
+}
+
+// transformFuncs are the functions applied to the string representation of the expanded values.
+var transformFuncs = map[string]func(string) (string, error){
+	"base64decode": func(s string) (string, error) {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		return string(decoded), err
+	},
+	"file": func(s string) (string, error) {
+		content, err := os.ReadFile(filepath.Clean(s))
+		return string(content), err
+	},
+	"lower": func(s string) (string, error) { return strings.ToLower(s), nil },
+	"trim":  func(s string) (string, error) { return strings.TrimSpace(s), nil },
+	"upper": func(s string) (string, error) { return strings.ToUpper(s), nil },
+}
+
+// parseModifiers splits the URI of an expansion from its modifiers: the transforms follow the URI, each one
+// after a "|", and the URI is followed by either ":-" and the default value, or ":?" and the message of the
+// error reported when the value is not set.
+func parseModifiers(uri string) (string, modifiers) {
+	var mods modifiers
+	parts := strings.Split(uri, "|")
+	uri, mods.transforms = parts[0], parts[1:]
+
+	defaultIndex, requiredIndex := strings.Index(uri, ":-"), strings.Index(uri, ":?")
+	switch {
+	case defaultIndex >= 0 && (requiredIndex < 0 || defaultIndex < requiredIndex):
+		defaultValue := uri[defaultIndex+2:]
+		uri, mods.defaultValue = uri[:defaultIndex], &defaultValue
+	case requiredIndex >= 0:
+		message := uri[requiredIndex+2:]
+		uri, mods.required = uri[:requiredIndex], &message
+	}
+	return uri, mods
+}
+
+// hasFallback returns whether the expansion handles the retrieval errors.
+func (m modifiers) hasFallback() bool {
+	return m.defaultValue != nil || m.required != nil
+}
+
+// apply returns the value retrieved from the uri with the modifiers applied. The default value is used, and
+// the required value error reported, when the value cannot be retrieved, is null or is an empty string.
+func (m modifiers) apply(uri location, ret *Retrieved, retrieveErr error) (*Retrieved, error) {
+	if retrieveErr == nil && !isUnset(ret) {
+		return m.transform(uri, ret)
+	}
+	switch {
+	case m.required != nil:
+		msg := *m.required
+		if msg == "" {
+			msg = "a value is required"
+		}
+		if retrieveErr != nil {
+			return nil, fmt.Errorf("%s: %s: %w", uri.asString(), msg, retrieveErr)
+		}
+		return nil, fmt.Errorf("%s: %s", uri.asString(), msg)
+	case m.defaultValue != nil:
+		var defaultRet *Retrieved
+		var err error
+		if *m.defaultValue == "" {
+			// NewRetrievedFromYAML translates an empty value to nil, an empty default is an empty string.
+			defaultRet, err = NewRetrieved("")
+		} else {
+			defaultRet, err = NewRetrievedFromYAML([]byte(*m.defaultValue))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return m.transform(uri, defaultRet)
+	}
+	return m.transform(uri, ret)
+}
+
+// transform applies the transforms to the string representation of the value, the result is parsed as YAML.
+func (m modifiers) transform(uri location, ret *Retrieved) (*Retrieved, error) {
+	if len(m.transforms) == 0 {
+		return ret, nil
+	}
+	str, err := ret.AsString()
+	if err != nil {
+		return nil, fmt.Errorf("cannot transform the value of %s: %w", uri.asString(), err)
+	}
+	for _, name := range m.transforms {
+		f, ok := transformFuncs[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform %q for %s", name, uri.asString())
+		}
+		if str, err = f(str); err != nil {
+			return nil, fmt.Errorf("cannot apply the %q transform to the value of %s: %w", name, uri.asString(), err)
+		}
+	}
+	return NewRetrievedFromYAML([]byte(str))
+}
+
+func isUnset(ret *Retrieved) bool {
+	raw, err := ret.AsRaw()
+	return err == nil && (raw == nil || raw == "")
 }
 
 type location struct {
 	scheme      string
 	opaqueValue string
+
+	// mergeStrategies are set by the merge parameters of the URI.
+	mergeStrategies []internal.MergeStrategy
 }
 
 func (c location) asString() string {
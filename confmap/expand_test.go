@@ -5,12 +5,18 @@ package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/internal/metadata"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 func TestResolverExpandEnvVars(t *testing.T) {
@@ -563,3 +569,95 @@ func TestResolverDefaultProviderExpand(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, map[string]any{"foo": "localhost"}, cfgMap.ToStringMap())
 }
+
+func TestResolverExpandModifiers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("s3cr3t\n"), 0o600))
+	values := map[string]string{
+		"VALUE":  " Value ",
+		"EMPTY":  "",
+		"B64":    base64.StdEncoding.EncodeToString([]byte("hello")),
+		"PATH":   path,
+		"NAME":   "VALUE",
+		"PORT":   "4317",
+		"COLONS": "a:-b",
+	}
+	testProvider := newFakeProvider("test", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		name := strings.TrimPrefix(uri, "test:")
+		if name == "MISSING" {
+			return nil, errors.New("missing value")
+		}
+		return NewRetrievedFromYAML([]byte(values[name]))
+	})
+
+	tests := []struct {
+		name        string
+		input       any
+		output      any
+		expectedErr string
+	}{
+		{name: "default_unset", input: "${test:UNSET:-fallback}", output: "fallback"},
+		{name: "default_empty", input: "${test:EMPTY:-fallback}", output: "fallback"},
+		{name: "default_retrieve_error", input: "${test:MISSING:-4317}", output: 4317},
+		{name: "default_set", input: "${test:PORT:-1234}", output: 4317},
+		{name: "default_empty_string", input: "${test:UNSET:-}", output: ""},
+		{name: "default_with_colons", input: "${test:UNSET:-localhost:4317}", output: "localhost:4317"},
+		{name: "default_embedded", input: "http://${test:UNSET:-localhost}:${test:PORT}", output: "http://localhost:4317"},
+		{name: "default_scheme", input: "${UNSET:-fallback}", output: "fallback"},
+		{name: "default_nested", input: "${test:UNSET:-${test:PORT}}", output: 4317},
+		{name: "nested_uri", input: "${test:${test:NAME}|trim}", output: "Value"},
+		{name: "required_set", input: "${test:PORT:?the port must be set}", output: 4317},
+		{name: "required_unset", input: "${test:UNSET:?the port must be set}", expectedErr: "test:UNSET: the port must be set"},
+		{name: "required_empty_message", input: "${test:EMPTY:?}", expectedErr: "test:EMPTY: a value is required"},
+		{name: "required_retrieve_error", input: "${test:MISSING:?must be set}", expectedErr: "test:MISSING: must be set: missing value"},
+		{name: "transform_trim", input: "${test:VALUE|trim}", output: "Value"},
+		{name: "transform_chain", input: "${test:VALUE|trim|upper}", output: "VALUE"},
+		{name: "transform_lower", input: "prefix-${test:VALUE|trim|lower}", output: "prefix-value"},
+		{name: "transform_base64decode", input: "${test:B64|base64decode}", output: "hello"},
+		{name: "transform_file", input: "${test:PATH|file|trim}", output: "s3cr3t"},
+		{name: "transform_default", input: "${test:UNSET:- Fallback |trim|lower}", output: "fallback"},
+		{name: "value_with_modifier_characters", input: "${test:COLONS}", output: "a:-b"},
+		{name: "transform_unknown", input: "${test:VALUE|reverse}", expectedErr: `unknown transform "reverse" for test:VALUE`},
+		{name: "transform_error", input: "${test:VALUE|base64decode}", expectedErr: `cannot apply the "base64decode" transform to the value of test:VALUE`},
+		{name: "transform_unset", input: "${test:UNSET|upper}", output: nil},
+		{name: "unsupported_scheme", input: "${unsupported:VALUE:-fallback}", expectedErr: `scheme "unsupported" is not supported for uri "unsupported:VALUE"`},
+	}
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableExpansionModifiersFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableExpansionModifiersFeatureGate.ID(), false))
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{"key": tt.input})
+			})
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, ProviderFactories: []ProviderFactory{provider, testProvider}, DefaultScheme: "test"})
+			require.NoError(t, err)
+
+			cfgMap, err := resolver.Resolve(context.Background())
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"key": tt.output}, cfgMap.ToStringMap())
+		})
+	}
+}
+
+func TestResolverExpandModifiersDisabled(t *testing.T) {
+	provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(map[string]any{"key": "${test:VALUE|upper}"})
+	})
+	// Without the feature gate, the modifiers are passed to the provider as part of the URI.
+	testProvider := newFakeProvider("test", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrieved(uri)
+	})
+	resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, ProviderFactories: []ProviderFactory{provider, testProvider}})
+	require.NoError(t, err)
+
+	cfgMap, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "test:VALUE|upper"}, cfgMap.ToStringMap())
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/knadh/koanf/maps"
 	"github.com/knadh/koanf/providers/confmap"
@@ -105,6 +106,23 @@ func (l *Conf) Merge(in *Conf) error {
 	return l.k.Merge(in.k)
 }
 
+// MergeWithStrategies merges the input given configuration into the existing config, merging the lists
+// with the first matching strategy. The strategies only apply when the confmap.enableMergeAppendOption
+// feature gate is enabled, before the default strategies merging the component lists.
+// Note that the given map may be modified.
+func MergeWithStrategies(l, in *Conf, strategies []MergeStrategy) error {
+	if len(strategies) == 0 || !metadata.ConfmapEnableMergeAppendOptionFeatureGate.IsEnabled() {
+		return l.Merge(in)
+	}
+	strategies = append(slices.Clip(strategies), defaultMergeStrategies...)
+	err := l.k.Load(confmap.Provider(in.ToStringMap(), ""), nil, koanf.WithMergeFunc(newMergeFunc(strategies)))
+	if err != nil {
+		return err
+	}
+	l.isNil = l.isNil && in.isNil
+	return nil
+}
+
 // Delete a path from the Conf.
 // If the path exists, deletes it and returns true.
 // If the path does not exist, does nothing and returns false.
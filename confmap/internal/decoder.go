@@ -82,9 +82,10 @@ func Decode(input, result any, settings UnmarshalOptions, skipTopLevelUnmarshale
 	}
 	if err = decoder.Decode(input); err != nil {
 		if strings.HasPrefix(err.Error(), "error decoding ''") {
-			return errors.Unwrap(err)
+			err = errors.Unwrap(err)
 		}
-		return err
+		// The names of the mapstructure errors are relative to the decoded configuration.
+		return &KeyError{Err: err}
 	}
 	return nil
 }
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/confmap/internal"
+
+// KeyError is an error about a key of the configuration, the keys referenced by the wrapped error are
+// relative to it. An empty key is the configuration being decoded, see Decode. The message of a KeyError
+// is the message of the wrapped error.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
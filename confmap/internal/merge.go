@@ -4,37 +4,217 @@
 package internal // import "go.opentelemetry.io/collector/confmap/internal"
 
 import (
+	"fmt"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/gobwas/glob"
 	"github.com/knadh/koanf/maps"
+	"go.yaml.in/yaml/v3"
 )
 
+// MergeMode is the way the lists of a configuration are merged into the lists of the previous configurations.
+type MergeMode string
+
+const (
+	// MergeModeReplace replaces the previous list, this is the default behavior.
+	MergeModeReplace MergeMode = "replace"
+	// MergeModeAppend appends the list to the previous list.
+	MergeModeAppend MergeMode = "append"
+	// MergeModePrepend prepends the list to the previous list.
+	MergeModePrepend MergeMode = "prepend"
+	// MergeModeUnion appends the elements of the list that are not in the previous list.
+	MergeModeUnion MergeMode = "union"
+)
+
+func (m MergeMode) validate() error {
+	switch m {
+	case MergeModeReplace, MergeModeAppend, MergeModePrepend, MergeModeUnion:
+		return nil
+	}
+	return fmt.Errorf("invalid merge mode %q: must be one of %q, %q, %q or %q",
+		m, MergeModeAppend, MergeModePrepend, MergeModeReplace, MergeModeUnion)
+}
+
+// MergeStrategy is the merge mode of the lists whose path matches a glob pattern.
+type MergeStrategy struct {
+	Pattern string
+	Mode    MergeMode
+	glob    glob.Glob
+}
+
+// NewMergeStrategy returns the strategy merging the lists matching the pattern, e.g. "service::**::processors".
+func NewMergeStrategy(pattern string, mode MergeMode) (MergeStrategy, error) {
+	if err := mode.validate(); err != nil {
+		return MergeStrategy{}, err
+	}
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		return MergeStrategy{}, fmt.Errorf("invalid merge path %q: %w", pattern, err)
+	}
+	return MergeStrategy{Pattern: pattern, Mode: mode, glob: g}, nil
+}
+
+// defaultMergePatterns are the paths of the component lists, merged when the
+// confmap.enableMergeAppendOption feature gate is enabled.
+var defaultMergePatterns = []string{
+	"service::extensions",
+	"service::**::receivers",
+	"service::**::exporters",
+}
+
+// defaultMergeStrategies deduplicate the component lists.
+var defaultMergeStrategies = newDefaultMergeStrategies()
+
+func newDefaultMergeStrategies() []MergeStrategy {
+	strategies := make([]MergeStrategy, 0, len(defaultMergePatterns))
+	for _, p := range defaultMergePatterns {
+		s, err := NewMergeStrategy(p, MergeModeUnion)
+		if err != nil {
+			panic(err)
+		}
+		strategies = append(strategies, s)
+	}
+	return strategies
+}
+
+// Merge URI parameters, see docs/rfcs/configuration-merging-strategy.md.
+const (
+	mergeModeParam  = "merge_mode"
+	mergePathsParam = "merge_paths"
+)
+
+// ParseMergeParams removes the merge parameters from the query of the URI, and returns the strategies they
+// describe. The URI is returned unchanged if its query has no merge parameter.
+func ParseMergeParams(uri string) (string, []MergeStrategy, error) {
+	idx := strings.LastIndexByte(uri, '?')
+	if idx < 0 {
+		return uri, nil, nil
+	}
+	query, err := url.ParseQuery(uri[idx+1:])
+	if err != nil || (!query.Has(mergeModeParam) && !query.Has(mergePathsParam)) {
+		// Not a query with merge parameters, e.g. a path containing a '?'.
+		return uri, nil, nil
+	}
+
+	mode := MergeModeAppend
+	if query.Has(mergeModeParam) {
+		mode = MergeMode(query.Get(mergeModeParam))
+	}
+	// Without paths, the component lists are merged.
+	patterns := defaultMergePatterns
+	if query.Has(mergePathsParam) {
+		patterns = strings.Split(query.Get(mergePathsParam), ",")
+	}
+	strategies := make([]MergeStrategy, 0, len(patterns))
+	for _, p := range patterns {
+		s, err := NewMergeStrategy(strings.TrimSpace(p), mode)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid merge parameters in uri %q: %w", uri, err)
+		}
+		strategies = append(strategies, s)
+	}
+
+	query.Del(mergeModeParam)
+	query.Del(mergePathsParam)
+	if len(query) == 0 {
+		return uri[:idx], strategies, nil
+	}
+	return uri[:idx+1] + query.Encode(), strategies, nil
+}
+
+// MergeStrategiesFromYAML returns the strategies set by the merge tags of a YAML document, e.g.
+// `extensions: !mode=append [health_check]` or `service: !mode=prepend&recursive=true`.
+// The tags which are not merge tags are ignored.
+func MergeStrategiesFromYAML(yamlBytes []byte) ([]MergeStrategy, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &doc); err != nil || len(doc.Content) == 0 {
+		return nil, nil //nolint:nilerr // Invalid documents are reported when they are decoded.
+	}
+	var strategies []MergeStrategy
+	err := collectMergeTags(doc.Content[0], "", &strategies)
+	return strategies, err
+}
+
+func collectMergeTags(node *yaml.Node, path string, strategies *[]MergeStrategy) error {
+	if strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		s, ok, err := parseMergeTag(node, path)
+		if err != nil {
+			return err
+		}
+		if ok {
+			*strategies = append(*strategies, s)
+		}
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := glob.QuoteMeta(node.Content[i].Value)
+		if path != "" {
+			key = path + KeyDelimiter + key
+		}
+		if err := collectMergeTags(node.Content[i+1], key, strategies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseMergeTag(node *yaml.Node, path string) (MergeStrategy, bool, error) {
+	options, err := url.ParseQuery(node.Tag[1:])
+	if err != nil || !options.Has("mode") {
+		return MergeStrategy{}, false, nil
+	}
+	recursive := false
+	if options.Has("recursive") {
+		if recursive, err = strconv.ParseBool(options.Get("recursive")); err != nil {
+			return MergeStrategy{}, false, fmt.Errorf("invalid merge tag %q at line %d: invalid recursive option: %w", node.Tag, node.Line, err)
+		}
+	}
+	switch {
+	case node.Kind == yaml.MappingNode && !recursive:
+		return MergeStrategy{}, false, fmt.Errorf("invalid merge tag %q at line %d: a map requires the recursive=true option", node.Tag, node.Line)
+	case node.Kind == yaml.MappingNode && path == "":
+		path = "*"
+	case node.Kind == yaml.MappingNode:
+		path += KeyDelimiter + "*"
+	case node.Kind != yaml.SequenceNode:
+		return MergeStrategy{}, false, fmt.Errorf("invalid merge tag %q at line %d: only lists and maps can be merged", node.Tag, node.Line)
+	}
+	s, err := NewMergeStrategy(path, MergeMode(options.Get("mode")))
+	if err != nil {
+		return MergeStrategy{}, false, fmt.Errorf("invalid merge tag %q at line %d: %w", node.Tag, node.Line, err)
+	}
+	return s, true, nil
+}
+
 func mergeAppend(src, dest map[string]any) error {
 	// mergeAppend recursively merges the src map into the dest map (left to right),
 	// modifying and expanding the dest map in the process.
 	// This function does not overwrite component lists, and ensures that the
 	// final value is a name-aware copy of lists from src and dest.
+	return mergeLists(src, dest, defaultMergeStrategies)
+}
 
-	// Compile the globs once
-	patterns := []string{
-		"service::extensions",
-		"service::**::receivers",
-		"service::**::exporters",
-	}
-	var globs []glob.Glob
-	for _, p := range patterns {
-		if g, err := glob.Compile(p); err == nil {
-			globs = append(globs, g)
-		}
+// newMergeFunc returns the koanf merge function merging the lists with the first matching strategy,
+// the other values are merged the default way.
+func newMergeFunc(strategies []MergeStrategy) func(src, dest map[string]any) error {
+	return func(src, dest map[string]any) error {
+		return mergeLists(src, dest, strategies)
 	}
+}
 
+func mergeLists(src, dest map[string]any, strategies []MergeStrategy) error {
 	// Flatten both source and destination maps
 	srcFlat, _ := maps.Flatten(src, []string{}, KeyDelimiter)
 	destFlat, _ := maps.Flatten(dest, []string{}, KeyDelimiter)
 
 	for sKey, sVal := range srcFlat {
-		if !isMatch(sKey, globs) {
+		mode, ok := matchMode(sKey, strategies)
+		if !ok || mode == MergeModeReplace {
 			continue
 		}
 
@@ -46,8 +226,16 @@ func mergeAppend(src, dest map[string]any) error {
 		srcVal := reflect.ValueOf(sVal)
 		destVal := reflect.ValueOf(dVal)
 
-		// Only merge if the value is a slice or array; let maps.Merge handle other types
-		if srcVal.Kind() == reflect.Slice || srcVal.Kind() == reflect.Array {
+		// Only merge if the values are slices or arrays; let maps.Merge handle other types
+		if !isList(srcVal) || !isList(destVal) {
+			continue
+		}
+		switch mode {
+		case MergeModeAppend:
+			srcFlat[sKey] = concatSlices(destVal, srcVal)
+		case MergeModePrepend:
+			srcFlat[sKey] = concatSlices(srcVal, destVal)
+		case MergeModeUnion:
 			srcFlat[sKey] = mergeSlice(srcVal, destVal)
 		}
 	}
@@ -59,14 +247,29 @@ func mergeAppend(src, dest map[string]any) error {
 	return nil
 }
 
-// isMatch checks if a key matches any glob in the list
-func isMatch(key string, globs []glob.Glob) bool {
-	for _, g := range globs {
-		if g.Match(key) {
-			return true
+// matchMode returns the mode of the first strategy matching the key.
+func matchMode(key string, strategies []MergeStrategy) (MergeMode, bool) {
+	for _, s := range strategies {
+		if s.glob.Match(key) {
+			return s.Mode, true
 		}
 	}
-	return false
+	return "", false
+}
+
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+func concatSlices(first, second reflect.Value) any {
+	slice := make([]any, 0, first.Len()+second.Len())
+	for i := 0; i < first.Len(); i++ {
+		slice = append(slice, first.Index(i).Interface())
+	}
+	for i := 0; i < second.Len(); i++ {
+		slice = append(slice, second.Index(i).Interface())
+	}
+	return slice
 }
 
 func mergeSlice(src, dest reflect.Value) any {
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/internal/metadata"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func patterns(strategies []MergeStrategy) map[string]MergeMode {
+	m := map[string]MergeMode{}
+	for _, s := range strategies {
+		m[s.Pattern] = s.Mode
+	}
+	return m
+}
+
+func TestParseMergeParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		expectedURI string
+		expected    map[string]MergeMode
+		expectedErr string
+	}{
+		{
+			name:        "no query",
+			uri:         "file:config.yaml",
+			expectedURI: "file:config.yaml",
+		},
+		{
+			name:        "other parameters",
+			uri:         "https://example.com/config.yaml?token=abc",
+			expectedURI: "https://example.com/config.yaml?token=abc",
+		},
+		{
+			name:        "default paths",
+			uri:         "file:extra.yaml?merge_mode=union",
+			expectedURI: "file:extra.yaml",
+			expected: map[string]MergeMode{
+				"service::extensions":    MergeModeUnion,
+				"service::**::receivers": MergeModeUnion,
+				"service::**::exporters": MergeModeUnion,
+			},
+		},
+		{
+			name:        "default mode",
+			uri:         "extra.yaml?merge_paths=service::**::processors,service::extensions",
+			expectedURI: "extra.yaml",
+			expected: map[string]MergeMode{
+				"service::**::processors": MergeModeAppend,
+				"service::extensions":     MergeModeAppend,
+			},
+		},
+		{
+			name:        "other parameters are kept",
+			uri:         "https://example.com/config.yaml?merge_mode=prepend&merge_paths=service::**::processors&token=abc",
+			expectedURI: "https://example.com/config.yaml?token=abc",
+			expected:    map[string]MergeMode{"service::**::processors": MergeModePrepend},
+		},
+		{
+			name:        "invalid mode",
+			uri:         "file:extra.yaml?merge_mode=merge",
+			expectedErr: `invalid merge parameters in uri "file:extra.yaml?merge_mode=merge": invalid merge mode "merge": must be one of "append", "prepend", "replace" or "union"`,
+		},
+		{
+			name:        "invalid path",
+			uri:         "file:extra.yaml?merge_paths=service::[",
+			expectedErr: `invalid merge parameters in uri "file:extra.yaml?merge_paths=service::[": invalid merge path "service::["`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, strategies, err := ParseMergeParams(tt.uri)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedURI, uri)
+			if tt.expected == nil {
+				assert.Empty(t, strategies)
+				return
+			}
+			assert.Equal(t, tt.expected, patterns(strategies))
+		})
+	}
+}
+
+func TestMergeStrategiesFromYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		expected    map[string]MergeMode
+		expectedErr string
+	}{
+		{
+			name: "list tags",
+			yaml: `
+service:
+  extensions: !mode=append [health_check]
+  pipelines:
+    traces:
+      processors: !mode=prepend [memory_limiter]
+      exporters: !custom [debug]
+`,
+			expected: map[string]MergeMode{
+				"service::extensions":                   MergeModeAppend,
+				"service::pipelines::traces::processors": MergeModePrepend,
+			},
+		},
+		{
+			name: "recursive tag",
+			yaml: `
+service: !mode=union&recursive=true
+  extensions: [health_check]
+`,
+			expected: map[string]MergeMode{"service::*": MergeModeUnion},
+		},
+		{
+			name: "quoted keys",
+			yaml: `
+service:
+  pipelines:
+    traces/[a]:
+      processors: !mode=append [batch]
+`,
+			expected: map[string]MergeMode{`service::pipelines::traces/\[a\]::processors`: MergeModeAppend},
+		},
+		{
+			name:        "map without recursive",
+			yaml:        "service: !mode=append\n  extensions: [health_check]\n",
+			expectedErr: `invalid merge tag "!mode=append" at line 1: a map requires the recursive=true option`,
+		},
+		{
+			name:        "scalar",
+			yaml:        "service:\n  telemetry: !mode=append value\n",
+			expectedErr: `invalid merge tag "!mode=append" at line 2: only lists and maps can be merged`,
+		},
+		{
+			name:        "invalid mode",
+			yaml:        "service:\n  extensions: !mode=merge [health_check]\n",
+			expectedErr: `invalid merge tag "!mode=merge" at line 2: invalid merge mode "merge"`,
+		},
+		{
+			name:        "invalid recursive",
+			yaml:        "service: !mode=append&recursive=yes\n  extensions: [health_check]\n",
+			expectedErr: `invalid merge tag "!mode=append&recursive=yes" at line 1: invalid recursive option`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategies, err := MergeStrategiesFromYAML([]byte(tt.yaml))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, patterns(strategies))
+		})
+	}
+}
+
+func TestMergeWithStrategies(t *testing.T) {
+	base := map[string]any{
+		"service": map[string]any{
+			"extensions": []any{"a", "b"},
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"otlp"},
+					"processors": []any{"batch"},
+					"exporters":  []any{"debug"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		pattern  string
+		mode     MergeMode
+		path     string
+		src      []any
+		expected []any
+	}{
+		{
+			name:     "append",
+			pattern:  "service::**::processors",
+			mode:     MergeModeAppend,
+			path:     "service::pipelines::traces::processors",
+			src:      []any{"batch", "transform"},
+			expected: []any{"batch", "batch", "transform"},
+		},
+		{
+			name:     "prepend",
+			pattern:  "service::**::processors",
+			mode:     MergeModePrepend,
+			path:     "service::pipelines::traces::processors",
+			src:      []any{"memory_limiter"},
+			expected: []any{"memory_limiter", "batch"},
+		},
+		{
+			name:     "union",
+			pattern:  "service::extensions",
+			mode:     MergeModeUnion,
+			path:     "service::extensions",
+			src:      []any{"b", "c"},
+			expected: []any{"a", "b", "c"},
+		},
+		{
+			name:     "replace",
+			pattern:  "service::extensions",
+			mode:     MergeModeReplace,
+			path:     "service::extensions",
+			src:      []any{"c"},
+			expected: []any{"c"},
+		},
+		{
+			name:     "default strategies",
+			pattern:  "service::**::processors",
+			mode:     MergeModeAppend,
+			path:     "service::pipelines::traces::exporters",
+			src:      []any{"debug", "otlp_grpc"},
+			expected: []any{"debug", "otlp_grpc"},
+		},
+		{
+			name:     "recursive",
+			pattern:  "service::*",
+			mode:     MergeModeAppend,
+			path:     "service::pipelines::traces::receivers",
+			src:      []any{"otlp"},
+			expected: []any{"otlp", "otlp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewMergeStrategy(tt.pattern, tt.mode)
+			require.NoError(t, err)
+
+			in := NewFromStringMap(map[string]any{tt.path: tt.src})
+			// The strategies are ignored without the feature gate.
+			conf := NewFromStringMap(base)
+			require.NoError(t, MergeWithStrategies(conf, in, []MergeStrategy{strategy}))
+			assert.Equal(t, tt.src, conf.Get(tt.path))
+
+			require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), true))
+			defer func() {
+				require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), false))
+			}()
+			conf = NewFromStringMap(base)
+			require.NoError(t, MergeWithStrategies(conf, in, []MergeStrategy{strategy}))
+			assert.Equal(t, tt.expected, conf.Get(tt.path))
+		})
+	}
+}
+
+func TestMergeStrategiesIgnoreInvalidYAML(t *testing.T) {
+	strategies, err := MergeStrategiesFromYAML([]byte("service: [mode="))
+	require.NoError(t, err)
+	assert.Empty(t, strategies)
+}
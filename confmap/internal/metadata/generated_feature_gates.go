@@ -6,6 +6,14 @@ import (
 	"go.opentelemetry.io/collector/featuregate"
 )
 
+var ConfmapEnableExpansionModifiersFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"confmap.enableExpansionModifiers",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("Enables the default values, required values and transforms of the ${} expansion syntax, e.g. ${env:NAME:-default|upper}."),
+	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector/blob/main/confmap/README.md#expansion-modifiers"),
+	featuregate.WithRegisterFromVersion("v0.160.0"),
+)
+
 var ConfmapEnableMergeAppendOptionFeatureGate = featuregate.GlobalRegistry().MustRegister(
 	"confmap.enableMergeAppendOption",
 	featuregate.StageAlpha,
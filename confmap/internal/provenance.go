@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/confmap/internal"
+
+import (
+	"go.yaml.in/yaml/v3"
+)
+
+// KeyLinesFromYAML returns the line of every key path of a YAML document, e.g. "exporters::debug".
+// An invalid document has no lines.
+func KeyLinesFromYAML(yamlBytes []byte) map[string]int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	lines := map[string]int{}
+	collectKeyLines(doc.Content[0], "", lines)
+	return lines
+}
+
+func collectKeyLines(node *yaml.Node, path string, lines map[string]int) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if path != "" {
+			key = path + KeyDelimiter + key
+		}
+		lines[key] = node.Content[i].Line
+		collectKeyLines(node.Content[i+1], key, lines)
+	}
+}
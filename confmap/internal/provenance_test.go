@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLinesFromYAML(t *testing.T) {
+	lines := KeyLinesFromYAML([]byte(`
+receivers:
+  otlp:
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+`))
+	assert.Equal(t, map[string]int{
+		"receivers":                             2,
+		"receivers::otlp":                       3,
+		"service":                               5,
+		"service::pipelines":                    6,
+		"service::pipelines::traces":            7,
+		"service::pipelines::traces::receivers": 8,
+	}, lines)
+
+	assert.Nil(t, KeyLinesFromYAML([]byte("receivers: [otlp")))
+	assert.Empty(t, KeyLinesFromYAML([]byte("- otlp")))
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	"go.opentelemetry.io/collector/confmap/internal"
+)
+
+// Provenance describes where a value of the resolved configuration comes from.
+//
+// Experimental: This type is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+type Provenance struct {
+	// URI is the configuration URI the value was retrieved from, e.g. "file:config.yaml".
+	URI string
+	// Line is the line of the value in the retrieved YAML, or 0 when it is unknown.
+	Line int
+	// Expansion is the ${} reference the value was expanded from, e.g. "${env:ENDPOINT}",
+	// or empty when the value was not expanded.
+	Expansion string
+}
+
+// String returns the provenance as "<uri>[:<line>][ (expanded from <reference>)]".
+func (p Provenance) String() string {
+	s := p.URI
+	if p.Line > 0 {
+		s += ":" + strconv.Itoa(p.Line)
+	}
+	if p.Expansion != "" {
+		s += " (expanded from " + p.Expansion + ")"
+	}
+	return s
+}
+
+// recordProvenance records the uri as the provenance of every key path of the map, the values set by
+// a configuration override the provenance recorded for the previous configurations.
+func recordProvenance(provenance map[string]Provenance, prefix string, m map[string]any, uri string, lines map[string]int) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + KeyDelimiter + k
+		}
+		provenance[key] = Provenance{URI: uri, Line: lines[key]}
+		if sub, ok := v.(map[string]any); ok {
+			recordProvenance(provenance, key, sub, uri, lines)
+		}
+	}
+}
+
+// resolveProvenance returns the provenance of every key path of the resolved map. The keys without a
+// recorded provenance come from the expansion of a parent key, e.g. a ${file:...} reference to a map.
+func resolveProvenance(recorded map[string]Provenance, resolved map[string]any) map[string]Provenance {
+	provenance := make(map[string]Provenance, len(recorded))
+	var walk func(prefix string, m map[string]any, parent Provenance)
+	walk = func(prefix string, m map[string]any, parent Provenance) {
+		for k, v := range m {
+			key := k
+			if prefix != "" {
+				key = prefix + KeyDelimiter + k
+			}
+			p, ok := recorded[key]
+			if !ok {
+				p = parent
+			}
+			provenance[key] = p
+			if sub, ok := v.(map[string]any); ok {
+				walk(key, sub, p)
+			}
+		}
+	}
+	walk("", resolved, Provenance{})
+	return provenance
+}
+
+// NewKeyError returns an error about a key of the configuration being unmarshaled or validated, e.g. the
+// ID of a component, the keys referenced by err being relative to it. The error has the message of err,
+// ErrorKeys returns the key paths it references.
+//
+// Experimental: This function is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+func NewKeyError(key string, err error) error {
+	return &internal.KeyError{Key: key, Err: err}
+}
+
+// ErrorKeys returns the key paths, e.g. "receivers::otlp::protocols", referenced by an error returned by
+// Conf.Unmarshal or Validate. The paths are found from the fields of the decoding and validation errors,
+// and from the errors returned by NewKeyError, never from the error messages. A path may reference a key
+// which is not set, e.g. a list element, when the error is about a value which is not a map.
+//
+// Experimental: This function is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+func ErrorKeys(err error) []string {
+	var keys []string
+	collectErrorKeys(err, nil, nil, &keys)
+	return slices.Compact(keys)
+}
+
+// collectErrorKeys appends the key paths referenced by the error. The names of the mapstructure errors
+// are relative to base, the configuration being decoded, the other errors are relative to prefix.
+func collectErrorKeys(err error, base, prefix []string, keys *[]string) {
+	found := len(*keys)
+	switch e := err.(type) {
+	case nil:
+		return
+	case *internal.KeyError:
+		prefix = appendKey(prefix, e.Key)
+		collectErrorKeys(e.Err, prefix, prefix, keys)
+	case *mapstructure.DecodeError:
+		prefix = append(slices.Clip(base), splitFieldName(e.Name())...)
+		collectErrorKeys(e.Unwrap(), base, prefix, keys)
+	case pathError:
+		path := slices.Clone(e.path)
+		slices.Reverse(path)
+		prefix = append(slices.Clip(prefix), path...)
+		collectErrorKeys(e.err, prefix, prefix, keys)
+	case interface{ Unwrap() []error }:
+		for _, joined := range e.Unwrap() {
+			collectErrorKeys(joined, base, prefix, keys)
+		}
+	default:
+		collectErrorKeys(errors.Unwrap(err), base, prefix, keys)
+	}
+	// The error references the prefix if the errors it wraps reference no deeper key.
+	if len(*keys) == found && len(prefix) > 0 {
+		*keys = append(*keys, strings.Join(prefix, KeyDelimiter))
+	}
+}
+
+func appendKey(prefix []string, key string) []string {
+	if key == "" {
+		return prefix
+	}
+	return append(slices.Clip(prefix), strings.Split(key, KeyDelimiter)...)
+}
+
+// splitFieldName splits the name of a mapstructure field, e.g. "service.extensions[0]" or "receivers[otlp/a.b]",
+// into the keys of its path.
+func splitFieldName(name string) []string {
+	var segments []string
+	for name != "" {
+		switch name[0] {
+		case '.':
+			name = name[1:]
+		case '[':
+			end := strings.IndexByte(name, ']')
+			if end < 0 {
+				return append(segments, name[1:])
+			}
+			segments = append(segments, name[1:end])
+			name = name[end+1:]
+		default:
+			end := strings.IndexAny(name, ".[")
+			if end < 0 {
+				end = len(name)
+			}
+			segments = append(segments, name[:end])
+			name = name[end:]
+		}
+	}
+	return segments
+}
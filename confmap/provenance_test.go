@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorKeysComponent struct {
+	Endpoint string            `mapstructure:"endpoint"`
+	Timeout  int               `mapstructure:"timeout"`
+	Headers  map[string]string `mapstructure:"headers"`
+}
+
+type errorKeysComponents map[string]errorKeysComponent
+
+// Unmarshal unmarshals every component from its own Conf, like the configurations of the components.
+func (c *errorKeysComponents) Unmarshal(conf *Conf) error {
+	*c = errorKeysComponents{}
+	for id := range conf.ToStringMap() {
+		sub, err := conf.Sub(id)
+		if err != nil {
+			return err
+		}
+		var cfg errorKeysComponent
+		if err := sub.Unmarshal(&cfg); err != nil {
+			return NewKeyError(id, fmt.Errorf("error reading configuration: %w", err))
+		}
+		(*c)[id] = cfg
+	}
+	return nil
+}
+
+type errorKeysConfig struct {
+	Receivers errorKeysComponents `mapstructure:"receivers"`
+	Service   struct {
+		Extensions []string `mapstructure:"extensions"`
+		Workers    []int    `mapstructure:"workers"`
+	} `mapstructure:"service"`
+}
+
+func TestErrorKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     map[string]any
+		expected []string
+	}{
+		{
+			name:     "invalid_keys",
+			conf:     map[string]any{"service": map[string]any{"extension": []any{"zpages"}}},
+			expected: []string{"service"},
+		},
+		{
+			name:     "invalid_value",
+			conf:     map[string]any{"service": map[string]any{"workers": []any{1, "a"}}},
+			expected: []string{"service::workers::1"},
+		},
+		{
+			name: "component_field",
+			conf: map[string]any{"receivers": map[string]any{
+				"otlp/a.b": map[string]any{"timeout": "1s"},
+				"otlp":     map[string]any{"endpoint": "localhost:4317"},
+			}},
+			expected: []string{"receivers::otlp/a.b::timeout"},
+		},
+		{
+			name:     "map_value",
+			conf:     map[string]any{"receivers": map[string]any{"otlp": map[string]any{"headers": map[string]any{"a.b": []any{}}}}},
+			expected: []string{"receivers::otlp::headers::a.b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg errorKeysConfig
+			err := NewFromStringMap(tt.conf).Unmarshal(&cfg)
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, ErrorKeys(err))
+		})
+	}
+}
+
+func TestErrorKeysValidate(t *testing.T) {
+	cfg := struct {
+		Receivers map[string]*validatedConfig `mapstructure:"receivers"`
+		Service   keyValidatedConfig          `mapstructure:"service"`
+	}{
+		Receivers: map[string]*validatedConfig{"otlp": {Endpoint: ""}},
+	}
+	err := Validate(cfg)
+	require.Error(t, err)
+	assert.ElementsMatch(t, []string{"receivers::otlp", "service::pipelines::traces"}, ErrorKeys(err))
+
+	assert.Empty(t, ErrorKeys(errors.New("receivers::otlp: invalid")))
+	assert.Empty(t, ErrorKeys(nil))
+}
+
+type validatedConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+func (c *validatedConfig) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("the endpoint must be set")
+	}
+	return nil
+}
+
+type keyValidatedConfig struct{}
+
+func (keyValidatedConfig) Validate() error {
+	return NewKeyError("pipelines::traces", errors.New("references receiver \"x\" which is not configured"))
+}
@@ -4,12 +4,15 @@
 package confmap // import "go.opentelemetry.io/collector/confmap"
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap/internal"
 )
 
 // ProviderSettings are the settings to initialize a Provider.
@@ -112,6 +115,14 @@ type Retrieved struct {
 
 	stringRepresentation string
 	isSetString          bool
+
+	// mergeStrategies are set by the merge tags of the retrieved YAML.
+	mergeStrategies []internal.MergeStrategy
+	// keyLines are the lines of the keys of the retrieved YAML.
+	keyLines map[string]int
+	// provenance is the provenance of every key of a Retrieved merging several fragments, it overrides
+	// the provenance recorded from the URI and the keyLines.
+	provenance map[string]Provenance
 }
 
 type retrievedSettings struct {
@@ -175,7 +186,63 @@ func NewRetrievedFromYAML(yamlBytes []byte, opts ...RetrievedOption) (*Retrieved
 		opts = append(opts, withStringRepresentation(string(yamlBytes)))
 	}
 
-	return NewRetrieved(rawConf, opts...)
+	ret, err := NewRetrieved(rawConf, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rawConf.(map[string]any); ok {
+		ret.keyLines = internal.KeyLinesFromYAML(yamlBytes)
+	}
+	// Only parse the merge tags, e.g. `!mode=append`, of the documents which may have some.
+	if bytes.Contains(yamlBytes, []byte("mode=")) {
+		if ret.mergeStrategies, err = internal.MergeStrategiesFromYAML(yamlBytes); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// Fragment is a configuration retrieved from a source which is merged with other fragments into a single
+// Retrieved, e.g. a file of a directory.
+//
+// Experimental: This type is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+type Fragment struct {
+	// Source names the fragment in the provenance of its values, e.g. "conf.d/10-receivers.yaml".
+	Source string
+	// Retrieved is the configuration of the fragment, e.g. returned by NewRetrievedFromYAML.
+	Retrieved *Retrieved
+}
+
+// NewRetrievedFromFragments returns a new Retrieved instance merging the fragments in order, so a fragment
+// overrides the values set by the previous ones. The merge tags of a fragment apply when it is merged, and
+// when the merged configuration is merged with the other configurations. The provenance of every value is
+// the source and the line of the fragment setting it.
+//
+// Experimental: This function is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+func NewRetrievedFromFragments(fragments []Fragment, opts ...RetrievedOption) (*Retrieved, error) {
+	conf := New()
+	provenance := map[string]Provenance{}
+	var mergeStrategies []internal.MergeStrategy
+	for _, f := range fragments {
+		fragmentConf, err := f.Retrieved.AsConf()
+		if err != nil {
+			return nil, fmt.Errorf("invalid fragment %v: %w", f.Source, err)
+		}
+		if err := internal.MergeWithStrategies(conf, fragmentConf, f.Retrieved.mergeStrategies); err != nil {
+			return nil, fmt.Errorf("failed to merge the fragment %v: %w", f.Source, err)
+		}
+		recordProvenance(provenance, "", fragmentConf.ToStringMap(), f.Source, f.Retrieved.keyLines)
+		mergeStrategies = append(mergeStrategies, f.Retrieved.mergeStrategies...)
+	}
+	ret, err := NewRetrieved(conf.ToStringMap(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	ret.mergeStrategies = mergeStrategies
+	ret.provenance = provenance
+	return ret, nil
 }
 
 // NewRetrieved returns a new Retrieved instance that contains the data from the raw deserialized config.
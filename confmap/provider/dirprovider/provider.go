@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package dirprovider // import "go.opentelemetry.io/collector/confmap/provider/dirprovider"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/pollwatcher"
+)
+
+const schemeName = "dir"
+
+type provider struct {
+	logger        *zap.Logger
+	pollInterval  time.Duration
+	debounceDelay time.Duration
+	watchers      pollwatcher.Set
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads the configuration from the fragments
+// stored in a directory.
+//
+// This Provider supports "dir" scheme, and can be called with a "uri" that follows:
+//
+//	dir-uri		= "dir:" local-path
+//
+// The "local-path" can be relative or absolute, and it can be any OS supported format.
+//
+// Every "*.yaml" and "*.yml" file of the directory is a fragment, the files in the subdirectories and
+// the hidden files are ignored. The fragments are merged in the lexical order of their names, so a
+// fragment overrides the values set by the fragments sorted before it.
+//
+// Examples:
+// `dir:path/to/conf.d` - relative path (unix, windows)
+// `dir:/etc/otelcol/conf.d` - absolute path (unix, windows)
+//
+// When a watcher is passed to Retrieve, the directory is watched until the returned Retrieved is closed,
+// and the watcher is called once a fragment was added, removed or changed.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(ps confmap.ProviderSettings) confmap.Provider {
+	logger := ps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &provider{
+		logger:        logger,
+		pollInterval:  pollwatcher.DefaultPollInterval,
+		debounceDelay: pollwatcher.DefaultDebounceDelay,
+	}
+}
+
+func (dp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	// Clean the path before using it.
+	dir := filepath.Clean(uri[len(schemeName)+1:])
+	fragments, err := readFragments(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the directory %v: %w", uri, err)
+	}
+	if len(fragments) == 0 {
+		return nil, fmt.Errorf("no *.yaml fragment found in the directory %v", uri)
+	}
+	if watcher == nil {
+		return dp.merge(fragments)
+	}
+	closeFunc := dp.watchers.Add(pollwatcher.New(pollwatcher.Settings{
+		Source: dir,
+		Sum:    sumFragments(fragments),
+		Snapshot: func() (pollwatcher.Sum, error) {
+			current, readErr := readFragments(dir)
+			return sumFragments(current), readErr
+		},
+		PollInterval:  dp.pollInterval,
+		DebounceDelay: dp.debounceDelay,
+		Logger:        dp.logger,
+	}, watcher))
+	ret, err := dp.merge(fragments, confmap.WithRetrievedClose(closeFunc))
+	if err != nil {
+		return nil, errors.Join(err, closeFunc(context.Background()))
+	}
+	return ret, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (dp *provider) Shutdown(ctx context.Context) error {
+	return dp.watchers.Close(ctx)
+}
+
+// fragment is a configuration file of the directory.
+type fragment struct {
+	path    string
+	content []byte
+}
+
+// readFragments returns the fragments of the directory, sorted by name.
+func readFragments(dir string) ([]fragment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var fragments []fragment
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if ext := filepath.Ext(name); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		// Follow the symbolic links, e.g. the files of the Kubernetes ConfigMaps.
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fragments = append(fragments, fragment{path: path, content: content})
+	}
+	return fragments, nil
+}
+
+// sumFragments returns the digest of the fragments, so adding, removing or changing a fragment is a change.
+func sumFragments(fragments []fragment) pollwatcher.Sum {
+	h := sha256.New()
+	for _, f := range fragments {
+		contentSum := sha256.Sum256(f.content)
+		_, _ = h.Write([]byte(f.path))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(contentSum[:])
+	}
+	var sum pollwatcher.Sum
+	h.Sum(sum[:0])
+	return sum
+}
+
+// origin is the fragment setting a key.
+type origin struct {
+	path  string
+	isMap bool
+	value any
+}
+
+// merge merges the fragments in order, the errors name the fragments the conflicting keys come from. The
+// provenance of every value is the fragment file and line setting it.
+func (dp *provider) merge(fragments []fragment, opts ...confmap.RetrievedOption) (*confmap.Retrieved, error) {
+	origins := map[string]origin{}
+	retrieved := make([]confmap.Fragment, 0, len(fragments))
+	for _, f := range fragments {
+		var rawConf any
+		if err := yaml.Unmarshal(f.content, &rawConf); err != nil {
+			return nil, fmt.Errorf("invalid fragment %v: %w", f.path, err)
+		}
+		if rawConf == nil {
+			continue
+		}
+		m, ok := rawConf.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid fragment %v: the configuration must be a map, got %T", f.path, rawConf)
+		}
+		if err := dp.recordOrigins("", m, f.path, origins); err != nil {
+			return nil, err
+		}
+		ret, err := confmap.NewRetrievedFromYAML(f.content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fragment %v: %w", f.path, err)
+		}
+		retrieved = append(retrieved, confmap.Fragment{Source: f.path, Retrieved: ret})
+	}
+	return confmap.NewRetrievedFromFragments(retrieved, opts...)
+}
+
+// recordOrigins records the fragment setting every key of the map. A key set to a map by a fragment and to
+// another value by another fragment is an error, a value overridden by a later fragment is logged.
+func (dp *provider) recordOrigins(prefix string, m map[string]any, path string, origins map[string]origin) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + confmap.KeyDelimiter + k
+		}
+		v := m[k]
+		sub, isMap := v.(map[string]any)
+		// A key set to nil, e.g. a component using its default configuration, can be set by a later fragment.
+		if prev, ok := origins[key]; ok && prev.value != nil {
+			switch {
+			case v != nil && prev.isMap != isMap:
+				return fmt.Errorf("conflicting values for key %q in the fragments %v and %v: a map cannot be merged with another type",
+					key, prev.path, path)
+			case v == nil || (!isMap && !reflect.DeepEqual(prev.value, v)):
+				dp.logger.Warn("Configuration key overridden by a later fragment",
+					zap.String("key", key), zap.String("fragment", prev.path), zap.String("overridden_by", path))
+			}
+		}
+		origins[key] = origin{path: path, isMap: isMap, value: v}
+		if isMap {
+			if err := dp.recordOrigins(key, sub, path, origins); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
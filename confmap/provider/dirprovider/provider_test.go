@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dirprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+const dirSchemePrefix = schemeName + ":"
+
+func createProvider() confmap.Provider {
+	return NewFactory().Create(confmaptest.NewNopProviderSettings())
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(createProvider()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	dp := createProvider()
+	_, err := dp.Retrieve(context.Background(), "file:testdata", nil)
+	require.Error(t, err)
+	assert.NoError(t, dp.Shutdown(context.Background()))
+}
+
+func TestNonExistent(t *testing.T) {
+	dp := createProvider()
+	_, err := dp.Retrieve(context.Background(), dirSchemePrefix+filepath.Join("testdata", "non-existent"), nil)
+	require.ErrorContains(t, err, "unable to read the directory")
+	_, err = dp.Retrieve(context.Background(), dirSchemePrefix+t.TempDir(), nil)
+	require.ErrorContains(t, err, "no *.yaml fragment found")
+	require.NoError(t, dp.Shutdown(context.Background()))
+}
+
+func TestMergeFragments(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	dp := NewFactory().Create(confmap.ProviderSettings{Logger: zap.New(core)})
+	ret, err := dp.Retrieve(context.Background(), dirSchemePrefix+filepath.Join("testdata", "conf.d"), nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	expectedMap := confmap.NewFromStringMap(map[string]any{
+		"receivers::otlp::protocols::grpc":      nil,
+		"processors::batch::timeout":            "5s",
+		"exporters::otlp_grpc/team-a::endpoint": "team-a.example.com:4317",
+		"service::pipelines::traces::receivers": []any{"otlp"},
+		"service::pipelines::traces::exporters": []any{"otlp_grpc/team-a"},
+	})
+	assert.Equal(t, expectedMap.ToStringMap(), retMap.ToStringMap())
+
+	// The overridden value is logged with the fragments it comes from.
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "exporters::otlp_grpc/team-a::endpoint", fields["key"])
+	assert.Equal(t, filepath.Join("testdata", "conf.d", "10-team-a.yaml"), fields["fragment"])
+	assert.Equal(t, filepath.Join("testdata", "conf.d", "20-overrides.yml"), fields["overridden_by"])
+	require.NoError(t, dp.Shutdown(context.Background()))
+}
+
+func TestFragmentsProvenance(t *testing.T) {
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{dirSchemePrefix + filepath.Join("testdata", "conf.d")},
+		ProviderFactories: []confmap.ProviderFactory{NewFactory()},
+	})
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	// Every value names the fragment file and line setting it.
+	provenance := resolver.Provenance()
+	assert.Equal(t, filepath.Join("testdata", "conf.d", "00-receivers.yaml")+":4",
+		provenance["receivers::otlp::protocols::grpc"].String())
+	assert.Equal(t, filepath.Join("testdata", "conf.d", "10-team-a.yaml")+":3",
+		provenance["processors::batch::timeout"].String())
+	assert.Equal(t, filepath.Join("testdata", "conf.d", "20-overrides.yml")+":3",
+		provenance["exporters::otlp_grpc/team-a::endpoint"].String())
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestFragmentErrors(t *testing.T) {
+	dp := createProvider()
+	_, err := dp.Retrieve(context.Background(), dirSchemePrefix+filepath.Join("testdata", "invalid"), nil)
+	require.ErrorContains(t, err, "invalid fragment "+filepath.Join("testdata", "invalid", "b.yaml"))
+
+	_, err = dp.Retrieve(context.Background(), dirSchemePrefix+filepath.Join("testdata", "conflict"), nil)
+	require.EqualError(t, err, `conflicting values for key "exporters::debug" in the fragments `+
+		filepath.Join("testdata", "conflict", "a.yaml")+" and "+filepath.Join("testdata", "conflict", "b.yaml")+
+		": a map cannot be merged with another type")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "list.yaml"), []byte("- otlp\n"), 0o600))
+	_, err = dp.Retrieve(context.Background(), dirSchemePrefix+dir, nil)
+	require.ErrorContains(t, err, "the configuration must be a map")
+	require.NoError(t, dp.Shutdown(context.Background()))
+}
+
+func TestWatchFragments(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00-receivers.yaml"), []byte("receivers:\n  otlp:\n"), 0o600))
+
+	dp := createProvider().(*provider)
+	dp.pollInterval = 5 * time.Millisecond
+	dp.debounceDelay = 20 * time.Millisecond
+	retrieve := func() (*confmap.Retrieved, <-chan *confmap.ChangeEvent) {
+		events := make(chan *confmap.ChangeEvent, 1)
+		ret, err := dp.Retrieve(context.Background(), dirSchemePrefix+dir, func(event *confmap.ChangeEvent) {
+			events <- event
+		})
+		require.NoError(t, err)
+		return ret, events
+	}
+	waitForChange := func(events <-chan *confmap.ChangeEvent) {
+		select {
+		case event := <-events:
+			require.NoError(t, event.Error)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the change was not reported")
+		}
+	}
+
+	// Files that are not fragments are ignored.
+	ret, events := retrieve()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	// Adding a fragment is a change.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-exporters.yaml"), []byte("exporters:\n  debug:\n"), 0o600))
+	waitForChange(events)
+	require.NoError(t, ret.Close(context.Background()))
+	ret, events = retrieve()
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.True(t, retMap.IsSet("exporters::debug"))
+
+	// Removing a fragment is a change.
+	require.NoError(t, os.Remove(filepath.Join(dir, "10-exporters.yaml")))
+	waitForChange(events)
+	require.NoError(t, ret.Close(context.Background()))
+
+	// Shutdown releases the watches that were not closed.
+	_, events = retrieve()
+	require.NoError(t, dp.Shutdown(context.Background()))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-exporters.yaml"), []byte("exporters:\n  debug:\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+}
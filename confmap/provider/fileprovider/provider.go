@@ -7,17 +7,28 @@ package fileprovider // import "go.opentelemetry.io/collector/confmap/provider/f
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/pollwatcher"
 )
 
 const schemeName = "file"
 
-type provider struct{}
+type provider struct {
+	logger        *zap.Logger
+	pollInterval  time.Duration
+	debounceDelay time.Duration
+	watchers      pollwatcher.Set
+}
 
 // NewFactory returns a factory for a confmap.Provider that reads the configuration from a file.
 //
@@ -34,32 +45,65 @@ type provider struct{}
 // `file:/path/to/file` - absolute path (unix, windows)
 // `file:c:/path/to/file` - absolute path including drive-letter (windows)
 // `file:c:\path\to\file` - absolute path including drive-letter (windows)
+//
+// When a watcher is passed to Retrieve, the file is watched until the returned Retrieved is closed, and the
+// watcher is called once the file content changed, including when a symbolic link to the file is replaced.
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
 
-func newProvider(confmap.ProviderSettings) confmap.Provider {
-	return &provider{}
+func newProvider(ps confmap.ProviderSettings) confmap.Provider {
+	logger := ps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &provider{
+		logger:        logger,
+		pollInterval:  pollwatcher.DefaultPollInterval,
+		debounceDelay: pollwatcher.DefaultDebounceDelay,
+	}
 }
 
-func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+func (fmp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
 	if !strings.HasPrefix(uri, schemeName+":") {
 		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
 	}
 
 	// Clean the path before using it.
-	content, err := os.ReadFile(filepath.Clean(uri[len(schemeName)+1:]))
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
 	}
 
-	return confmap.NewRetrievedFromYAML(content)
+	if watcher == nil {
+		return confmap.NewRetrievedFromYAML(content)
+	}
+
+	// The file is read through its path, so replacing a symbolic link, as done by Kubernetes for the mounted
+	// ConfigMaps, is a change.
+	closeFunc := fmp.watchers.Add(pollwatcher.New(pollwatcher.Settings{
+		Source: path,
+		Sum:    sha256.Sum256(content),
+		Snapshot: func() (pollwatcher.Sum, error) {
+			current, readErr := os.ReadFile(path)
+			return sha256.Sum256(current), readErr
+		},
+		PollInterval:  fmp.pollInterval,
+		DebounceDelay: fmp.debounceDelay,
+		Logger:        fmp.logger,
+	}, watcher))
+	ret, err := confmap.NewRetrievedFromYAML(content, confmap.WithRetrievedClose(closeFunc))
+	if err != nil {
+		return nil, errors.Join(err, closeFunc(context.Background()))
+	}
+	return ret, nil
 }
 
 func (*provider) Scheme() string {
 	return schemeName
 }
 
-func (*provider) Shutdown(context.Context) error {
-	return nil
+func (fmp *provider) Shutdown(ctx context.Context) error {
+	return fmp.watchers.Close(ctx)
 }
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func createWatchingProvider() *provider {
+	fp := newProvider(confmaptest.NewNopProviderSettings()).(*provider)
+	fp.pollInterval = 5 * time.Millisecond
+	fp.debounceDelay = 20 * time.Millisecond
+	return fp
+}
+
+func retrieveWatched(t *testing.T, fp *provider, path string) (*confmap.Retrieved, <-chan *confmap.ChangeEvent) {
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	return ret, events
+}
+
+func TestWatchFileChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch:\n"), 0o600))
+
+	fp := createWatchingProvider()
+	ret, events := retrieveWatched(t, fp, path)
+
+	// Writing the same content is not a change.
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch:\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  memory_limiter:\n"), 0o600))
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not reported")
+	}
+
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchDebounce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch:\n"), 0o600))
+
+	fp := createWatchingProvider()
+	fp.debounceDelay = time.Hour
+	ret, events := retrieveWatched(t, fp, path)
+
+	// The change is not reported until the file stays unchanged for the debounce delay.
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"v1": "processors:\n  batch:\n", "v2": "processors:\n  memory_limiter:\n"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "config.yaml"), []byte(content), 0o600))
+	}
+	// Mimic the updates of the Kubernetes ConfigMaps, the data directory is an atomically replaced symbolic link.
+	dataDir := filepath.Join(dir, "..data")
+	if err := os.Symlink(filepath.Join(dir, "v1"), dataDir); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.Symlink(filepath.Join(dataDir, "config.yaml"), path))
+
+	fp := createWatchingProvider()
+	ret, events := retrieveWatched(t, fp, path)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"processors": map[string]any{"batch": nil}}, raw)
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(filepath.Join(dir, "v2"), tmpLink))
+	require.NoError(t, os.Rename(tmpLink, dataDir))
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not reported")
+	}
+	require.NoError(t, ret.Close(context.Background()))
+
+	ret, _ = retrieveWatched(t, fp, path)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"processors": map[string]any{"memory_limiter": nil}}, raw)
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
+func TestWatchClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch:\n"), 0o600))
+
+	fp := createWatchingProvider()
+	ret, events := retrieveWatched(t, fp, path)
+	require.NoError(t, ret.Close(context.Background()))
+	assert.Zero(t, fp.watchers.Len())
+
+	// The closed watch does not report the changes.
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  memory_limiter:\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	// Shutdown releases the watches that were not closed.
+	_, _ = retrieveWatched(t, fp, path)
+	assert.Equal(t, 1, fp.watchers.Len())
+	require.NoError(t, fp.Shutdown(context.Background()))
+	assert.Zero(t, fp.watchers.Len())
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch:\n"), 0o600))
+
+	fp := createWatchingProvider()
+	ret, events := retrieveWatched(t, fp, path)
+
+	// The configuration is kept while the file is missing.
+	require.NoError(t, os.Remove(path))
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  memory_limiter:\n"), 0o600))
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not reported")
+	}
+	require.NoError(t, ret.Close(context.Background()))
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
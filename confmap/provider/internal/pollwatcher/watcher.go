@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pollwatcher watches the configuration sources of the providers by polling them.
+package pollwatcher // import "go.opentelemetry.io/collector/confmap/provider/internal/pollwatcher"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// DefaultPollInterval is the interval at which the watched sources are checked for changes.
+	DefaultPollInterval = time.Second
+	// DefaultDebounceDelay is the time a changed source must stay unchanged before the change is reported,
+	// so the tools writing a source in several steps trigger a single reload.
+	DefaultDebounceDelay = time.Second
+)
+
+// Sum is the digest of the content of a watched source.
+type Sum = [sha256.Size]byte
+
+// SnapshotFunc returns the digest of the current content of a watched source.
+type SnapshotFunc func() (Sum, error)
+
+// Settings configures a Watcher.
+type Settings struct {
+	// Source names the watched source in the logs, e.g. a path.
+	Source string
+	// Sum is the digest of the retrieved content, changes are reported relative to it.
+	Sum Sum
+	// Snapshot returns the digest of the current content.
+	Snapshot SnapshotFunc
+
+	PollInterval  time.Duration
+	DebounceDelay time.Duration
+	Logger        *zap.Logger
+}
+
+// Watcher polls a source and calls the watcher once its content changed.
+type Watcher struct {
+	set     Settings
+	watcher confmap.WatcherFunc
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// New starts watching the source described by the settings.
+func New(set Settings, watcher confmap.WatcherFunc) *Watcher {
+	w := &Watcher{
+		set:     set,
+		watcher: watcher,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *Watcher) run() {
+	defer close(w.doneCh)
+	ticker := time.NewTicker(w.set.PollInterval)
+	defer ticker.Stop()
+
+	var pendingSum Sum
+	var pendingSince time.Time
+	reportedErr := false
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		sum, err := w.set.Snapshot()
+		if err != nil {
+			// The source may be missing while it is replaced, keep the current configuration until it is back.
+			if !reportedErr {
+				w.set.Logger.Warn("Failed to read the watched configuration", zap.String("source", w.set.Source), zap.Error(err))
+				reportedErr = true
+			}
+			pendingSince = time.Time{}
+			continue
+		}
+		reportedErr = false
+
+		switch {
+		case sum == w.set.Sum:
+			pendingSince = time.Time{}
+		case pendingSince.IsZero() || sum != pendingSum:
+			pendingSum = sum
+			pendingSince = time.Now()
+		case time.Since(pendingSince) >= w.set.DebounceDelay:
+			w.set.Logger.Info("Configuration changed", zap.String("source", w.set.Source))
+			// The watch ends here, the new content is watched after it is retrieved again.
+			w.watcher(&confmap.ChangeEvent{})
+			return
+		}
+	}
+}
+
+// Close stops the watch and waits for the watcher goroutine to return.
+func (w *Watcher) Close(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stopCh) })
+	select {
+	case <-w.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Set tracks the active watchers of a provider, so they are released on shutdown.
+type Set struct {
+	mu       sync.Mutex
+	watchers map[*Watcher]struct{}
+}
+
+// Add tracks the watcher and returns the function closing it, to be set on the retrieved configuration.
+func (s *Set) Add(w *Watcher) confmap.CloseFunc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watchers == nil {
+		s.watchers = map[*Watcher]struct{}{}
+	}
+	s.watchers[w] = struct{}{}
+	return func(ctx context.Context) error {
+		s.mu.Lock()
+		delete(s.watchers, w)
+		s.mu.Unlock()
+		return w.Close(ctx)
+	}
+}
+
+// Len returns the number of active watchers.
+func (s *Set) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.watchers)
+}
+
+// Close closes all the active watchers.
+func (s *Set) Close(ctx context.Context) error {
+	s.mu.Lock()
+	watchers := s.watchers
+	s.watchers = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for w := range watchers {
+		errs = append(errs, w.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
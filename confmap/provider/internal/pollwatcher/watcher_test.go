@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pollwatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// source is a watched source whose content is set by the tests.
+type source struct {
+	mu      sync.Mutex
+	content string
+	err     error
+}
+
+func (s *source) set(content string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content, s.err = content, err
+}
+
+func (s *source) snapshot() (Sum, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sha256.Sum256([]byte(s.content)), s.err
+}
+
+func newTestWatcher(src *source, debounceDelay time.Duration) (*Watcher, <-chan *confmap.ChangeEvent) {
+	events := make(chan *confmap.ChangeEvent, 1)
+	w := New(Settings{
+		Source:        "test",
+		Sum:           sha256.Sum256([]byte(src.content)),
+		Snapshot:      src.snapshot,
+		PollInterval:  time.Millisecond,
+		DebounceDelay: debounceDelay,
+		Logger:        zap.NewNop(),
+	}, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	return w, events
+}
+
+func TestWatcherReportsChange(t *testing.T) {
+	src := &source{content: "v1"}
+	w, events := newTestWatcher(src, 10*time.Millisecond)
+
+	// Errors and reverted changes are not reported.
+	src.set("v1", errors.New("missing"))
+	time.Sleep(20 * time.Millisecond)
+	src.set("v2", nil)
+	time.Sleep(5 * time.Millisecond)
+	src.set("v1", nil)
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, events)
+
+	src.set("v2", nil)
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not reported")
+	}
+	require.NoError(t, w.Close(context.Background()))
+}
+
+func TestWatcherDebounce(t *testing.T) {
+	src := &source{content: "v1"}
+	w, events := newTestWatcher(src, time.Hour)
+
+	src.set("v2", nil)
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, events)
+	require.NoError(t, w.Close(context.Background()))
+	// Closing twice is safe.
+	require.NoError(t, w.Close(context.Background()))
+}
+
+func TestSet(t *testing.T) {
+	var set Set
+	first, _ := newTestWatcher(&source{content: "v1"}, time.Hour)
+	second, _ := newTestWatcher(&source{content: "v1"}, time.Hour)
+	closeFirst := set.Add(first)
+	set.Add(second)
+	assert.Equal(t, 2, set.Len())
+
+	require.NoError(t, closeFirst(context.Background()))
+	assert.Equal(t, 1, set.Len())
+	require.NoError(t, set.Close(context.Background()))
+	assert.Zero(t, set.Len())
+	require.NoError(t, set.Close(context.Background()))
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command encryptsecrets creates the secrets file read by the secret provider. It encrypts a YAML map of the
+// secret names to their values with the key stored in a file, encoded in base64, e.g. created with
+// `openssl rand -base64 32 > secrets.key`:
+//
+//	encryptsecrets -key-file secrets.key -o secrets.enc secrets.yaml
+//
+// The secrets are read from the standard input when no file is given, and the secrets file is written to the
+// standard output when -o is not set.
+package main // import "go.opentelemetry.io/collector/confmap/provider/secretprovider/cmd/encryptsecrets"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/confmap/provider/secretprovider"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run encrypts the secrets as set by the arguments.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("encryptsecrets", flag.ContinueOnError)
+	keyFile := flags.String("key-file", "", "the path of the file storing the key, encoded in base64, required")
+	output := flags.String("o", "", "the path of the secrets file to write, the standard output by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *keyFile == "" {
+		return errors.New("the -key-file flag is required")
+	}
+	if flags.NArg() > 1 {
+		return errors.New("at most one secrets file can be encrypted")
+	}
+
+	encoded, err := os.ReadFile(filepath.Clean(*keyFile))
+	if err != nil {
+		return fmt.Errorf("unable to read the key file: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return fmt.Errorf("invalid key encoding, the key must be encoded in base64: %w", err)
+	}
+
+	var secrets []byte
+	if flags.NArg() == 1 {
+		secrets, err = os.ReadFile(filepath.Clean(flags.Arg(0)))
+	} else {
+		secrets, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read the secrets: %w", err)
+	}
+
+	content, err := secretprovider.Encrypt(key, secrets)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = stdout.Write(content)
+		return err
+	}
+	return os.WriteFile(*output, content, 0o600)
+}
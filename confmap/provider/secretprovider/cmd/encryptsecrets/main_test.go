@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/provider/secretprovider"
+)
+
+func writeKey(t *testing.T, dir string) string {
+	key := make([]byte, secretprovider.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "secrets.key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+	return path
+}
+
+// retrieve returns the secret read by the secret provider from the secrets file.
+func retrieve(t *testing.T, secretsFile, keyFile, name string) string {
+	t.Setenv("OTELCOL_SECRETS_FILE", secretsFile)
+	t.Setenv("OTELCOL_SECRETS_KEY_FILE", keyFile)
+	sp := secretprovider.NewFactory().Create(confmaptest.NewNopProviderSettings())
+	ret, err := sp.Retrieve(context.Background(), "secret:"+name, nil)
+	require.NoError(t, err)
+	str, err := ret.AsString()
+	require.NoError(t, err)
+	require.NoError(t, sp.Shutdown(context.Background()))
+	return str
+}
+
+func TestRunFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := writeKey(t, dir)
+	secretsYAML := filepath.Join(dir, "secrets.yaml")
+	require.NoError(t, os.WriteFile(secretsYAML, []byte("db_password: s3cr3t\npin: 0123\n"), 0o600))
+	secretsFile := filepath.Join(dir, "secrets.enc")
+
+	require.NoError(t, run([]string{"-key-file", keyFile, "-o", secretsFile, secretsYAML}, nil, nil))
+	info, err := os.Stat(secretsFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.Equal(t, "s3cr3t", retrieve(t, secretsFile, keyFile, "db_password"))
+	assert.Equal(t, "0123", retrieve(t, secretsFile, keyFile, "pin"))
+}
+
+func TestRunStdin(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := writeKey(t, dir)
+	stdout := &bytes.Buffer{}
+	require.NoError(t, run([]string{"-key-file", keyFile}, strings.NewReader("api_token: t0ken\n"), stdout))
+
+	secretsFile := filepath.Join(dir, "secrets.enc")
+	require.NoError(t, os.WriteFile(secretsFile, stdout.Bytes(), 0o600))
+	assert.Equal(t, "t0ken", retrieve(t, secretsFile, keyFile, "api_token"))
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := writeKey(t, dir)
+	invalidKeyFile := filepath.Join(dir, "invalid.key")
+	require.NoError(t, os.WriteFile(invalidKeyFile, []byte("not base64"), 0o600))
+
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		expectedErr string
+	}{
+		{name: "no_key", expectedErr: "the -key-file flag is required"},
+		{name: "unknown_flag", args: []string{"-key", keyFile}, expectedErr: "flag provided but not defined: -key"},
+		{name: "several_files", args: []string{"-key-file", keyFile, "a.yaml", "b.yaml"}, expectedErr: "at most one secrets file"},
+		{name: "missing_key_file", args: []string{"-key-file", filepath.Join(dir, "missing")}, expectedErr: "unable to read the key file"},
+		{name: "invalid_key", args: []string{"-key-file", invalidKeyFile}, expectedErr: "invalid key encoding"},
+		{name: "missing_secrets", args: []string{"-key-file", keyFile, filepath.Join(dir, "missing.yaml")}, expectedErr: "unable to read the secrets"},
+		{name: "invalid_secrets", args: []string{"-key-file", keyFile}, stdin: "- a\n", expectedErr: "the secrets must be a map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(tt.args, strings.NewReader(tt.stdin), &bytes.Buffer{})
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package secretprovider // import "go.opentelemetry.io/collector/confmap/provider/secretprovider"
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal/pollwatcher"
+)
+
+const (
+	schemeName = "secret"
+
+	// fileEnvVar is the path of the secrets file.
+	fileEnvVar = "OTELCOL_SECRETS_FILE"
+	// keyEnvVar is the key of the secrets file, encoded in base64.
+	keyEnvVar = "OTELCOL_SECRETS_KEY"
+	// keyFileEnvVar is the path of a file storing the key of the secrets file, encoded in base64.
+	keyFileEnvVar = "OTELCOL_SECRETS_KEY_FILE"
+	// pollIntervalEnvVar is the interval at which the secrets file is re-read, the file is not
+	// watched when it is not set.
+	pollIntervalEnvVar = "OTELCOL_SECRETS_POLL_INTERVAL"
+)
+
+type provider struct {
+	logger        *zap.Logger
+	debounceDelay time.Duration
+	watchers      pollwatcher.Set
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads the secrets from a local encrypted file.
+//
+// This Provider supports "secret" scheme, and can be called with a "uri" that follows:
+//
+//	secret-uri = "secret:" name
+//
+// The secrets file is set by the OTELCOL_SECRETS_FILE environment variable, and its key by either the
+// OTELCOL_SECRETS_KEY environment variable or the file set by the OTELCOL_SECRETS_KEY_FILE one. The file is
+// created with Encrypt.
+//
+// Examples:
+// `secret:db_password` - the value of the "db_password" secret.
+//
+// The secrets are returned as strings, as written in the secrets file. When the OTELCOL_SECRETS_POLL_INTERVAL
+// environment variable is set and a watcher is passed to Retrieve, the secret is re-read at that interval
+// until the returned Retrieved is closed, and the watcher is called once its value changed.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(ps confmap.ProviderSettings) confmap.Provider {
+	logger := ps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &provider{
+		logger:        logger,
+		debounceDelay: pollwatcher.DefaultDebounceDelay,
+	}
+}
+
+func (sp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	name := uri[len(schemeName)+1:]
+	if name == "" {
+		return nil, fmt.Errorf("invalid uri %q: the secret name is empty", uri)
+	}
+
+	path, ok := os.LookupEnv(fileEnvVar)
+	if !ok {
+		return nil, fmt.Errorf("unable to read the secret %q: %s is not set", name, fileEnvVar)
+	}
+	// Clean the path before using it.
+	path = filepath.Clean(path)
+	value, err := readSecret(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pollInterval, err := parsePollInterval()
+	if err != nil {
+		return nil, err
+	}
+	if watcher == nil || pollInterval == 0 {
+		return confmap.NewRetrieved(value)
+	}
+	closeFunc := sp.watchers.Add(pollwatcher.New(pollwatcher.Settings{
+		// The name of the secret is logged, never its value.
+		Source: path + "#" + name,
+		Sum:    sha256.Sum256([]byte(value)),
+		Snapshot: func() (pollwatcher.Sum, error) {
+			current, readErr := readSecret(path, name)
+			return sha256.Sum256([]byte(current)), readErr
+		},
+		PollInterval:  pollInterval,
+		DebounceDelay: min(sp.debounceDelay, pollInterval),
+		Logger:        sp.logger,
+	}, watcher))
+	ret, err := confmap.NewRetrieved(value, confmap.WithRetrievedClose(closeFunc))
+	if err != nil {
+		return nil, errors.Join(err, closeFunc(context.Background()))
+	}
+	return ret, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (sp *provider) Shutdown(ctx context.Context) error {
+	return sp.watchers.Close(ctx)
+}
+
+// readSecret returns the value of the secret stored in the secrets file.
+func readSecret(path, name string) (string, error) {
+	key, err := readKey()
+	if err != nil {
+		return "", fmt.Errorf("unable to read the secret %q: %w", name, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the secrets file %v: %w", path, err)
+	}
+	secrets, err := decrypt(key, content)
+	if err != nil {
+		return "", fmt.Errorf("invalid secrets file %v: %w", path, err)
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found in the secrets file %v", name, path)
+	}
+	return value, nil
+}
+
+func parsePollInterval() (time.Duration, error) {
+	value, ok := os.LookupEnv(pollIntervalEnvVar)
+	if !ok || value == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration", pollIntervalEnvVar, value)
+	}
+	return interval, nil
+}
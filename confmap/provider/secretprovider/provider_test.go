@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+const secretSchemePrefix = schemeName + ":"
+
+func createProvider() confmap.Provider {
+	return NewFactory().Create(confmaptest.NewNopProviderSettings())
+}
+
+// writeSecrets writes the secrets encrypted with a new key, and sets the environment to read them.
+func writeSecrets(t *testing.T, secrets string) []byte {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	content, err := Encrypt(key, []byte(secrets))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	t.Setenv(fileEnvVar, path)
+	t.Setenv(keyEnvVar, base64.StdEncoding.EncodeToString(key))
+	return key
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(createProvider()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	sp := createProvider()
+	_, err := sp.Retrieve(context.Background(), "file:secrets", nil)
+	require.Error(t, err)
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix, nil)
+	require.ErrorContains(t, err, "the secret name is empty")
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestRetrieveSecret(t *testing.T) {
+	writeSecrets(t, "db_password: s3cr3t\npin: 0123\n")
+	sp := createProvider()
+
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+
+	// The values are strings, as written in the secrets file.
+	ret, err = sp.Retrieve(context.Background(), secretSchemePrefix+"pin", nil)
+	require.NoError(t, err)
+	str, err := ret.AsString()
+	require.NoError(t, err)
+	assert.Equal(t, "0123", str)
+
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	require.ErrorContains(t, err, `secret "api_key" not found in the secrets file`)
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestRetrieveSecretKeyFile(t *testing.T) {
+	key := writeSecrets(t, "db_password: s3cr3t\n")
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+	sp := createProvider()
+
+	// Only one of the key and the key file can be set.
+	t.Setenv(keyFileEnvVar, keyFile)
+	_, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", nil)
+	require.ErrorContains(t, err, "only one of OTELCOL_SECRETS_KEY and OTELCOL_SECRETS_KEY_FILE can be set")
+
+	require.NoError(t, os.Unsetenv(keyEnvVar))
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestRetrieveSecretErrors(t *testing.T) {
+	sp := createProvider()
+	retrieve := func() error {
+		_, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", nil)
+		return err
+	}
+
+	require.ErrorContains(t, retrieve(), "OTELCOL_SECRETS_FILE is not set")
+
+	writeSecrets(t, "db_password: s3cr3t\n")
+	wrongKey := make([]byte, KeySize)
+	t.Setenv(keyEnvVar, base64.StdEncoding.EncodeToString(wrongKey))
+	require.ErrorContains(t, retrieve(), "unable to decrypt the secrets: wrong key or corrupted content")
+
+	t.Setenv(keyEnvVar, base64.StdEncoding.EncodeToString(wrongKey[:16]))
+	require.ErrorContains(t, retrieve(), "invalid key size 16: the key must be 32 bytes long")
+
+	t.Setenv(keyEnvVar, "not base64")
+	require.ErrorContains(t, retrieve(), "invalid key encoding")
+
+	require.NoError(t, os.Unsetenv(keyEnvVar))
+	require.ErrorContains(t, retrieve(), "no key: OTELCOL_SECRETS_KEY or OTELCOL_SECRETS_KEY_FILE must be set")
+
+	writeSecrets(t, "db_password: s3cr3t\n")
+	require.NoError(t, os.WriteFile(os.Getenv(fileEnvVar), []byte("db_password: s3cr3t\n"), 0o600))
+	require.ErrorContains(t, retrieve(), `unsupported format: the content must start with "otelcol-secrets:v1:"`)
+
+	t.Setenv(fileEnvVar, filepath.Join(t.TempDir(), "non-existent"))
+	require.ErrorContains(t, retrieve(), "unable to read the secrets file")
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestEncryptErrors(t *testing.T) {
+	_, err := Encrypt(make([]byte, KeySize), []byte("- a list"))
+	require.ErrorContains(t, err, "the secrets must be a map of names to string values")
+	_, err = Encrypt(make([]byte, 8), []byte("db_password: s3cr3t"))
+	require.ErrorContains(t, err, "invalid key size 8")
+}
+
+func TestWatchSecret(t *testing.T) {
+	key := writeSecrets(t, "db_password: s3cr3t\napi_key: abc\n")
+	sp := createProvider().(*provider)
+	sp.debounceDelay = 20 * time.Millisecond
+	events := make(chan *confmap.ChangeEvent, 1)
+	watcher := func(event *confmap.ChangeEvent) {
+		events <- event
+	}
+
+	// The secrets are not watched without a poll interval.
+	_, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", watcher)
+	require.NoError(t, err)
+	assert.Zero(t, sp.watchers.Len())
+
+	t.Setenv(pollIntervalEnvVar, "invalid")
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", watcher)
+	require.ErrorContains(t, err, `invalid OTELCOL_SECRETS_POLL_INTERVAL "invalid"`)
+
+	t.Setenv(pollIntervalEnvVar, "5ms")
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"db_password", watcher)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sp.watchers.Len())
+
+	rewrite := func(secrets string) {
+		content, encryptErr := Encrypt(key, []byte(secrets))
+		require.NoError(t, encryptErr)
+		require.NoError(t, os.WriteFile(os.Getenv(fileEnvVar), content, 0o600))
+	}
+
+	// Changing another secret is not a change of the watched secret.
+	rewrite("db_password: s3cr3t\napi_key: def\n")
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	rewrite("db_password: r0tated\napi_key: def\n")
+	select {
+	case event := <-events:
+		require.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the change was not reported")
+	}
+	require.NoError(t, ret.Close(context.Background()))
+	assert.Zero(t, sp.watchers.Len())
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
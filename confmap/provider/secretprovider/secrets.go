@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider // import "go.opentelemetry.io/collector/confmap/provider/secretprovider"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// KeySize is the size of the keys, in bytes, the secrets are encrypted with AES-256-GCM.
+	KeySize = 32
+
+	// filePrefix is the prefix of the secrets files, it identifies the format version.
+	filePrefix = "otelcol-secrets:v1:"
+)
+
+// additionalData binds the ciphertext to the format version.
+var additionalData = []byte(filePrefix)
+
+// Encrypt returns the content of a secrets file storing the secrets encrypted with the key.
+// The secrets are a YAML map of the secret names to their values, e.g. "db_password: s3cr3t".
+// The key is KeySize random bytes, e.g. the decoded output of `openssl rand -base64 32`.
+func Encrypt(key, secrets []byte) ([]byte, error) {
+	if _, err := parseSecrets(secrets); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := aead.Seal(nonce, nonce, secrets, additionalData)
+	return []byte(filePrefix + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// decrypt returns the secrets stored in the content of a secrets file.
+func decrypt(key, content []byte) (map[string]string, error) {
+	content = bytes.TrimSpace(content)
+	if !bytes.HasPrefix(content, []byte(filePrefix)) {
+		return nil, fmt.Errorf("unsupported format: the content must start with %q", filePrefix)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(string(content[len(filePrefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encoding: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("the content is truncated")
+	}
+	secrets, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		// Do not wrap the error, a wrong key and a corrupted file cannot be told apart.
+		return nil, errors.New("unable to decrypt the secrets: wrong key or corrupted content")
+	}
+	return parseSecrets(secrets)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key size %d: the key must be %d bytes long", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// parseSecrets parses the YAML map of the secret names to their values. The values are
+// kept as written, e.g. "0123" is not an integer.
+func parseSecrets(secrets []byte) (map[string]string, error) {
+	var m map[string]string
+	if err := yaml.Unmarshal(secrets, &m); err != nil {
+		return nil, fmt.Errorf("the secrets must be a map of names to string values: %w", err)
+	}
+	return m, nil
+}
+
+// readKey returns the key set by the environment, either directly or by the path of a file storing it.
+// The key is encoded in base64.
+func readKey() ([]byte, error) {
+	encoded, hasKey := os.LookupEnv(keyEnvVar)
+	keyFile, hasKeyFile := os.LookupEnv(keyFileEnvVar)
+	switch {
+	case hasKey && hasKeyFile:
+		return nil, fmt.Errorf("only one of %s and %s can be set", keyEnvVar, keyFileEnvVar)
+	case hasKeyFile:
+		content, err := os.ReadFile(filepath.Clean(keyFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the key file: %w", err)
+		}
+		encoded = string(content)
+	case !hasKey:
+		return nil, fmt.Errorf("no key: %s or %s must be set", keyEnvVar, keyFileEnvVar)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace([]byte(encoded))))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key encoding, the key must be encoded in base64: %w", err)
+	}
+	return key, nil
+}
@@ -12,6 +12,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/internal/metadata"
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 // This is an example of a provider that calls a provided WatcherFunc to update configuration dynamically every second.
@@ -129,6 +132,33 @@ func TestNewRetrievedFromYAMLWithOptions(t *testing.T) {
 	assert.Equal(t, want, ret.Close(context.Background()))
 }
 
+func TestNewRetrievedFromFragments(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), false))
+	}()
+	first, err := NewRetrievedFromYAML([]byte("service:\n  extensions: [zpages]\n  telemetry:\n    logs:\n      level: info\n"))
+	require.NoError(t, err)
+	second, err := NewRetrievedFromYAML([]byte("service:\n  extensions: !mode=append [pprof]\n"))
+	require.NoError(t, err)
+	ret, err := NewRetrievedFromFragments([]Fragment{{Source: "a.yaml", Retrieved: first}, {Source: "b.yaml", Retrieved: second}})
+	require.NoError(t, err)
+
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, []any{"zpages", "pprof"}, retMap.Get("service::extensions"))
+	assert.Equal(t, map[string]Provenance{
+		"service":                         {URI: "b.yaml", Line: 1},
+		"service::extensions":             {URI: "b.yaml", Line: 2},
+		"service::telemetry":              {URI: "a.yaml", Line: 3},
+		"service::telemetry::logs":        {URI: "a.yaml", Line: 4},
+		"service::telemetry::logs::level": {URI: "a.yaml", Line: 5},
+	}, ret.provenance)
+
+	_, err = NewRetrievedFromFragments([]Fragment{{Source: "c.yaml", Retrieved: &Retrieved{rawConf: "string"}}})
+	require.EqualError(t, err, "invalid fragment c.yaml: retrieved value (type=string) cannot be used as a Conf")
+}
+
 func TestNewRetrievedFromYAMLInvalidYAMLBytes(t *testing.T) {
 	ret, err := NewRetrievedFromYAML([]byte("[invalid:,"))
 	require.NoError(t, err)
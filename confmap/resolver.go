@@ -7,13 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/confmap/internal"
+	"go.opentelemetry.io/collector/confmap/internal/metadata"
 )
 
 // follows drive-letter specification:
@@ -26,6 +29,7 @@ type Resolver struct {
 	providers     map[string]Provider
 	defaultScheme string
 	converters    []Converter
+	logger        *zap.Logger
 
 	closers []CloseFunc
 	watcher chan error
@@ -33,6 +37,10 @@ type Resolver struct {
 	// unexpandedConfMap holds the merged configuration captured during the most
 	// recent Resolve call before provider/env-var references were expanded.
 	unexpandedConfMap map[string]any
+
+	// provenance holds the source of every key path of the configuration
+	// resolved by the most recent Resolve call.
+	provenance map[string]Provenance
 }
 
 // ResolverSettings are the settings to configure the behavior of the Resolver.
@@ -133,11 +141,16 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	// Safe copy, ensures the slices and maps cannot be changed from the caller.
 	uris := make([]location, len(set.URIs))
 	for i, uri := range set.URIs {
+		// The merge parameters are handled by the Resolver, they are not passed to the providers.
+		uri, mergeStrategies, err := internal.ParseMergeParams(uri)
+		if err != nil {
+			return nil, err
+		}
 		// For backwards compatibility:
 		// - empty url scheme means "file".
 		// - "^[A-z]:" also means "file"
 		if driverLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
-			uris[i] = location{scheme: "file", opaqueValue: uri}
+			uris[i] = location{scheme: "file", opaqueValue: uri, mergeStrategies: mergeStrategies}
 			continue
 		}
 		lURI, err := newLocation(uri)
@@ -147,6 +160,7 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		if _, ok := providers[lURI.scheme]; !ok {
 			return nil, fmt.Errorf("unsupported scheme on URI %q", uri)
 		}
+		lURI.mergeStrategies = mergeStrategies
 		uris[i] = lURI
 	}
 
@@ -155,6 +169,7 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		providers:     providers,
 		defaultScheme: set.DefaultScheme,
 		converters:    converters,
+		logger:        set.ProviderSettings.Logger,
 		watcher:       make(chan error, 1),
 	}, nil
 }
@@ -169,6 +184,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
+	provenance := map[string]Provenance{}
 	for _, uri := range mr.uris {
 		ret, err := mr.retrieveValue(ctx, uri)
 		if err != nil {
@@ -180,9 +196,21 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 			return nil, err
 		}
 
-		if err := retMap.Merge(retCfgMap); err != nil {
+		// The merge tags of the configuration take precedence over the merge parameters of its URI.
+		mergeStrategies := append(slices.Clip(ret.mergeStrategies), uri.mergeStrategies...)
+		if len(mergeStrategies) > 0 && !metadata.ConfmapEnableMergeAppendOptionFeatureGate.IsEnabled() {
+			mr.logger.Warn("The merge options of the configuration are ignored, enable the feature gate to use them",
+				zap.String("uri", uri.asString()),
+				zap.String("feature_gate", metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID()))
+		}
+		if err := internal.MergeWithStrategies(retMap, retCfgMap, mergeStrategies); err != nil {
 			return nil, err
 		}
+		if ret.provenance != nil {
+			maps.Copy(provenance, ret.provenance)
+		} else {
+			recordProvenance(provenance, "", retCfgMap.ToStringMap(), uri.asString(), ret.keyLines)
+		}
 	}
 
 	// Capture the pre-expansion map (provider references intact) before expanding.
@@ -191,13 +219,22 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	cfgMap := make(map[string]any)
 	for _, k := range retMap.AllKeys() {
 		ug := internal.UnsanitizedGetter{Conf: retMap}
-		val, err := mr.expandValueRecursively(ctx, ug.UnsanitizedGet(k))
+		raw := ug.UnsanitizedGet(k)
+		val, err := mr.expandValueRecursively(ctx, raw)
 		if err != nil {
 			return nil, err
 		}
+		if ref, ok := raw.(string); ok && strings.Contains(ref, "${") {
+			if s, isString := val.(string); !isString || s != ref {
+				p := provenance[k]
+				p.Expansion = ref
+				provenance[k] = p
+			}
+		}
 		cfgMap[k] = escapeDollarSigns(val)
 	}
 	retMap = NewFromStringMap(cfgMap)
+	mr.provenance = resolveProvenance(provenance, retMap.ToStringMap())
 
 	// Apply the converters in the given order.
 	for _, confConv := range mr.converters {
@@ -223,6 +260,17 @@ func (mr *Resolver) UnexpandedConf() *Conf {
 	return NewFromStringMap(mr.unexpandedConfMap)
 }
 
+// Provenance returns the source of every key path, e.g. "exporters::otlp_grpc::endpoint", of the
+// configuration resolved by the most recent Resolve call, before the converters are applied.
+// The parent keys of a value are included, so a map key, e.g. "exporters::otlp_grpc", has the
+// provenance of the last configuration setting it. Returns nil if Resolve has not been called.
+//
+// Experimental: This method is experimental. Its behavior may change without backward
+// compatibility until this notice is removed.
+func (mr *Resolver) Provenance() map[string]Provenance {
+	return maps.Clone(mr.provenance)
+}
+
 func escapeDollarSigns(val any) any {
 	switch v := val.(type) {
 	case string:
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 	"go.yaml.in/yaml/v3"
 
 	"go.opentelemetry.io/collector/confmap/internal/metadata"
@@ -476,6 +477,116 @@ func TestMergeFunctionality(t *testing.T) {
 	}
 }
 
+func TestMergeStrategies(t *testing.T) {
+	configs := map[string]string{
+		"main.yaml": `
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`,
+		"tags.yaml": `
+extensions:
+  pprof:
+service:
+  extensions: !mode=append [pprof]
+  pipelines:
+    traces:
+      processors: !mode=prepend [memory_limiter]
+`,
+		"params.yaml": `
+service:
+  pipelines:
+    traces:
+      processors: [transform]
+      exporters: [debug, otlp_grpc]
+`,
+	}
+	yamlProvider := newFakeProvider("file", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		// The merge parameters are not passed to the providers.
+		return NewRetrievedFromYAML([]byte(configs[uri[len("file:"):]]))
+	})
+	uris := []string{"main.yaml", "tags.yaml", "file:params.yaml?merge_mode=append&merge_paths=service::**::processors"}
+
+	tests := []struct {
+		name        string
+		flagEnabled bool
+		expected    map[string]any
+	}{
+		{
+			name:        "feature-flag-enabled",
+			flagEnabled: true,
+			expected: map[string]any{
+				"service::extensions":                    []any{"health_check", "pprof"},
+				"service::pipelines::traces::receivers":  []any{"otlp"},
+				"service::pipelines::traces::processors": []any{"memory_limiter", "batch", "transform"},
+				"service::pipelines::traces::exporters":  []any{"debug", "otlp_grpc"},
+			},
+		},
+		{
+			name: "feature-flag-disabled",
+			expected: map[string]any{
+				"service::extensions":                    []any{"pprof"},
+				"service::pipelines::traces::receivers":  []any{"otlp"},
+				"service::pipelines::traces::processors": []any{"transform"},
+				"service::pipelines::traces::exporters":  []any{"debug", "otlp_grpc"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.flagEnabled {
+				require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), true))
+				defer func() {
+					// Restore previous value.
+					require.NoError(t, featuregate.GlobalRegistry().Set(metadata.ConfmapEnableMergeAppendOptionFeatureGate.ID(), false))
+				}()
+			}
+			core, logs := observer.New(zap.WarnLevel)
+			resolver, err := NewResolver(ResolverSettings{
+				URIs:              uris,
+				ProviderFactories: []ProviderFactory{yamlProvider},
+				ProviderSettings:  ProviderSettings{Logger: zap.New(core)},
+			})
+			require.NoError(t, err)
+			conf, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+			for key, expected := range tt.expected {
+				assert.Equal(t, expected, conf.Get(key), key)
+			}
+			// The ignored merge options are reported.
+			if tt.flagEnabled {
+				assert.Zero(t, logs.Len())
+			} else {
+				assert.Equal(t, 2, logs.Len())
+			}
+		})
+	}
+}
+
+func TestMergeStrategiesErrors(t *testing.T) {
+	_, err := NewResolver(ResolverSettings{
+		URIs:              []string{"file:extra.yaml?merge_mode=merge"},
+		ProviderFactories: []ProviderFactory{newFileProvider(t)},
+	})
+	require.ErrorContains(t, err, `invalid merge mode "merge"`)
+
+	resolver, err := NewResolver(ResolverSettings{
+		URIs: []string{"file:extra.yaml"},
+		ProviderFactories: []ProviderFactory{newFakeProvider("file", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+			return NewRetrievedFromYAML([]byte("service:\n  extensions: !mode=merge [health_check]\n"))
+		})},
+	})
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	require.ErrorContains(t, err, `invalid merge tag "!mode=merge" at line 2`)
+}
+
 func runScenario(t *testing.T, path string) {
 	yamlData, err := os.ReadFile(filepath.Clean(path))
 	require.NoError(t, err)
@@ -608,3 +719,52 @@ func TestResolverUnexpandedConf(t *testing.T) {
 		}, pre.ToStringMap())
 	})
 }
+
+func TestResolverProvenance(t *testing.T) {
+	files := map[string]string{
+		"file:main.yaml": `receivers:
+  otlp:
+    endpoint: ${env:HOST}
+exporters:
+  debug:
+    verbosity: basic
+  otlp_grpc:
+    endpoint: $${literal}
+`,
+		"file:extra.yaml": `exporters:
+  debug:
+    verbosity: detailed
+processors:
+  batch: ${file:batch.yaml}
+`,
+		"file:batch.yaml": "timeout: 5s\n",
+	}
+	fileProvider := newFakeProvider("file", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+		return NewRetrievedFromYAML([]byte(files[uri]))
+	})
+	r, err := NewResolver(ResolverSettings{
+		URIs:              []string{"file:main.yaml", "file:extra.yaml"},
+		ProviderFactories: []ProviderFactory{fileProvider, newEnvProvider()},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, r.Provenance())
+
+	_, err = r.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Provenance{
+		"receivers":                      {URI: "file:main.yaml", Line: 1},
+		"receivers::otlp":                {URI: "file:main.yaml", Line: 2},
+		"receivers::otlp::endpoint":      {URI: "file:main.yaml", Line: 3, Expansion: "${env:HOST}"},
+		"exporters":                      {URI: "file:extra.yaml", Line: 1},
+		"exporters::debug":               {URI: "file:extra.yaml", Line: 2},
+		"exporters::debug::verbosity":    {URI: "file:extra.yaml", Line: 3},
+		"exporters::otlp_grpc":           {URI: "file:main.yaml", Line: 7},
+		"exporters::otlp_grpc::endpoint": {URI: "file:main.yaml", Line: 8},
+		"processors":                     {URI: "file:extra.yaml", Line: 4},
+		"processors::batch":              {URI: "file:extra.yaml", Line: 5, Expansion: "${file:batch.yaml}"},
+		"processors::batch::timeout":     {URI: "file:extra.yaml", Line: 5, Expansion: "${file:batch.yaml}"},
+	}, r.Provenance())
+
+	assert.Equal(t, "file:main.yaml:3 (expanded from ${env:HOST})", r.Provenance()["receivers::otlp::endpoint"].String())
+	assert.Equal(t, "env:", Provenance{URI: "env:"}.String())
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "go.opentelemetry.io/collector/connector/failoverconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Config defines the configuration for the failover connector.
+type Config struct {
+	// Pipelines is the ordered list of pipelines the data is routed to, by priority. Every request is sent to the
+	// first healthy pipeline of the signal, and to the next ones if it fails.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// RetryInterval is the time a pipeline is considered unhealthy after a failure. Once elapsed, the requests are
+	// sent to the pipeline again, so the connector fails back to a higher priority pipeline after it recovered.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Pipelines) == 0 {
+		return errors.New("`pipelines` must not be empty")
+	}
+	seen := make(map[pipeline.ID]struct{}, len(cfg.Pipelines))
+	for _, id := range cfg.Pipelines {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("duplicate entry in `pipelines`: %q", id)
+		}
+		seen[id] = struct{}{}
+	}
+	if cfg.RetryInterval <= 0 {
+		return errors.New("`retry_interval` must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(component.MustNewType("failover")),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType("failover"), "custom"),
+			expected: &Config{
+				Pipelines: []pipeline.ID{
+					pipeline.NewIDWithName(pipeline.SignalTraces, "primary"),
+					pipeline.NewIDWithName(pipeline.SignalTraces, "secondary"),
+					pipeline.NewIDWithName(pipeline.SignalLogs, "primary"),
+					pipeline.NewIDWithName(pipeline.SignalLogs, "secondary"),
+				},
+				RetryInterval: time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.EqualError(t, confmap.Validate(cfg), "`pipelines` must not be empty")
+
+	cfg.Pipelines = []pipeline.ID{pipeline.NewID(pipeline.SignalTraces), pipeline.NewID(pipeline.SignalTraces)}
+	require.EqualError(t, confmap.Validate(cfg), "duplicate entry in `pipelines`: \"traces\"")
+
+	cfg.Pipelines = []pipeline.ID{pipeline.NewID(pipeline.SignalTraces)}
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg.RetryInterval = 0
+	require.EqualError(t, confmap.Validate(cfg), "`retry_interval` must be positive")
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package failoverconnector routes the signals to the first healthy pipeline of an ordered list of pipelines.
+package failoverconnector // import "go.opentelemetry.io/collector/connector/failoverconnector"
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "go.opentelemetry.io/collector/connector/failoverconnector"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/failoverconnector/internal/metadata"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+// errNotRouter is returned if the next consumer does not route the data to the pipelines of the connector.
+var errNotRouter = errors.New("the next consumer is not a pipeline router")
+
+// NewFactory returns a connector.Factory.
+func NewFactory() xconnector.Factory {
+	return xconnector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		xconnector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
+		xconnector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		xconnector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithProfilesToProfiles(createProfilesToProfiles, metadata.ProfilesToProfilesStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		RetryInterval: 30 * time.Second,
+	}
+}
+
+// createTracesToTraces creates a traces connector based on provided config.
+func createTracesToTraces(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Traces, error) {
+	router, ok := nextConsumer.(connector.TracesRouterAndConsumer)
+	if !ok {
+		return nil, errNotRouter
+	}
+	f, err := newFailover(set, cfg.(*Config), pipeline.SignalTraces, router)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesFailover{failover: f}, nil
+}
+
+// createMetricsToMetrics creates a metrics connector based on provided config.
+func createMetricsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	router, ok := nextConsumer.(connector.MetricsRouterAndConsumer)
+	if !ok {
+		return nil, errNotRouter
+	}
+	f, err := newFailover(set, cfg.(*Config), pipeline.SignalMetrics, router)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsFailover{failover: f}, nil
+}
+
+// createLogsToLogs creates a logs connector based on provided config.
+func createLogsToLogs(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Logs, error) {
+	router, ok := nextConsumer.(connector.LogsRouterAndConsumer)
+	if !ok {
+		return nil, errNotRouter
+	}
+	f, err := newFailover(set, cfg.(*Config), pipeline.SignalLogs, router)
+	if err != nil {
+		return nil, err
+	}
+	return &logsFailover{failover: f}, nil
+}
+
+// createProfilesToProfiles creates a profiles connector based on provided config.
+func createProfilesToProfiles(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer xconsumer.Profiles,
+) (xconnector.Profiles, error) {
+	router, ok := nextConsumer.(xconnector.ProfilesRouterAndConsumer)
+	if !ok {
+		return nil, errNotRouter
+	}
+	f, err := newFailover(set, cfg.(*Config), xpipeline.SignalProfiles, router)
+	if err != nil {
+		return nil, err
+	}
+	return &profilesFailover{failover: f}, nil
+}
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "go.opentelemetry.io/collector/connector/failoverconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var errAllPipelinesFailed = errors.New("all failover pipelines failed")
+
+// router is the part of the connector routers used to get the consumer of a single pipeline.
+type router[C any] interface {
+	Consumer(...pipeline.ID) (C, error)
+}
+
+// failover sends every request to the first healthy pipeline, by priority. A pipeline is unhealthy for the
+// retry interval after it returned a non-permanent error, e.g. when the retries of its exporter are exhausted
+// or its circuit breaker is open. When all the pipelines are unhealthy, they are all tried in order.
+type failover[C any] struct {
+	component.ShutdownFunc
+	logger        *zap.Logger
+	retryInterval time.Duration
+	pipelines     []pipeline.ID
+	consumers     []C
+
+	mu             sync.Mutex
+	host           component.Host
+	active         int
+	unhealthyUntil []time.Time
+}
+
+func newFailover[C any](set connector.Settings, cfg *Config, signal pipeline.Signal, r router[C]) (*failover[C], error) {
+	f := &failover[C]{
+		logger:        set.Logger,
+		retryInterval: cfg.RetryInterval,
+	}
+	for _, id := range cfg.Pipelines {
+		if id.Signal() != signal {
+			continue
+		}
+		c, err := r.Consumer(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the consumer of pipeline %q: %w", id, err)
+		}
+		f.pipelines = append(f.pipelines, id)
+		f.consumers = append(f.consumers, c)
+	}
+	if len(f.pipelines) == 0 {
+		return nil, fmt.Errorf("no %s pipeline configured in `pipelines`", signal)
+	}
+	f.unhealthyUntil = make([]time.Time, len(f.pipelines))
+	return f, nil
+}
+
+func (f *failover[C]) Start(_ context.Context, host component.Host) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.host = host
+	return nil
+}
+
+func (*failover[C]) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// consume sends the request to the pipelines by priority until one of them accepts it.
+func (f *failover[C]) consume(ctx context.Context, send func(context.Context, C) error) error {
+	var errs []error
+	for _, i := range f.order() {
+		err := send(ctx, f.consumers[i])
+		// Permanent errors are caused by the data, another pipeline would not accept it either.
+		if err == nil || consumererror.IsPermanent(err) {
+			f.onSuccess(i)
+			return err
+		}
+		f.onFailure(i, err)
+		errs = append(errs, fmt.Errorf("pipeline %q: %w", f.pipelines[i], err))
+	}
+	err := fmt.Errorf("%w: %w", errAllPipelinesFailed, errors.Join(errs...))
+	f.reportStatus(componentstatus.NewRecoverableErrorEvent(err))
+	return err
+}
+
+// order returns the indexes of the healthy pipelines followed by the unhealthy ones, by priority.
+func (f *failover[C]) order() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	healthy := make([]int, 0, len(f.pipelines))
+	var unhealthy []int
+	for i, until := range f.unhealthyUntil {
+		if now.Before(until) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (f *failover[C]) onSuccess(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unhealthyUntil[i] = time.Time{}
+	if f.active == i {
+		return
+	}
+	f.active = i
+	if i == 0 {
+		f.logger.Info("Failed back to the primary pipeline", zap.Stringer("pipeline", f.pipelines[i]))
+		f.reportStatusLocked(componentstatus.NewEvent(componentstatus.StatusOK))
+		return
+	}
+	f.logger.Warn("Failed over to a secondary pipeline", zap.Stringer("pipeline", f.pipelines[i]))
+	f.reportStatusLocked(componentstatus.NewRecoverableErrorEvent(
+		fmt.Errorf("failed over to pipeline %q", f.pipelines[i])))
+}
+
+func (f *failover[C]) onFailure(i int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Respect the delay requested by the destination if longer than the retry interval.
+	until := time.Now().Add(f.retryInterval)
+	var throttleErr *consumererror.Throttle
+	if errors.As(err, &throttleErr) {
+		if throttled := time.Now().Add(throttleErr.Delay()); throttled.After(until) {
+			until = throttled
+		}
+	}
+	f.unhealthyUntil[i] = until
+	f.logger.Debug("Pipeline is unhealthy", zap.Stringer("pipeline", f.pipelines[i]),
+		zap.Time("until", until), zap.Error(err))
+}
+
+func (f *failover[C]) reportStatus(ev *componentstatus.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reportStatusLocked(ev)
+}
+
+// reportStatusLocked reports the status of the connector. Callers MUST hold the mutex.
+func (f *failover[C]) reportStatusLocked(ev *componentstatus.Event) {
+	if f.host != nil {
+		componentstatus.ReportStatus(f.host, ev)
+	}
+}
+
+type tracesFailover struct {
+	*failover[consumer.Traces]
+}
+
+func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// The data may be sent to more than one pipeline, mutating pipelines get a copy.
+	if len(f.consumers) > 1 && !td.IsReadOnly() {
+		td.MarkReadOnly()
+	}
+	return f.consume(ctx, func(ctx context.Context, c consumer.Traces) error {
+		return c.ConsumeTraces(ctx, td)
+	})
+}
+
+type metricsFailover struct {
+	*failover[consumer.Metrics]
+}
+
+func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	// The data may be sent to more than one pipeline, mutating pipelines get a copy.
+	if len(f.consumers) > 1 && !md.IsReadOnly() {
+		md.MarkReadOnly()
+	}
+	return f.consume(ctx, func(ctx context.Context, c consumer.Metrics) error {
+		return c.ConsumeMetrics(ctx, md)
+	})
+}
+
+type logsFailover struct {
+	*failover[consumer.Logs]
+}
+
+func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	// The data may be sent to more than one pipeline, mutating pipelines get a copy.
+	if len(f.consumers) > 1 && !ld.IsReadOnly() {
+		ld.MarkReadOnly()
+	}
+	return f.consume(ctx, func(ctx context.Context, c consumer.Logs) error {
+		return c.ConsumeLogs(ctx, ld)
+	})
+}
+
+type profilesFailover struct {
+	*failover[xconsumer.Profiles]
+}
+
+func (f *profilesFailover) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	// The data may be sent to more than one pipeline, mutating pipelines get a copy.
+	if len(f.consumers) > 1 && !pd.IsReadOnly() {
+		pd.MarkReadOnly()
+	}
+	return f.consume(ctx, func(ctx context.Context, c xconsumer.Profiles) error {
+		return c.ConsumeProfiles(ctx, pd)
+	})
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+type statusHost struct {
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (*statusHost) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
+
+func (h *statusHost) Report(e *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var statuses []componentstatus.Status
+	for _, e := range h.events {
+		statuses = append(statuses, e.Status())
+	}
+	return statuses
+}
+
+// tracesPipeline is a traces pipeline returning the configured error.
+type tracesPipeline struct {
+	consumertest.TracesSink
+	mu  sync.Mutex
+	err error
+}
+
+func (p *tracesPipeline) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *tracesPipeline) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	p.mu.Lock()
+	err := p.err
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return p.TracesSink.ConsumeTraces(ctx, td)
+}
+
+var (
+	primaryID   = pipeline.NewIDWithName(pipeline.SignalTraces, "primary")
+	secondaryID = pipeline.NewIDWithName(pipeline.SignalTraces, "secondary")
+)
+
+func newTestTracesFailover(t *testing.T, retryInterval time.Duration) (connector.Traces, *tracesPipeline, *tracesPipeline, *statusHost) {
+	primary, secondary := &tracesPipeline{}, &tracesPipeline{}
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{primaryID: primary, secondaryID: secondary})
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Pipelines = []pipeline.ID{primaryID, secondaryID, pipeline.NewID(pipeline.SignalLogs)}
+	cfg.RetryInterval = retryInterval
+	conn, err := f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, router)
+	require.NoError(t, err)
+	host := &statusHost{}
+	require.NoError(t, conn.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, conn.Shutdown(context.Background())) })
+	return conn, primary, secondary, host
+}
+
+func TestFailoverAndFailback(t *testing.T) {
+	conn, primary, secondary, host := newTestTracesFailover(t, 50*time.Millisecond)
+	assert.False(t, conn.Capabilities().MutatesData)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, primary.AllTraces(), 1)
+	assert.Empty(t, secondary.AllTraces())
+
+	// The failed request is sent to the secondary pipeline, then the primary pipeline is skipped.
+	primary.setErr(errors.New("unavailable"))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	primary.setErr(nil)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, primary.AllTraces(), 1)
+	assert.Len(t, secondary.AllTraces(), 2)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// After the retry interval, the requests fail back to the primary pipeline.
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, primary.AllTraces(), 2)
+	assert.Len(t, secondary.AllTraces(), 2)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+}
+
+func TestFailoverPermanentError(t *testing.T) {
+	conn, primary, secondary, host := newTestTracesFailover(t, time.Minute)
+
+	primary.setErr(consumererror.NewPermanent(errors.New("bad data")))
+	err := conn.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Empty(t, secondary.AllTraces())
+	assert.Empty(t, host.statuses())
+}
+
+func TestFailoverAllPipelinesFailed(t *testing.T) {
+	conn, primary, secondary, host := newTestTracesFailover(t, time.Minute)
+
+	primary.setErr(errors.New("primary unavailable"))
+	secondary.setErr(consumererror.NewThrottle(errors.New("secondary unavailable"), time.Hour))
+	err := conn.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.ErrorIs(t, err, errAllPipelinesFailed)
+	require.ErrorContains(t, err, "primary unavailable")
+	require.ErrorContains(t, err, "secondary unavailable")
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// The unhealthy pipelines are still tried, by priority.
+	primary.setErr(nil)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, primary.AllTraces(), 1)
+}
+
+func TestFailoverThrottleDelay(t *testing.T) {
+	conn, primary, secondary, _ := newTestTracesFailover(t, time.Millisecond)
+
+	primary.setErr(consumererror.NewThrottle(errors.New("unavailable"), time.Hour))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	primary.setErr(nil)
+	time.Sleep(10 * time.Millisecond)
+	// The primary pipeline stays unhealthy for the delay requested by the destination.
+	require.NoError(t, conn.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Empty(t, primary.AllTraces())
+	assert.Len(t, secondary.AllTraces(), 2)
+}
+
+func TestFailoverMissingPipeline(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Pipelines = []pipeline.ID{primaryID}
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{secondaryID: consumertest.NewNop()})
+	_, err := f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, router)
+	require.ErrorContains(t, err, `failed to get the consumer of pipeline "traces/primary"`)
+
+	cfg.Pipelines = []pipeline.ID{pipeline.NewID(pipeline.SignalLogs)}
+	_, err = f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, router)
+	require.EqualError(t, err, "no traces pipeline configured in `pipelines`")
+}
+
+func TestFailoverNotRouter(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Pipelines = []pipeline.ID{primaryID, secondaryID}
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(f.Type())
+
+	_, err := f.CreateTracesToTraces(ctx, set, cfg, consumertest.NewNop())
+	require.ErrorIs(t, err, errNotRouter)
+	_, err = f.CreateMetricsToMetrics(ctx, set, cfg, consumertest.NewNop())
+	require.ErrorIs(t, err, errNotRouter)
+	_, err = f.CreateLogsToLogs(ctx, set, cfg, consumertest.NewNop())
+	require.ErrorIs(t, err, errNotRouter)
+	_, err = f.CreateProfilesToProfiles(ctx, set, cfg, consumertest.NewNop())
+	require.ErrorIs(t, err, errNotRouter)
+}
+
+func TestFailoverAllSignals(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Pipelines = []pipeline.ID{
+		pipeline.NewIDWithName(pipeline.SignalMetrics, "primary"),
+		pipeline.NewIDWithName(pipeline.SignalMetrics, "secondary"),
+		pipeline.NewIDWithName(pipeline.SignalLogs, "primary"),
+		pipeline.NewIDWithName(pipeline.SignalLogs, "secondary"),
+		pipeline.NewIDWithName(xpipeline.SignalProfiles, "primary"),
+		pipeline.NewIDWithName(xpipeline.SignalProfiles, "secondary"),
+	}
+	ctx := context.Background()
+	set := connectortest.NewNopSettings(f.Type())
+	unavailable := errors.New("unavailable")
+
+	metricsSink := new(consumertest.MetricsSink)
+	metricsToMetrics, err := f.CreateMetricsToMetrics(ctx, set, cfg, connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+		cfg.Pipelines[0]: consumertest.NewErr(unavailable),
+		cfg.Pipelines[1]: metricsSink,
+	}))
+	require.NoError(t, err)
+	md := pmetric.NewMetrics()
+	require.NoError(t, metricsToMetrics.ConsumeMetrics(ctx, md))
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.True(t, md.IsReadOnly())
+
+	logsSink := new(consumertest.LogsSink)
+	logsToLogs, err := f.CreateLogsToLogs(ctx, set, cfg, connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		cfg.Pipelines[2]: consumertest.NewErr(unavailable),
+		cfg.Pipelines[3]: logsSink,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, logsToLogs.ConsumeLogs(ctx, plog.NewLogs()))
+	assert.Len(t, logsSink.AllLogs(), 1)
+
+	profilesSink := new(consumertest.ProfilesSink)
+	profilesToProfiles, err := f.(xconnector.Factory).CreateProfilesToProfiles(ctx, set, cfg, xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+		cfg.Pipelines[4]: consumertest.NewErr(unavailable),
+		cfg.Pipelines[5]: profilesSink,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, profilesToProfiles.ConsumeProfiles(ctx, pprofile.NewProfiles()))
+	assert.Len(t, profilesSink.AllProfiles(), 1)
+}
@@ -0,0 +1,21 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+// Package metadata contains the autogenerated telemetry and
+// build information for the connector/failover component.
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("failover")
+	ScopeName = "go.opentelemetry.io/collector/connector/failoverconnector"
+)
+
+const (
+	TracesToTracesStability     = component.StabilityLevelDevelopment
+	MetricsToMetricsStability   = component.StabilityLevelDevelopment
+	LogsToLogsStability         = component.StabilityLevelDevelopment
+	ProfilesToProfilesStability = component.StabilityLevelDevelopment
+)
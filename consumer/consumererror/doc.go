@@ -20,4 +20,8 @@
 // may be done by the component itself, however typically it is done by the original sender, after
 // the receiver in the pipeline returns a response to the sender indicating that the Collector is
 // currently overloaded and the request must be retried.
+//
+// A non-Permanent error can be wrapped with NewThrottle when the destination asks the sender to slow
+// down, e.g. using a gRPC RetryInfo or an HTTP Retry-After. The delay carried by the Throttle error is
+// honored by the retry logic of the exporters, which pauses sending any data until the delay elapses.
 package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
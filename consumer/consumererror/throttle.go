@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import "time"
+
+// Throttle is an error indicating that the destination is overloaded and asks the sender
+// to wait before sending more data, e.g. when a gRPC RetryInfo or an HTTP Retry-After is received.
+// Throttle errors are not permanent, the data should be retried after the delay.
+//
+// Throttle should be obtained from a given `error` object using `errors.As`.
+type Throttle struct {
+	err       error
+	delay     time.Duration
+	rateRatio float64
+}
+
+var _ error = (*Throttle)(nil)
+
+// ThrottleOption configures optional hints of a Throttle error.
+type ThrottleOption interface {
+	apply(*Throttle)
+}
+
+type throttleOptionFunc func(*Throttle)
+
+func (of throttleOptionFunc) apply(t *Throttle) {
+	of(t)
+}
+
+// WithRateRatio hints the ratio, between 0 and 1, of the current sending rate the destination
+// is able to accept. Values outside of this range are ignored.
+func WithRateRatio(ratio float64) ThrottleOption {
+	return throttleOptionFunc(func(t *Throttle) {
+		if ratio > 0 && ratio <= 1 {
+			t.rateRatio = ratio
+		}
+	})
+}
+
+// NewThrottle wraps an error to indicate that the destination is throttling the sender,
+// and no data should be sent to it before the given delay.
+func NewThrottle(err error, delay time.Duration, options ...ThrottleOption) error {
+	t := &Throttle{err: err, delay: delay}
+	for _, op := range options {
+		op.apply(t)
+	}
+	return t
+}
+
+func (t *Throttle) Error() string {
+	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
+}
+
+// Unwrap returns the wrapped error for use by `errors.Is` and `errors.As`.
+func (t *Throttle) Unwrap() error {
+	return t.err
+}
+
+// Delay returns the minimum amount of time to wait before sending data again.
+func (t *Throttle) Delay() time.Duration {
+	return t.delay
+}
+
+// RateRatio returns the ratio of the current sending rate the destination is able to accept,
+// or 0 if the destination did not provide this hint.
+func (t *Throttle) RateRatio() float64 {
+	return t.rateRatio
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	inner := errors.New("resource exhausted")
+	err := fmt.Errorf("export failed: %w", NewThrottle(inner, time.Second))
+	require.EqualError(t, err, "export failed: Throttle (1s), error: resource exhausted")
+	require.ErrorIs(t, err, inner)
+	assert.False(t, IsPermanent(err))
+
+	var throttle *Throttle
+	require.ErrorAs(t, err, &throttle)
+	assert.Equal(t, time.Second, throttle.Delay())
+	assert.Zero(t, throttle.RateRatio())
+}
+
+func TestThrottle_RateRatio(t *testing.T) {
+	var throttle *Throttle
+	require.ErrorAs(t, NewThrottle(errors.New("slow down"), time.Second, WithRateRatio(0.5)), &throttle)
+	assert.Equal(t, 0.5, throttle.RateRatio())
+
+	require.ErrorAs(t, NewThrottle(errors.New("slow down"), time.Second, WithRateRatio(2)), &throttle)
+	assert.Zero(t, throttle.RateRatio())
+}
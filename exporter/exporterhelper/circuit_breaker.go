@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// CircuitBreakerConfig defines configuration for the circuit breaker of the exporter.
+type CircuitBreakerConfig = internal.CircuitBreakerConfig
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+// By default, the circuit opens after 5 consecutive failures and stays open for 30 seconds.
+var NewDefaultCircuitBreakerConfig = internal.NewDefaultCircuitBreakerConfig
+
+// WithCircuitBreaker enables the circuit breaker, which stops sending requests to a destination that keeps failing.
+// While the circuit is open, the requests stay in the sending queue if enabled, otherwise they are rejected
+// with a retryable error. The state of the circuit is reported through the component status.
+// The default is to always send the requests.
+func WithCircuitBreaker(config configoptional.Optional[CircuitBreakerConfig]) Option {
+	return internal.WithCircuitBreaker(config)
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// DeadLetterConfig defines configuration for storing the requests that could not be exported.
+type DeadLetterConfig = internal.DeadLetterConfig
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+// By default, the dead letter storage keeps up to 10000 requests.
+var NewDefaultDeadLetterConfig = internal.NewDefaultDeadLetterConfig
+
+// WithDeadLetter enables storing the requests that failed permanently, or for which all the
+// retries were exhausted, in a storage extension using the same encoding as the persistent queue.
+// The stored requests can be replayed later.
+// The default is to drop the requests.
+func WithDeadLetter(config configoptional.Optional[DeadLetterConfig]) Option {
+	return internal.WithDeadLetter(config)
+}
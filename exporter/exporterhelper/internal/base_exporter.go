@@ -38,8 +38,11 @@ type BaseExporter struct {
 	// Chain of senders that the exporter helper applies before passing the data to the actual exporter.
 	// The data is handled by each sender in the respective order starting from the QueueBatch.
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
-	QueueSender sender.Sender[request.Request]
-	RetrySender sender.Sender[request.Request]
+	QueueSender          sender.Sender[request.Request]
+	RetrySender          sender.Sender[request.Request]
+	CircuitBreakerSender sender.Sender[request.Request]
+	RateLimiterSender    sender.Sender[request.Request]
+	DeadLetterSender     sender.Sender[request.Request]
 
 	firstSender sender.Sender[request.Request]
 
@@ -47,21 +50,24 @@ type BaseExporter struct {
 
 	ExtraAttrs []attribute.KeyValue
 
-	timeoutCfg TimeoutConfig
-	retryCfg   configretry.BackOffConfig
+	timeoutCfg     TimeoutConfig
+	retryCfg       configretry.BackOffConfig
+	circuitBreaker configoptional.Optional[CircuitBreakerConfig]
+	rateLimiter    configoptional.Optional[RateLimiterConfig]
+	deadLetter     configoptional.Optional[DeadLetterConfig]
 
 	queueBatchSettings queuebatch.Settings[request.Request]
 	queueCfg           configoptional.Optional[queuebatch.Config]
 }
 
-func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (*BaseExporter, error) {
+func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (_ *BaseExporter, err error) {
 	be := &BaseExporter{
 		Set:        set,
 		timeoutCfg: NewDefaultTimeoutConfig(),
 	}
 
 	for _, op := range options {
-		if err := op(be); err != nil {
+		if err = op(be); err != nil {
 			return nil, err
 		}
 	}
@@ -75,30 +81,72 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		be.firstSender = newTimeoutSender(be.timeoutCfg, be.firstSender)
 	}
 
+	qSet := queuebatch.AllSettings[request.Request]{
+		Settings:  be.queueBatchSettings,
+		Signal:    signal,
+		ID:        set.ID,
+		Telemetry: set.TelemetrySettings,
+	}
+	// The adaptive concurrency observes every attempt to export the data, including the retries,
+	// while the QueueBatch limits the number of concurrent exports.
+	if be.queueCfg.HasValue() && be.queueCfg.Get().AdaptiveConcurrency.HasValue() {
+		qSet.ConcurrencyLimiter, err = queuebatch.NewConcurrencyLimiter(qSet, *be.queueCfg.Get().AdaptiveConcurrency.Get(), be.queueCfg.Get().NumConsumers)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				qSet.ConcurrencyLimiter.Shutdown()
+			}
+		}()
+		be.firstSender = qSet.ConcurrencyLimiter.AttemptSender(be.firstSender)
+	}
+
+	// The circuit breaker is placed after the retries, so every attempt is counted and the retries are throttled
+	// instead of being sent while the circuit is open.
+	// The requests wait while the circuit is open only if they can stay in the queue.
+	if be.circuitBreaker.HasValue() {
+		be.CircuitBreakerSender, err = newCircuitBreakerSender(*be.circuitBreaker.Get(), set, signal, be.queueCfg.HasValue(), be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.CircuitBreakerSender
+	}
+
+	// The rate limiter is placed after the retries, so every attempt to send data is limited.
+	if be.rateLimiter.HasValue() {
+		be.RateLimiterSender, err = newRateLimiterSender(*be.rateLimiter.Get(), be.firstSender)
+		if err != nil {
+			return nil, err
+		}
+		be.firstSender = be.RateLimiterSender
+	}
+
 	if be.retryCfg.Enabled {
 		be.RetrySender = newRetrySender(be.retryCfg, set, be.firstSender)
 		be.firstSender = be.RetrySender
 	}
 
-	var err error
 	batchEnabled := be.queueCfg.HasValue() && be.queueCfg.Get().Batch.HasValue()
 	be.firstSender, err = newObsReportSender(set, signal, be.ExtraAttrs, batchEnabled, be.firstSender)
 	if err != nil {
 		return nil, err
 	}
 
+	if be.deadLetter.HasValue() {
+		if be.queueBatchSettings.Encoding == nil {
+			return nil, errors.New("`Settings.Encoding` must not be nil when dead letter storage is enabled")
+		}
+		be.DeadLetterSender = newDeadLetterSender(*be.deadLetter.Get(), set, signal, be.queueBatchSettings.Encoding, be.firstSender)
+		be.firstSender = be.DeadLetterSender
+	}
+
 	if batchEnabled {
 		// Batcher mutates the data.
 		be.ConsumerOptions = append(be.ConsumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
 	}
 
 	if be.queueCfg.HasValue() {
-		qSet := queuebatch.AllSettings[request.Request]{
-			Settings:  be.queueBatchSettings,
-			Signal:    signal,
-			ID:        set.ID,
-			Telemetry: set.TelemetrySettings,
-		}
 		be.QueueSender, err = NewQueueSender(qSet, *be.queueCfg.Get(), be.ExportFailureMessage, be.firstSender)
 		if err != nil {
 			return nil, err
@@ -128,6 +176,19 @@ func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if be.CircuitBreakerSender != nil {
+		if err := be.CircuitBreakerSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	// Then start the dead letter storage, since the queue may dispatch persisted requests right away.
+	if be.DeadLetterSender != nil {
+		if err := be.DeadLetterSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// Last start the QueueBatch.
 	if be.QueueSender != nil {
 		return be.QueueSender.Start(ctx, host)
@@ -144,11 +205,24 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 		err = multierr.Append(err, be.RetrySender.Shutdown(ctx))
 	}
 
+	// Also shutdown the circuit breaker and the rate limiter, so the waiting requests are released.
+	if be.CircuitBreakerSender != nil {
+		err = multierr.Append(err, be.CircuitBreakerSender.Shutdown(ctx))
+	}
+	if be.RateLimiterSender != nil {
+		err = multierr.Append(err, be.RateLimiterSender.Shutdown(ctx))
+	}
+
 	// Then shutdown the queue sender.
 	if be.QueueSender != nil {
 		err = multierr.Append(err, be.QueueSender.Shutdown(ctx))
 	}
 
+	// Then shutdown the dead letter storage, after the queue is drained.
+	if be.DeadLetterSender != nil {
+		err = multierr.Append(err, be.DeadLetterSender.Shutdown(ctx))
+	}
+
 	// Last shutdown the wrapped exporter itself.
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
@@ -193,6 +267,33 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	}
 }
 
+// WithCircuitBreaker enables the circuit breaker, which stops sending requests while the destination keeps failing.
+// The default is to always send the requests.
+func WithCircuitBreaker(cfg configoptional.Optional[CircuitBreakerConfig]) Option {
+	return func(o *BaseExporter) error {
+		o.circuitBreaker = cfg
+		return nil
+	}
+}
+
+// WithRateLimiter enables the client-side rate limiter, which caps the rate at which the data is sent.
+// The default is to not limit the rate.
+func WithRateLimiter(cfg configoptional.Optional[RateLimiterConfig]) Option {
+	return func(o *BaseExporter) error {
+		o.rateLimiter = cfg
+		return nil
+	}
+}
+
+// WithDeadLetter enables storing the requests that could not be exported in the configured storage extension.
+// The default is to drop the requests.
+func WithDeadLetter(cfg configoptional.Optional[DeadLetterConfig]) Option {
+	return func(o *BaseExporter) error {
+		o.deadLetter = cfg
+		return nil
+	}
+}
+
 // WithQueue overrides the default queuebatch.Config for an exporter.
 // The default queuebatch.Config is to disable queueing.
 // This option cannot be used with the new exporter helpers New[Traces|Metrics|Logs]RequestExporter.
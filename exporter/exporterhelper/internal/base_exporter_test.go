@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -267,6 +268,45 @@ func TestQueueRetryWithDisabledQueue(t *testing.T) {
 	}
 }
 
+func TestBaseExporterWithCircuitBreaker(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 1
+	cfg.OpenDuration = time.Hour
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, errExport,
+		WithCircuitBreaker(configoptional.Some(cfg)))
+	require.NoError(t, err)
+	require.NotNil(t, be.CircuitBreakerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.EqualError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}), "my error")
+	// Without a queue, the requests are rejected while the circuit is open.
+	require.ErrorIs(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}), errCircuitBreakerOpen)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterWithAdaptiveConcurrency(t *testing.T) {
+	qCfg := NewDefaultQueueConfig()
+	qCfg.WaitForResult = true
+	qCfg.AdaptiveConcurrency.GetOrInsertDefault()
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	attempts := 0
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs,
+		func(context.Context, request.Request) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("unavailable")
+			}
+			return nil
+		},
+		WithRetry(rCfg),
+		WithQueueBatch(configoptional.Some(qCfg), queuebatch.Settings[request.Request]{}))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.Equal(t, 3, attempts)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
 func errExport(context.Context, request.Request) error {
 	return errors.New("my error")
 }
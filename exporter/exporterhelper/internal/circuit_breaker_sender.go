@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var errCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerConfig defines the configuration of the circuit breaker, which stops sending requests
+// to a destination that keeps failing. The circuit opens when the consecutive failures or the failure
+// ratio reach the configured thresholds, and is closed again once the probe requests succeed.
+type CircuitBreakerConfig struct {
+	// ConsecutiveFailures is the number of consecutive failed exports that opens the circuit. If 0, it is not considered.
+	ConsecutiveFailures int `mapstructure:"consecutive_failures"`
+
+	// FailureRatio is the ratio of failed exports during the Window that opens the circuit, between 0 and 1.
+	// If 0, it is not considered.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+
+	// MinRequests is the minimum number of exports during the Window before the FailureRatio is considered.
+	MinRequests int `mapstructure:"min_requests"`
+
+	// Window is the period after which the exports counted for the FailureRatio are reset.
+	Window time.Duration `mapstructure:"window"`
+
+	// OpenDuration is the time the circuit stays open before allowing probe requests.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// HalfOpenRequests is the number of probe requests sent while the circuit is half-open.
+	// The circuit is closed when all of them succeed, and opened again as soon as one fails.
+	HalfOpenRequests int `mapstructure:"half_open_requests"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *CircuitBreakerConfig) Validate() error {
+	if cfg.ConsecutiveFailures < 0 {
+		return errors.New("`consecutive_failures` must be non-negative")
+	}
+	if cfg.FailureRatio < 0 || cfg.FailureRatio > 1 {
+		return fmt.Errorf("`failure_ratio` must be between 0 and 1, found %v", cfg.FailureRatio)
+	}
+	if cfg.ConsecutiveFailures == 0 && cfg.FailureRatio == 0 {
+		return errors.New("at least one of `consecutive_failures` or `failure_ratio` must be set")
+	}
+	if cfg.FailureRatio > 0 && cfg.Window <= 0 {
+		return errors.New("`window` must be positive when `failure_ratio` is set")
+	}
+	if cfg.MinRequests < 0 {
+		return errors.New("`min_requests` must be non-negative")
+	}
+	if cfg.OpenDuration <= 0 {
+		return errors.New("`open_duration` must be positive")
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		return errors.New("`half_open_requests` must be positive")
+	}
+	return nil
+}
+
+// NewDefaultCircuitBreakerConfig returns the default config for CircuitBreakerConfig.
+func NewDefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		ConsecutiveFailures: 5,
+		MinRequests:         20,
+		Window:              time.Minute,
+		OpenDuration:        30 * time.Second,
+		HalfOpenRequests:    1,
+	}
+}
+
+type circuitState int64
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreakerSender is a requestSender that stops sending requests while the destination keeps failing.
+// If blockWhileOpen is true, the requests wait until the circuit allows them, so they stay in the queue,
+// otherwise they are rejected with a throttle error until the circuit is half-open.
+type circuitBreakerSender struct {
+	cfg            CircuitBreakerConfig
+	blockWhileOpen bool
+	logger         *zap.Logger
+	tb             *metadata.TelemetryBuilder
+	attrs          attribute.Set
+	stopOnce       sync.Once
+	stopCh         chan struct{}
+	next           sender.Sender[request.Request]
+
+	mu    sync.Mutex
+	host  component.Host
+	state circuitState
+	// generation is incremented on every transition, so the results of the requests sent in a previous state are ignored.
+	generation          uint64
+	stateChanged        chan struct{}
+	openedAt            time.Time
+	consecutiveFailures int
+	windowStart         time.Time
+	windowRequests      int
+	windowFailures      int
+	halfOpenInFlight    int
+	halfOpenSuccesses   int
+}
+
+func newCircuitBreakerSender(cfg CircuitBreakerConfig, set exporter.Settings, signal pipeline.Signal, blockWhileOpen bool, next sender.Sender[request.Request]) (*circuitBreakerSender, error) {
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	cb := &circuitBreakerSender{
+		cfg:            cfg,
+		blockWhileOpen: blockWhileOpen,
+		logger:         set.Logger,
+		tb:             tb,
+		attrs: attribute.NewSet(
+			attribute.String(ExporterKey, set.ID.String()),
+			attribute.String(DataTypeKey, signal.String())),
+		stopCh:       make(chan struct{}),
+		next:         next,
+		stateChanged: make(chan struct{}),
+		windowStart:  time.Now(),
+	}
+	if err = tb.RegisterExporterCircuitBreakerStateCallback(func(_ context.Context, o metric.Int64Observer) error {
+		cb.mu.Lock()
+		defer cb.mu.Unlock()
+		o.Observe(int64(cb.state), metric.WithAttributeSet(cb.attrs))
+		return nil
+	}); err != nil {
+		tb.Shutdown()
+		return nil, err
+	}
+	return cb, nil
+}
+
+func (cb *circuitBreakerSender) Start(_ context.Context, host component.Host) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.host = host
+	return nil
+}
+
+func (cb *circuitBreakerSender) Shutdown(context.Context) error {
+	cb.stopOnce.Do(func() {
+		close(cb.stopCh)
+		cb.tb.Shutdown()
+	})
+	return nil
+}
+
+// Send implements the requestSender interface
+func (cb *circuitBreakerSender) Send(ctx context.Context, req request.Request) error {
+	for {
+		generation, allowed, stateChanged, wait := cb.acquire()
+		if allowed {
+			err := cb.next.Send(ctx, req)
+			cb.onDone(generation, err)
+			return err
+		}
+		if !cb.blockWhileOpen {
+			return consumererror.NewThrottle(errCircuitBreakerOpen, wait)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("request is cancelled or timed out: %w", errCircuitBreakerOpen)
+		case <-cb.stopCh:
+			timer.Stop()
+			return experr.NewShutdownErr(errCircuitBreakerOpen)
+		case <-stateChanged:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// acquire returns whether a request can be sent in the current state. If not, it returns a channel closed
+// on the next transition and the time to wait before the circuit may allow the request.
+func (cb *circuitBreakerSender) acquire() (uint64, bool, <-chan struct{}, time.Duration) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == circuitOpen {
+		remaining := cb.cfg.OpenDuration - time.Since(cb.openedAt)
+		if remaining > 0 {
+			return cb.generation, false, cb.stateChanged, remaining
+		}
+		cb.transitionLocked(circuitHalfOpen, nil)
+	}
+	if cb.state == circuitHalfOpen {
+		if cb.halfOpenInFlight >= cb.cfg.HalfOpenRequests {
+			return cb.generation, false, cb.stateChanged, cb.cfg.OpenDuration
+		}
+		cb.halfOpenInFlight++
+	}
+	return cb.generation, true, nil, 0
+}
+
+func (cb *circuitBreakerSender) onDone(generation uint64, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if generation != cb.generation {
+		// The state changed since the request was sent.
+		return
+	}
+	// Permanent errors mean the destination is able to respond, the requests interrupted by a shutdown are not counted.
+	failed := err != nil && !consumererror.IsPermanent(err) && !experr.IsShutdownErr(err)
+	switch cb.state {
+	case circuitHalfOpen:
+		cb.halfOpenInFlight--
+		if failed {
+			cb.transitionLocked(circuitOpen, err)
+			return
+		}
+		if err == nil || consumererror.IsPermanent(err) {
+			cb.halfOpenSuccesses++
+		}
+		if cb.halfOpenSuccesses >= cb.cfg.HalfOpenRequests {
+			cb.transitionLocked(circuitClosed, nil)
+		}
+	case circuitClosed:
+		if experr.IsShutdownErr(err) {
+			return
+		}
+		if time.Since(cb.windowStart) > cb.cfg.Window {
+			cb.windowStart = time.Now()
+			cb.windowRequests = 0
+			cb.windowFailures = 0
+		}
+		cb.windowRequests++
+		if !failed {
+			cb.consecutiveFailures = 0
+			return
+		}
+		cb.windowFailures++
+		cb.consecutiveFailures++
+		if (cb.cfg.ConsecutiveFailures > 0 && cb.consecutiveFailures >= cb.cfg.ConsecutiveFailures) ||
+			(cb.cfg.FailureRatio > 0 && cb.windowRequests >= cb.cfg.MinRequests &&
+				float64(cb.windowFailures)/float64(cb.windowRequests) >= cb.cfg.FailureRatio) {
+			cb.transitionLocked(circuitOpen, err)
+		}
+	}
+}
+
+// transitionLocked changes the state of the circuit and wakes up the waiting requests. Callers MUST hold the mutex.
+func (cb *circuitBreakerSender) transitionLocked(state circuitState, err error) {
+	cb.state = state
+	cb.generation++
+	cb.consecutiveFailures = 0
+	cb.windowStart = time.Now()
+	cb.windowRequests = 0
+	cb.windowFailures = 0
+	cb.halfOpenInFlight = 0
+	cb.halfOpenSuccesses = 0
+	close(cb.stateChanged)
+	cb.stateChanged = make(chan struct{})
+
+	cb.tb.ExporterCircuitBreakerTransitions.Add(context.Background(), 1, metric.WithAttributeSet(cb.attrs),
+		metric.WithAttributes(attribute.String("state", state.String())))
+	switch state {
+	case circuitOpen:
+		cb.openedAt = time.Now()
+		cb.logger.Warn("Circuit breaker is open, exports are paused.",
+			zap.Error(err), zap.Duration("open_duration", cb.cfg.OpenDuration))
+		cb.reportStatusLocked(componentstatus.NewRecoverableErrorEvent(fmt.Errorf("%w: %w", errCircuitBreakerOpen, err)))
+	case circuitHalfOpen:
+		cb.logger.Info("Circuit breaker is half-open, sending probe requests.")
+	case circuitClosed:
+		cb.logger.Info("Circuit breaker is closed, exports are resumed.")
+		cb.reportStatusLocked(componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+}
+
+func (cb *circuitBreakerSender) reportStatusLocked(ev *componentstatus.Event) {
+	if cb.host != nil {
+		componentstatus.ReportStatus(cb.host, ev)
+	}
+}
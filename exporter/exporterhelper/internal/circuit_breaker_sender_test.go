@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+type statusHost struct {
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (*statusHost) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
+
+func (h *statusHost) Report(e *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var statuses []componentstatus.Status
+	for _, e := range h.events {
+		statuses = append(statuses, e.Status())
+	}
+	return statuses
+}
+
+func TestCircuitBreakerConfigValidate(t *testing.T) {
+	cfg := NewDefaultCircuitBreakerConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 0
+	require.EqualError(t, cfg.Validate(), "at least one of `consecutive_failures` or `failure_ratio` must be set")
+
+	cfg.FailureRatio = 1.5
+	require.EqualError(t, cfg.Validate(), "`failure_ratio` must be between 0 and 1, found 1.5")
+
+	cfg.FailureRatio = 0.5
+	cfg.Window = 0
+	require.EqualError(t, cfg.Validate(), "`window` must be positive when `failure_ratio` is set")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.OpenDuration = 0
+	require.EqualError(t, cfg.Validate(), "`open_duration` must be positive")
+
+	cfg = NewDefaultCircuitBreakerConfig()
+	cfg.HalfOpenRequests = 0
+	require.EqualError(t, cfg.Validate(), "`half_open_requests` must be positive")
+}
+
+func newTestCircuitBreaker(t *testing.T, tt *componenttest.Telemetry, cfg CircuitBreakerConfig, blockWhileOpen bool, sink *requesttest.Sink) (*circuitBreakerSender, *statusHost) {
+	set := exportertest.NewNopSettings(exportertest.NopType)
+	set.ID = component.MustNewID("otlp")
+	set.TelemetrySettings = tt.NewTelemetrySettings()
+	cb, err := newCircuitBreakerSender(cfg, set, pipeline.SignalTraces, blockWhileOpen, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+	host := &statusHost{}
+	require.NoError(t, cb.Start(context.Background(), host))
+	return cb, host
+}
+
+func TestCircuitBreakerSenderConsecutiveFailures(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 2
+	cfg.OpenDuration = 50 * time.Millisecond
+	sink := requesttest.NewSink()
+	cb, host := newTestCircuitBreaker(t, tt, cfg, false, sink)
+
+	// Permanent errors do not open the circuit.
+	sink.SetExportErr(consumererror.NewPermanent(errors.New("bad data")))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	sink.SetExportErr(consumererror.NewPermanent(errors.New("bad data")))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, circuitClosed, cb.state)
+
+	unavailable := errors.New("unavailable")
+	sink.SetExportErr(unavailable)
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), unavailable)
+	sink.SetExportErr(unavailable)
+	require.ErrorIs(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}), unavailable)
+	assert.Equal(t, circuitOpen, cb.state)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// While open, the requests are rejected with a throttle error without being sent.
+	err := cb.Send(context.Background(), &requesttest.FakeRequest{Items: 5})
+	var throttleErr *consumererror.Throttle
+	require.ErrorAs(t, err, &throttleErr)
+	assert.LessOrEqual(t, throttleErr.Delay(), 50*time.Millisecond)
+	require.ErrorIs(t, err, errCircuitBreakerOpen)
+	assert.Equal(t, 0, sink.ItemsCount())
+
+	metadatatest.AssertEqualExporterCircuitBreakerState(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", "otlp"),
+					attribute.String("data_type", "traces")),
+				Value: int64(circuitOpen),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+
+	// After the open duration, a probe request closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	assert.Equal(t, circuitClosed, cb.state)
+	assert.Equal(t, 3, sink.ItemsCount())
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+
+	metadatatest.AssertEqualExporterCircuitBreakerTransitions(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", "otlp"),
+					attribute.String("data_type", "traces"),
+					attribute.String("state", "open")),
+				Value: 1,
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", "otlp"),
+					attribute.String("data_type", "traces"),
+					attribute.String("state", "half_open")),
+				Value: 1,
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", "otlp"),
+					attribute.String("data_type", "traces"),
+					attribute.String("state", "closed")),
+				Value: 1,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, cb.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerSenderFailureRatio(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 0
+	cfg.FailureRatio = 0.5
+	cfg.MinRequests = 4
+	sink := requesttest.NewSink()
+	cb, _ := newTestCircuitBreaker(t, tt, cfg, false, sink)
+
+	for range 2 {
+		assert.Equal(t, circuitClosed, cb.state)
+		require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		sink.SetExportErr(errors.New("unavailable"))
+		require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	// Half of the 4 requests failed.
+	assert.Equal(t, circuitOpen, cb.state)
+	require.NoError(t, cb.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerSenderHalfOpenFailure(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 1
+	cfg.OpenDuration = 10 * time.Millisecond
+	cfg.HalfOpenRequests = 2
+	sink := requesttest.NewSink()
+	cb, _ := newTestCircuitBreaker(t, tt, cfg, false, sink)
+
+	sink.SetExportErr(errors.New("unavailable"))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, circuitOpen, cb.state)
+
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, circuitHalfOpen, cb.state)
+	// A failed probe opens the circuit again.
+	sink.SetExportErr(errors.New("unavailable"))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, circuitOpen, cb.state)
+	require.NoError(t, cb.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerSenderBlockWhileOpen(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := NewDefaultCircuitBreakerConfig()
+	cfg.ConsecutiveFailures = 1
+	cfg.OpenDuration = 50 * time.Millisecond
+	sink := requesttest.NewSink()
+	cb, _ := newTestCircuitBreaker(t, tt, cfg, true, sink)
+
+	sink.SetExportErr(errors.New("unavailable"))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+
+	// The request waits for the circuit to be half-open, then it is sent as a probe.
+	start := time.Now()
+	require.NoError(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.LessOrEqual(t, 40*time.Millisecond, time.Since(start))
+	assert.Equal(t, 2, sink.ItemsCount())
+	assert.Equal(t, circuitClosed, cb.state)
+
+	// The waiting requests are released on cancellation and shutdown.
+	sink.SetExportErr(errors.New("unavailable"))
+	require.Error(t, cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cb.Send(ctx, &requesttest.FakeRequest{Items: 1}), errCircuitBreakerOpen)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, cb.Shutdown(context.Background()))
+	}()
+	assert.True(t, experr.IsShutdownErr(cb.Send(context.Background(), &requesttest.FakeRequest{Items: 1})))
+	// Shutting down again is a no-op.
+	assert.NoError(t, cb.Shutdown(context.Background()))
+}
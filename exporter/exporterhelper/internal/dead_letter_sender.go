@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// DeadLetterStorageName returns the name of the storage client used to store the dead letter requests
+// of the exporter for the given signal.
+func DeadLetterStorageName(signal pipeline.Signal) string {
+	return signal.String() + "_dead_letter"
+}
+
+// DeadLetterConfig defines configuration for storing the requests that failed permanently,
+// or for which all the retries were exhausted.
+type DeadLetterConfig struct {
+	// StorageID is the storage extension used to persist the failed requests.
+	StorageID component.ID `mapstructure:"storage"`
+
+	// QueueSize is the maximum number of requests kept in the dead letter storage.
+	// When the limit is reached, new failed requests are dropped.
+	QueueSize int64 `mapstructure:"queue_size"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *DeadLetterConfig) Validate() error {
+	if cfg.StorageID == (component.ID{}) {
+		return errors.New("`storage` must be set")
+	}
+	if cfg.QueueSize <= 0 {
+		return errors.New("`queue_size` must be positive")
+	}
+	return nil
+}
+
+// NewDefaultDeadLetterConfig returns the default config for DeadLetterConfig.
+func NewDefaultDeadLetterConfig() DeadLetterConfig {
+	return DeadLetterConfig{
+		QueueSize: 10_000,
+	}
+}
+
+// deadLetterSender is a requestSender that writes the requests that failed to be exported
+// to the configured storage extension, so they can be replayed later.
+type deadLetterSender struct {
+	logger *zap.Logger
+	queue  queue.Queue[request.Request]
+	next   sender.Sender[request.Request]
+}
+
+func newDeadLetterSender(
+	cfg DeadLetterConfig,
+	set exporter.Settings,
+	signal pipeline.Signal,
+	encoding queue.Encoding[request.Request],
+	next sender.Sender[request.Request],
+) *deadLetterSender {
+	return &deadLetterSender{
+		logger: set.Logger,
+		queue: queue.NewDeadLetterQueue(queue.Settings[request.Request]{
+			SizerType:   request.SizerTypeRequests,
+			Capacity:    cfg.QueueSize,
+			Signal:      signal,
+			StorageID:   &cfg.StorageID,
+			StorageName: DeadLetterStorageName(signal),
+			Encoding:    encoding,
+			ID:          set.ID,
+			Telemetry:   set.TelemetrySettings,
+		}),
+		next: next,
+	}
+}
+
+func (ds *deadLetterSender) Start(ctx context.Context, host component.Host) error {
+	return ds.queue.Start(ctx, host)
+}
+
+func (ds *deadLetterSender) Shutdown(ctx context.Context) error {
+	return ds.queue.Shutdown(ctx)
+}
+
+// Send implements the requestSender interface
+func (ds *deadLetterSender) Send(ctx context.Context, req request.Request) error {
+	// Have to read the number of items before sending the request since the request can
+	// be modified by the downstream components.
+	itemsCount := req.ItemsCount()
+	err := ds.next.Send(ctx, req)
+	// Requests interrupted by a shutdown are not dead-lettered, the persistent queue (if any) retains them.
+	if err == nil || experr.IsShutdownErr(err) {
+		return err
+	}
+	// Only the requests that failed permanently or exhausted their retries are dead-lettered, the other failures,
+	// e.g. rejected by the circuit breaker, the rate limiter or the cancellation of the context, are returned.
+	if !consumererror.IsPermanent(err) && !experr.IsRetriesExhaustedErr(err) {
+		return err
+	}
+
+	// The request context may already be cancelled or expired, the storage write must not be affected by that.
+	if dlErr := ds.queue.Offer(context.WithoutCancel(ctx), req); dlErr != nil {
+		ds.logger.Error("Failed to store the request in the dead letter storage.",
+			zap.Error(dlErr), zap.Int("dropped_items", itemsCount))
+		return err
+	}
+
+	ds.logger.Warn("Exporting failed. Stored data in the dead letter storage.",
+		zap.Error(err), zap.Int("stored_items", itemsCount))
+	return nil
+}
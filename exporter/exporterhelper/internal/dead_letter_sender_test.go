@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestDeadLetterConfigValidate(t *testing.T) {
+	cfg := NewDefaultDeadLetterConfig()
+	require.EqualError(t, cfg.Validate(), "`storage` must be set")
+
+	cfg.StorageID = component.MustNewID("file_storage")
+	require.NoError(t, cfg.Validate())
+
+	cfg.QueueSize = 0
+	require.EqualError(t, cfg.Validate(), "`queue_size` must be positive")
+}
+
+func TestDeadLetterSender(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{storageID: ext})
+	set := exportertest.NewNopSettings(exportertest.NopType)
+
+	cfg := NewDefaultDeadLetterConfig()
+	cfg.StorageID = storageID
+	cfg.QueueSize = 1
+	sink := requesttest.NewSink()
+	ds := newDeadLetterSender(cfg, set, pipeline.SignalTraces, fakeEncoding{}, sender.NewSender(sink.Export))
+	require.NoError(t, ds.Start(context.Background(), host))
+
+	require.NoError(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.Equal(t, 2, sink.ItemsCount())
+
+	// Failures interrupted by a shutdown are returned and not stored.
+	shutdownErr := experr.NewShutdownErr(errors.New("shutdown"))
+	sink.SetExportErr(shutdownErr)
+	require.ErrorIs(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 3}), shutdownErr)
+
+	// Failures that may be retried are returned and not stored.
+	retryableErr := consumererror.NewThrottle(errors.New("circuit breaker is open"), time.Second)
+	sink.SetExportErr(retryableErr)
+	require.ErrorIs(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 3}), retryableErr)
+	cancelledErr := fmt.Errorf("request is cancelled or timed out: %w", context.Canceled)
+	sink.SetExportErr(cancelledErr)
+	require.ErrorIs(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 3}), cancelledErr)
+
+	// Permanent failures are stored in the dead letter storage.
+	sink.SetExportErr(consumererror.NewPermanent(errors.New("bad data")))
+	require.NoError(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 4}))
+
+	// The dead letter storage is full, the error is returned.
+	expErr := consumererror.NewPermanent(errors.New("bad data"))
+	sink.SetExportErr(expErr)
+	require.ErrorIs(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 5}), expErr)
+	require.NoError(t, ds.Shutdown(context.Background()))
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, set.ID, DeadLetterStorageName(pipeline.SignalTraces))
+	require.NoError(t, err)
+	val, err := client.Get(context.Background(), "0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mockRequest"), val)
+	val, err = client.Get(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestDeadLetterSenderRetriesExhausted(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{storageID: ext})
+	set := exportertest.NewNopSettings(exportertest.NopType)
+
+	cfg := NewDefaultDeadLetterConfig()
+	cfg.StorageID = storageID
+	retryCfg := configretry.NewDefaultBackOffConfig()
+	retryCfg.InitialInterval = time.Millisecond
+	retryCfg.MaxElapsedTime = 10 * time.Millisecond
+	rs := newRetrySender(retryCfg, set, sender.NewSender(errExport))
+	ds := newDeadLetterSender(cfg, set, pipeline.SignalTraces, fakeEncoding{}, rs)
+	require.NoError(t, ds.Start(context.Background(), host))
+
+	require.NoError(t, ds.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, rs.Shutdown(context.Background()))
+	require.NoError(t, ds.Shutdown(context.Background()))
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, set.ID, DeadLetterStorageName(pipeline.SignalTraces))
+	require.NoError(t, err)
+	val, err := client.Get(context.Background(), "0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mockRequest"), val)
+}
+
+func TestBaseExporterDeadLetter(t *testing.T) {
+	cfg := NewDefaultDeadLetterConfig()
+	cfg.StorageID = component.MustNewID("file_storage")
+	_, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, errExport,
+		WithDeadLetter(configoptional.Some(cfg)))
+	require.EqualError(t, err, "`Settings.Encoding` must not be nil when dead letter storage is enabled")
+
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{cfg.StorageID: ext})
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs,
+		func(context.Context, request.Request) error {
+			return consumererror.NewPermanent(errors.New("bad data"))
+		},
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithDeadLetter(configoptional.Some(cfg)))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	// Without retries, the failures that are not permanent are not stored.
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalLogs, errExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithDeadLetter(configoptional.Some(cfg)))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	require.Error(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Shutdown(context.Background()))
+}
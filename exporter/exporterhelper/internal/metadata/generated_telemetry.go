@@ -28,6 +28,8 @@ type TelemetryBuilder struct {
 	meter                               metric.Meter
 	mu                                  sync.Mutex
 	registrations                       []metric.Registration
+	ExporterCircuitBreakerState         metric.Int64ObservableGauge
+	ExporterCircuitBreakerTransitions   metric.Int64Counter
 	ExporterEnqueueFailedLogRecords     metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints   metric.Int64Counter
 	ExporterEnqueueFailedProfileSamples metric.Int64Counter
@@ -38,6 +40,8 @@ type TelemetryBuilder struct {
 	ExporterQueueBatchSendSize          metric.Int64Histogram
 	ExporterQueueBatchSendSizeBytes     metric.Int64Histogram
 	ExporterQueueCapacity               metric.Int64ObservableGauge
+	ExporterQueueConcurrency            metric.Int64ObservableGauge
+	ExporterQueueDropped                metric.Int64Counter
 	ExporterQueueSize                   metric.Int64ObservableGauge
 	ExporterSendFailedLogRecords        metric.Int64Counter
 	ExporterSendFailedMetricPoints      metric.Int64Counter
@@ -60,6 +64,21 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterExporterCircuitBreakerStateCallback sets callback for observable ExporterCircuitBreakerState metric.
+func (builder *TelemetryBuilder) RegisterExporterCircuitBreakerStateCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterCircuitBreakerState, obs: o})
+		return nil
+	}, builder.ExporterCircuitBreakerState)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueCapacityCallback sets callback for observable ExporterQueueCapacity metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -75,6 +94,21 @@ func (builder *TelemetryBuilder) RegisterExporterQueueCapacityCallback(cb metric
 	return nil
 }
 
+// RegisterExporterQueueConcurrencyCallback sets callback for observable ExporterQueueConcurrency metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueConcurrencyCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueConcurrency, obs: o})
+		return nil
+	}, builder.ExporterQueueConcurrency)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterExporterQueueSizeCallback sets callback for observable ExporterQueueSize metric.
 func (builder *TelemetryBuilder) RegisterExporterQueueSizeCallback(cb metric.Int64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -118,6 +152,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ExporterCircuitBreakerState, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_circuit_breaker_state",
+		metric.WithDescription("Current state of the circuit breaker: 0 when closed, 1 when open, 2 when half-open. Only recorded when `circuit_breaker` is enabled. [Development]"),
+		metric.WithUnit("{state}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterCircuitBreakerTransitions, err = builder.meter.Int64Counter(
+		"otelcol_exporter_circuit_breaker_transitions",
+		metric.WithDescription("Number of state transitions of the circuit breaker. Includes the attribute state: the new state of the circuit breaker, `closed`, `open` or `half_open`. [Development]"),
+		metric.WithUnit("{transition}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterEnqueueFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_enqueue_failed_log_records",
 		metric.WithDescription("Number of log records failed to be added to the sending queue. [Alpha]"),
@@ -182,6 +228,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{batch}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueConcurrency, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_concurrency",
+		metric.WithDescription("Current number of concurrent exports allowed by the sending queue. Only recorded when `adaptive_concurrency` is enabled. [Development]"),
+		metric.WithUnit("{consumer}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterQueueDropped, err = builder.meter.Int64Counter(
+		"otelcol_exporter_queue_dropped",
+		metric.WithDescription("Number of items (spans, metric points, log records, profile samples) dropped from the sending queue before being sent. Includes the attribute reason: `expired` when the item exceeded `max_age`, `overflow` when the item was dropped to make space for newer data. [Development]"),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterQueueSize, err = builder.meter.Int64ObservableGauge(
 		"otelcol_exporter_queue_size",
 		metric.WithDescription("Current size of the retry queue (in batches). [Alpha]"),
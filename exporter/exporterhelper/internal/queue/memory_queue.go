@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
@@ -31,11 +32,18 @@ type memoryQueue[T request.Request] struct {
 	refCounter ReferenceCounter[T]
 	sizer      request.Sizer
 	cap        int64
+	maxAge     time.Duration
+	dropOldest bool
+	onDropped  droppedFunc[T]
+	priority   *PrioritySettings[T]
 
 	mu              sync.Mutex
 	hasMoreElements *sync.Cond
 	hasMoreSpace    *cond
-	items           *linkedQueue[T]
+	// lanes holds the waiting requests of every priority lane, lane 0 being the highest priority.
+	lanes []*linkedQueue[T]
+	// skipped counts for every lane the requests read from higher lanes while the lane was not empty.
+	skipped         []int
 	size            int64
 	stopped         bool
 	waitForResult   bool
@@ -49,10 +57,21 @@ func newMemoryQueue[T request.Request](set Settings[T]) readableQueue[T] {
 		refCounter:      set.ReferenceCounter,
 		sizer:           request.NewSizer(set.SizerType),
 		cap:             set.Capacity,
-		items:           &linkedQueue[T]{},
+		maxAge:          set.MaxAge,
+		dropOldest:      set.OverflowPolicy == OverflowPolicyDropOldest,
+		priority:        set.Priority,
 		waitForResult:   set.WaitForResult,
 		blockOnOverflow: set.BlockOnOverflow,
 	}
+	numLanes := 1
+	if set.Priority != nil {
+		numLanes = set.Priority.NumLanes
+	}
+	sq.lanes = make([]*linkedQueue[T], numLanes)
+	for i := range sq.lanes {
+		sq.lanes[i] = &linkedQueue[T]{}
+	}
+	sq.skipped = make([]int, numLanes)
 	sq.hasMoreElements = sync.NewCond(&sq.mu)
 	sq.hasMoreSpace = newCond(&sq.mu)
 	return sq
@@ -76,11 +95,16 @@ func (mq *memoryQueue[T]) Offer(ctx context.Context, el T) error {
 		return errSizeTooLarge
 	}
 
+	lane := 0
+	if mq.priority != nil {
+		lane = mq.priority.GetLane(ctx, el)
+	}
+
 	if mq.refCounter != nil {
 		mq.refCounter.Ref(el)
 	}
 
-	done, err := mq.add(ctx, el, elSize)
+	done, err := mq.add(ctx, el, elSize, lane)
 	if err != nil {
 		// Unref in case of an error since there will not be any async worker to pick it up.
 		if mq.refCounter != nil {
@@ -104,11 +128,15 @@ func (mq *memoryQueue[T]) Offer(ctx context.Context, el T) error {
 	return nil
 }
 
-func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockingDone, error) {
+func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64, lane int) (*blockingDone, error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
 	for mq.size+elSize > mq.cap {
+		// Make space by dropping the expired requests, or the oldest requests if configured, before rejecting.
+		if elSize <= mq.cap && (mq.dropHead(dropReasonExpired) || (mq.dropOldest && mq.dropHead(dropReasonOverflow))) {
+			continue
+		}
 		if !mq.blockOnOverflow {
 			return nil, ErrQueueIsFull
 		}
@@ -128,7 +156,7 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 		ctx = context.WithoutCancel(ctx)
 	}
 
-	mq.items.push(ctx, el, done)
+	mq.lanes[lane].push(ctx, el, done, time.Now())
 	// Signal one consumer if any.
 	mq.hasMoreElements.Signal()
 	return done, nil
@@ -142,8 +170,11 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 	defer mq.mu.Unlock()
 
 	for {
-		if mq.items.hasElements() {
-			elCtx, el, done := mq.items.pop()
+		if mq.dropHead(dropReasonExpired) {
+			continue
+		}
+		if lane := mq.nextLane(); lane >= 0 {
+			elCtx, el, done := mq.lanes[lane].pop()
 			return elCtx, el, done, true
 		}
 
@@ -158,9 +189,81 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 	}
 }
 
+// nextLane returns the lane to read the next request from, or -1 if all lanes are empty.
+// The highest priority non-empty lane is read, unless a lower priority lane reached the starvation limit.
+// Callers MUST hold the mutex.
+func (mq *memoryQueue[T]) nextLane() int {
+	next := -1
+	for i, l := range mq.lanes {
+		if !l.hasElements() {
+			continue
+		}
+		if next < 0 {
+			next = i
+			continue
+		}
+		if mq.skipped[i] >= mq.priority.StarvationLimit {
+			next = i
+			break
+		}
+	}
+	if next < 0 {
+		return next
+	}
+	mq.skipped[next] = 0
+	for i := next + 1; i < len(mq.lanes); i++ {
+		if mq.lanes[i].hasElements() {
+			mq.skipped[i]++
+		}
+	}
+	return next
+}
+
+// dropHead drops a request at the head of a lane and returns true if a request can be dropped for the given reason.
+// Expired requests are dropped from any lane, otherwise the request is dropped from the lowest priority lane.
+// Callers MUST hold the mutex.
+func (mq *memoryQueue[T]) dropHead(reason dropReason) bool {
+	if reason == dropReasonExpired && mq.maxAge <= 0 {
+		return false
+	}
+	lane := -1
+	for i := len(mq.lanes) - 1; i >= 0; i-- {
+		l := mq.lanes[i]
+		if l.hasElements() && (reason != dropReasonExpired || time.Since(l.head.enqueuedAt) > mq.maxAge) {
+			lane = i
+			break
+		}
+	}
+	if lane < 0 {
+		return false
+	}
+	elCtx, el, done := mq.lanes[lane].pop()
+	if mq.onDropped != nil {
+		mq.onDropped(elCtx, el, reason)
+	}
+	if mq.refCounter != nil {
+		mq.refCounter.Unref(el)
+	}
+	err := errRequestExpired
+	if reason == dropReasonOverflow {
+		err = errRequestEvicted
+	}
+	mq.onDoneLocked(done.(*blockingDone), err)
+	return true
+}
+
+func (mq *memoryQueue[T]) setDroppedFunc(onDropped droppedFunc[T]) {
+	mq.onDropped = onDropped
+}
+
 func (mq *memoryQueue[T]) onDone(bd *blockingDone, err error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
+	mq.onDoneLocked(bd, err)
+}
+
+// onDoneLocked releases the space used by the request. Callers MUST hold the mutex.
+func (mq *memoryQueue[T]) onDoneLocked(bd *blockingDone, err error) {
 	mq.size -= bd.elSize
 	mq.hasMoreSpace.Signal()
 	if mq.waitForResult {
@@ -191,10 +294,11 @@ func (mq *memoryQueue[T]) Capacity() int64 {
 }
 
 type node[T any] struct {
-	ctx  context.Context
-	data T
-	done Done
-	next *node[T]
+	ctx        context.Context
+	data       T
+	done       Done
+	enqueuedAt time.Time
+	next       *node[T]
 }
 
 type linkedQueue[T any] struct {
@@ -202,8 +306,8 @@ type linkedQueue[T any] struct {
 	tail *node[T]
 }
 
-func (l *linkedQueue[T]) push(ctx context.Context, data T, done Done) {
-	n := &node[T]{ctx: ctx, data: data, done: done}
+func (l *linkedQueue[T]) push(ctx context.Context, data T, done Done, enqueuedAt time.Time) {
+	n := &node[T]{ctx: ctx, data: data, done: done, enqueuedAt: enqueuedAt}
 	// If tail is nil means list is empty so update both head and tail to point to same element.
 	if l.tail == nil {
 		l.head = n
@@ -241,3 +241,121 @@ func consume[T any](q readableQueue[T], consumeFunc func(context.Context, T) err
 	done.OnDone(consumeFunc(ctx, req))
 	return true
 }
+
+func TestMemoryQueueDropOldest(t *testing.T) {
+	set := newSettings(request.SizerTypeItems, 5)
+	set.OverflowPolicy = OverflowPolicyDropOldest
+	q := newMemoryQueue[intRequest](set)
+	var dropped []intRequest
+	q.setDroppedFunc(func(_ context.Context, req intRequest, reason dropReason) {
+		assert.Equal(t, dropReasonOverflow, reason)
+		dropped = append(dropped, req)
+	})
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, q.Offer(context.Background(), 2))
+	require.NoError(t, q.Offer(context.Background(), 2))
+	require.NoError(t, q.Offer(context.Background(), 3))
+	assert.Equal(t, []intRequest{2}, dropped)
+	assert.EqualValues(t, 5, q.Size())
+
+	// The dispatched requests are not dropped.
+	_, req, done, ok := q.Read(context.Background())
+	require.True(t, ok)
+	assert.EqualValues(t, 2, req)
+	require.ErrorIs(t, q.Offer(context.Background(), 4), ErrQueueIsFull)
+	assert.Equal(t, []intRequest{2, 3}, dropped)
+	done.OnDone(nil)
+	require.NoError(t, q.Offer(context.Background(), 4))
+	assert.EqualValues(t, 4, q.Size())
+
+	// The requests that can never fit do not drop the queued requests.
+	require.ErrorIs(t, q.Offer(context.Background(), 6), errSizeTooLarge)
+	_, err := q.(*memoryQueue[intRequest]).add(context.Background(), 6, 6, 0)
+	require.ErrorIs(t, err, ErrQueueIsFull)
+	assert.Equal(t, []intRequest{2, 3}, dropped)
+	assert.EqualValues(t, 4, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueueMaxAge(t *testing.T) {
+	set := newSettings(request.SizerTypeItems, 5)
+	set.MaxAge = time.Millisecond
+	set.WaitForResult = true
+	q := newMemoryQueue[intRequest](set)
+	var dropped []intRequest
+	q.setDroppedFunc(func(_ context.Context, req intRequest, reason dropReason) {
+		assert.Equal(t, dropReasonExpired, reason)
+		dropped = append(dropped, req)
+	})
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The request expires while waiting in the queue, the caller receives the error.
+	errCh := make(chan error, 1)
+	go func() { errCh <- q.Offer(context.Background(), 3) }()
+	assert.Eventually(t, func() bool { return q.Size() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	// Expired requests are dropped to make space for new requests.
+	go func() {
+		assert.NoError(t, q.Offer(context.Background(), 4))
+	}()
+	require.ErrorIs(t, <-errCh, errRequestExpired)
+	assert.Equal(t, []intRequest{3}, dropped)
+
+	assert.True(t, consume(q, func(_ context.Context, el intRequest) error {
+		assert.EqualValues(t, 4, el)
+		return nil
+	}))
+	assert.EqualValues(t, 0, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueuePriority(t *testing.T) {
+	set := newSettings(request.SizerTypeRequests, 100)
+	set.Priority = &PrioritySettings[intRequest]{
+		NumLanes: 3,
+		// The lane is encoded in the request value: lane*10 + sequence.
+		GetLane:         func(_ context.Context, req intRequest) int { return int(req) / 10 },
+		StarvationLimit: 2,
+	}
+	q := newMemoryQueue[intRequest](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, req := range []intRequest{20, 21, 10, 11, 12, 1, 2, 3, 4} {
+		require.NoError(t, q.Offer(context.Background(), req))
+	}
+
+	var got []intRequest
+	for range 9 {
+		assert.True(t, consume(q, func(_ context.Context, req intRequest) error {
+			got = append(got, req)
+			return nil
+		}))
+	}
+	// Higher lanes are read first, lower lanes are read after being skipped twice.
+	assert.Equal(t, []intRequest{1, 2, 10, 20, 3, 4, 21, 11, 12}, got)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueuePriorityDropOldest(t *testing.T) {
+	set := newSettings(request.SizerTypeRequests, 3)
+	set.OverflowPolicy = OverflowPolicyDropOldest
+	set.Priority = &PrioritySettings[intRequest]{
+		NumLanes:        2,
+		GetLane:         func(_ context.Context, req intRequest) int { return int(req) / 10 },
+		StarvationLimit: 10,
+	}
+	q := newMemoryQueue[intRequest](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	for _, req := range []intRequest{1, 10, 11, 2} {
+		require.NoError(t, q.Offer(context.Background(), req))
+	}
+	// The oldest request of the lowest priority lane was dropped.
+	var got []intRequest
+	for range 3 {
+		assert.True(t, consume(q, func(_ context.Context, req intRequest) error {
+			got = append(got, req)
+			return nil
+		}))
+	}
+	assert.Equal(t, []intRequest{1, 2, 11}, got)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
@@ -22,6 +22,9 @@ const (
 
 	// DataTypeKey used to identify the data type in the queue size metric.
 	dataTypeKey = "data_type"
+
+	// reasonKey used to identify why the data was dropped from the queue.
+	reasonKey = "reason"
 )
 
 // obsQueue is a helper to add observability to a queue.
@@ -32,10 +35,11 @@ type obsQueue[T request.Request] struct {
 	enqueueFailedInst    metric.Int64Counter
 	enqueueSizeInst      metric.Int64Histogram
 	enqueueSizeBytesInst metric.Int64Histogram
+	droppedAttrs         map[dropReason]metric.MeasurementOption
 	tracer               trace.Tracer
 }
 
-func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T], error) {
+func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (*obsQueue[T], error) {
 	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
 	if err != nil {
 		return nil, err
@@ -65,7 +69,11 @@ func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T
 		Queue:      delegate,
 		tb:         tb,
 		metricAttr: metric.WithAttributeSet(attribute.NewSet(exporterAttr)),
-		tracer:     tracer,
+		droppedAttrs: map[dropReason]metric.MeasurementOption{
+			dropReasonExpired:  metric.WithAttributeSet(attribute.NewSet(exporterAttr, attribute.String(dataTypeKey, set.Signal.String()), attribute.String(reasonKey, string(dropReasonExpired)))),
+			dropReasonOverflow: metric.WithAttributeSet(attribute.NewSet(exporterAttr, attribute.String(dataTypeKey, set.Signal.String()), attribute.String(reasonKey, string(dropReasonOverflow)))),
+		},
+		tracer: tracer,
 	}
 
 	switch set.Signal {
@@ -110,3 +118,8 @@ func (or *obsQueue[T]) Offer(ctx context.Context, req T) error {
 	}
 	return err
 }
+
+// recordDropped records the items of a request dropped by the queue before being sent.
+func (or *obsQueue[T]) recordDropped(ctx context.Context, req T, reason dropReason) {
+	or.tb.ExporterQueueDropped.Add(ctx, int64(req.ItemsCount()), or.droppedAttrs[reason])
+}
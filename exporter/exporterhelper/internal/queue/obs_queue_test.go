@@ -375,3 +375,45 @@ func TestObsQueueProfilesBatchSize(t *testing.T) {
 			},
 		}, metricdatatest.IgnoreTimestamp())
 }
+
+func TestObsQueueDropped(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := newSettings(request.SizerTypeItems, 5)
+	set.Signal = pipeline.SignalTraces
+	set.ID = exporterID
+	set.Telemetry = tt.NewTelemetrySettings()
+	set.OverflowPolicy = OverflowPolicyDropOldest
+	set.NumConsumers = 1
+	started := make(chan struct{}, 1)
+	block := make(chan struct{})
+	q, err := NewQueue[intRequest](set, func(_ context.Context, _ intRequest, done Done) {
+		started <- struct{}{}
+		<-block
+		done.OnDone(nil)
+	})
+	require.NoError(t, err)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+	// The first request is dispatched by the single consumer, the next ones are waiting in the queue.
+	require.NoError(t, q.Offer(context.Background(), 1))
+	<-started
+	require.NoError(t, q.Offer(context.Background(), 2))
+	require.NoError(t, q.Offer(context.Background(), 2))
+	require.NoError(t, q.Offer(context.Background(), 3))
+	close(block)
+	require.NoError(t, q.Shutdown(context.Background()))
+	<-started
+
+	metadatatest.AssertEqualExporterQueueDropped(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, exporterID.String()),
+					attribute.String(dataTypeKey, pipeline.SignalTraces.String()),
+					attribute.String(reasonKey, string(dropReasonOverflow)),
+				),
+				Value: int64(4),
+			},
+		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
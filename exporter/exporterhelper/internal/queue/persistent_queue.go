@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -79,8 +80,13 @@ type persistentQueue[T request.Request] struct {
 	itemsSizer  request.Sizer
 	bytesSizer  request.Sizer
 	storageID   component.ID
+	storageName string
+	verify      bool
 	id          component.ID
 	signal      pipeline.Signal
+	maxAge      time.Duration
+	dropOldest  bool
+	onDropped   droppedFunc[T]
 
 	// mu guards everything declared below.
 	mu              sync.Mutex
@@ -104,8 +110,12 @@ func newPersistentQueue[T request.Request](set Settings[T]) readableQueue[T] {
 		itemsSizer:      request.NewItemsSizer(),
 		bytesSizer:      request.NewBytesSizer(),
 		storageID:       *set.StorageID,
+		storageName:     set.StorageName,
+		verify:          set.VerifyStorage,
 		id:              set.ID,
 		signal:          set.Signal,
+		maxAge:          set.MaxAge,
+		dropOldest:      set.OverflowPolicy == OverflowPolicyDropOldest,
 		blockOnOverflow: set.BlockOnOverflow,
 	}
 	pq.hasMoreElements = sync.NewCond(&pq.mu)
@@ -115,7 +125,11 @@ func newPersistentQueue[T request.Request](set Settings[T]) readableQueue[T] {
 
 // Start starts the persistentQueue with the given number of consumers.
 func (pq *persistentQueue[T]) Start(ctx context.Context, host component.Host) error {
-	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, pq.signal)
+	storageName := pq.storageName
+	if storageName == "" {
+		storageName = pq.signal.String()
+	}
+	storageClient, err := toStorageClient(ctx, pq.storageID, host, pq.id, storageName)
 	if err != nil {
 		return err
 	}
@@ -166,6 +180,10 @@ func (pq *persistentQueue[T]) initClient(ctx context.Context, client storage.Cli
 		pq.logger.Info("New queue metadata key not found, attempting to load legacy format.")
 		pq.loadLegacyMetadata(ctx)
 	}
+
+	if pq.verify {
+		pq.verifyStorage(ctx)
+	}
 }
 
 // loadQueueMetadata loads queue metadata from the consolidated key
@@ -275,6 +293,10 @@ func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
 
 	size := pq.activeSizer.Sizeof(req)
 	for pq.internalSize()+size > pq.capacity {
+		// Make space by dropping the expired requests, or the oldest requests if configured, before rejecting.
+		if size <= pq.capacity && (pq.dropHead(ctx, dropReasonExpired) || (pq.dropOldest && pq.dropHead(ctx, dropReasonOverflow))) {
+			continue
+		}
 		if !pq.blockOnOverflow {
 			return ErrQueueIsFull
 		}
@@ -286,11 +308,11 @@ func (pq *persistentQueue[T]) Offer(ctx context.Context, req T) error {
 	pq.metadata.ItemsSize += pq.itemsSizer.Sizeof(req)
 	pq.metadata.BytesSize += pq.bytesSizer.Sizeof(req)
 
-	return pq.putInternal(ctx, req)
+	return pq.putInternal(ctx, req, time.Now())
 }
 
 // putInternal adds the request to the storage without updating items/bytes sizes.
-func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
+func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T, enqueuedAt time.Time) error {
 	pq.metadata.WriteIndex++
 
 	metadataBuf, err := proto.Marshal(&pq.metadata)
@@ -307,6 +329,9 @@ func (pq *persistentQueue[T]) putInternal(ctx context.Context, req T) error {
 		storage.SetOperation(metadataKey, metadataBuf),
 		storage.SetOperation(getItemKey(pq.metadata.WriteIndex-1), reqBuf),
 	}
+	if pq.maxAge > 0 {
+		ops = append(ops, storage.SetOperation(getItemTimeKey(pq.metadata.WriteIndex-1), timeToBytes(enqueuedAt)))
+	}
 	if err := pq.client.Batch(ctx, ops...); err != nil {
 		// At this moment, metadata may be updated in the storage, so we cannot just revert changes to the
 		// metadata, rely on the sizes being fixed on complete draining.
@@ -330,6 +355,11 @@ func (pq *persistentQueue[T]) Read(ctx context.Context) (context.Context, T, Don
 
 		// Read until either a successful retrieved element or no more elements in the storage.
 		for pq.metadata.ReadIndex != pq.metadata.WriteIndex {
+			if pq.dropHead(ctx, dropReasonExpired) {
+				// More space available, data was dropped.
+				pq.hasMoreSpace.Signal()
+				continue
+			}
 			index, req, reqCtx, consumed := pq.getNextItem(ctx)
 			// Ensure the used size are in sync when queue is drained.
 			if pq.requestSize() == 0 {
@@ -455,12 +485,17 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 		len(dispatchedItems)))
 	retrieveBatch := make([]*storage.Operation, len(dispatchedItems))
 	cleanupBatch := make([]*storage.Operation, len(dispatchedItems))
+	var retrieveTimeBatch []*storage.Operation
 	for i, it := range dispatchedItems {
 		key := getItemKey(it)
 		retrieveBatch[i] = storage.GetOperation(key)
 		cleanupBatch[i] = storage.DeleteOperation(key)
+		if pq.maxAge > 0 {
+			retrieveTimeBatch = append(retrieveTimeBatch, storage.GetOperation(getItemTimeKey(it)))
+			cleanupBatch = append(cleanupBatch, storage.DeleteOperation(getItemTimeKey(it)))
+		}
 	}
-	retrieveErr := pq.client.Batch(ctx, retrieveBatch...)
+	retrieveErr := pq.client.Batch(ctx, append(retrieveBatch, retrieveTimeBatch...)...)
 	cleanupErr := pq.client.Batch(ctx, cleanupBatch...)
 
 	if cleanupErr != nil {
@@ -473,7 +508,7 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 	}
 
 	errCount := 0
-	for _, op := range retrieveBatch {
+	for i, op := range retrieveBatch {
 		if op.Value == nil {
 			pq.logger.Warn("Failed retrieving item", zap.String(zapKey, op.Key), zap.Error(errValueNotSet))
 			continue
@@ -484,7 +519,14 @@ func (pq *persistentQueue[T]) enqueueNotDispatchedReqs(ctx context.Context, disp
 			pq.logger.Warn("Failed unmarshalling item", zap.String(zapKey, op.Key), zap.Error(err))
 			continue
 		}
-		if pq.putInternal(reqCtx, req) != nil { //nolint:contextcheck
+		// Keep the original enqueue time, so the max age is not extended by restarts.
+		enqueuedAt := time.Now()
+		if pq.maxAge > 0 {
+			if t, ok := bytesToTime(retrieveTimeBatch[i].Value); ok {
+				enqueuedAt = t
+			}
+		}
+		if pq.putInternal(reqCtx, req, enqueuedAt) != nil { //nolint:contextcheck
 			errCount++
 		}
 	}
@@ -522,7 +564,11 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 
 	setOp := storage.SetOperation(metadataKey, metadataBytes)
 	deleteOp := storage.DeleteOperation(getItemKey(index))
-	err = pq.client.Batch(ctx, setOp, deleteOp)
+	ops := []*storage.Operation{setOp, deleteOp}
+	if pq.maxAge > 0 {
+		ops = append(ops, storage.DeleteOperation(getItemTimeKey(index)))
+	}
+	err = pq.client.Batch(ctx, ops...)
 	if err == nil {
 		// Everything ok, exit
 		return nil
@@ -546,7 +592,65 @@ func (pq *persistentQueue[T]) itemDispatchingFinish(ctx context.Context, index u
 	return nil
 }
 
-func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, signal pipeline.Signal) (storage.Client, error) {
+// dropHead drops the item at the read index and returns true if the item can be dropped for the given reason.
+// Items stored without an enqueue time never expire. Callers MUST hold the mutex.
+func (pq *persistentQueue[T]) dropHead(ctx context.Context, reason dropReason) bool {
+	if pq.metadata.ReadIndex == pq.metadata.WriteIndex {
+		return false
+	}
+	index := pq.metadata.ReadIndex
+	if reason == dropReasonExpired {
+		if pq.maxAge <= 0 {
+			return false
+		}
+		buf, err := pq.client.Get(ctx, getItemTimeKey(index))
+		if err != nil {
+			return false
+		}
+		enqueuedAt, ok := bytesToTime(buf)
+		if !ok || time.Since(enqueuedAt) <= pq.maxAge {
+			return false
+		}
+	}
+
+	getOp := storage.GetOperation(getItemKey(index))
+	if err := pq.client.Batch(ctx, getOp); err != nil {
+		return false
+	}
+	pq.metadata.ReadIndex++
+	reqCtx, req, err := pq.encoding.Unmarshal(getOp.Value)
+	if err == nil {
+		pq.metadata.ItemsSize = max(pq.metadata.ItemsSize-pq.itemsSizer.Sizeof(req), 0)
+		pq.metadata.BytesSize = max(pq.metadata.BytesSize-pq.bytesSizer.Sizeof(req), 0)
+	}
+	// Ensure the used size are in sync when queue is drained.
+	if pq.requestSize() == 0 {
+		pq.metadata.BytesSize = 0
+		pq.metadata.ItemsSize = 0
+	}
+
+	ops := []*storage.Operation{storage.DeleteOperation(getItemKey(index)), storage.DeleteOperation(getItemTimeKey(index))}
+	if metadataBytes, mErr := proto.Marshal(&pq.metadata); mErr == nil {
+		ops = append(ops, storage.SetOperation(metadataKey, metadataBytes))
+	}
+	if bErr := pq.client.Batch(ctx, ops...); bErr != nil {
+		pq.logger.Error("Error deleting dropped item from queue", zap.Error(bErr))
+	}
+	if err != nil {
+		pq.logger.Debug("Failed to unmarshal dropped item", zap.Error(err))
+		return true
+	}
+	if pq.onDropped != nil {
+		pq.onDropped(reqCtx, req, reason)
+	}
+	return true
+}
+
+func (pq *persistentQueue[T]) setDroppedFunc(onDropped droppedFunc[T]) {
+	pq.onDropped = onDropped
+}
+
+func toStorageClient(ctx context.Context, storageID component.ID, host component.Host, ownerID component.ID, storageName string) (storage.Client, error) {
 	ext, found := host.GetExtensions()[storageID]
 	if !found {
 		return nil, errNoStorageClient
@@ -557,13 +661,30 @@ func toStorageClient(ctx context.Context, storageID component.ID, host component
 		return nil, errWrongExtensionType
 	}
 
-	return storageExt.GetClient(ctx, component.KindExporter, ownerID, signal.String())
+	return storageExt.GetClient(ctx, component.KindExporter, ownerID, storageName)
 }
 
 func getItemKey(index uint64) string {
 	return strconv.FormatUint(index, 10)
 }
 
+// getItemTimeKey returns the key of the time when the item was added to the queue, only set if max age is configured.
+func getItemTimeKey(index uint64) string {
+	return "t" + strconv.FormatUint(index, 10)
+}
+
+func timeToBytes(t time.Time) []byte {
+	return binary.LittleEndian.AppendUint64(nil, uint64(t.UnixNano()))
+}
+
+func bytesToTime(buf []byte) (time.Time, bool) {
+	// The sizeof uint64 in binary is 8.
+	if len(buf) < 8 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(binary.LittleEndian.Uint64(buf))), true
+}
+
 func bytesToItemIndex(buf []byte) (uint64, error) {
 	if buf == nil {
 		return uint64(0), errValueNotSet
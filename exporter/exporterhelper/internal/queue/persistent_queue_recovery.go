@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+const (
+	// quarantineKeyPrefix is the prefix of the keys where the items that cannot be unmarshaled are moved,
+	// so they can be inspected without blocking the queue.
+	quarantineKeyPrefix = "quarantine/"
+	// maxQuarantinedItems is the maximum number of quarantined items kept in the storage,
+	// the verification deletes the oldest ones.
+	maxQuarantinedItems = 100
+)
+
+// recoveryReport describes the repairs made by the storage verification.
+type recoveryReport struct {
+	quarantined       int
+	expiredQuarantine int
+	orphanItems       int
+	orphanTimes       int
+	movedItems        int
+	itemsSize         int64
+	bytesSize         int64
+	indicesFixed      bool
+	sizesFixed        bool
+	unknownKeys       int
+	verifiedItems     int
+}
+
+func (r recoveryReport) changed() bool {
+	return r.quarantined > 0 || r.expiredQuarantine > 0 || r.orphanItems > 0 || r.orphanTimes > 0 || r.movedItems > 0 || r.indicesFixed || r.sizesFixed
+}
+
+// verifyStorage walks through all the storage entries of the queue and repairs the inconsistencies left by
+// crashes or storage failures, e.g. during disk-full events:
+//   - the items that cannot be unmarshaled are moved under the quarantine prefix, only the most recent
+//     maxQuarantinedItems quarantined items are kept;
+//   - the items before the read index, which were already dispatched, and the enqueue times without an item are deleted;
+//   - the items after the write index, which were written without their metadata, are recovered;
+//   - the remaining items are compacted to contiguous indices, and the read and write indices are fixed;
+//   - the items and bytes sizes are recomputed from the items.
+//
+// It must be called after the items left for dispatch by consumers were moved back to the queue.
+// It is a no-op if the storage client does not implement storage.Walker. Every stored item is unmarshaled,
+// so it only runs if enabled by the queue settings.
+func (pq *persistentQueue[T]) verifyStorage(ctx context.Context) {
+	walker, ok := pq.client.(storage.Walker)
+	if !ok {
+		pq.logger.Debug("Storage client does not support walking, skipping the queue verification")
+		return
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	report := recoveryReport{}
+	now := time.Now()
+	var items []uint64
+	var quarantineKeys []string
+	timeKeys := map[uint64]struct{}{}
+	err := walker.Walk(ctx, func(key string, value []byte) ([]*storage.Operation, error) {
+		if key == metadataKey || key == legacyReadIndexKey || key == legacyWriteIndexKey ||
+			key == legacyCurrentlyDispatchedItemsKey {
+			return nil, nil
+		}
+		if strings.HasPrefix(key, quarantineKeyPrefix) {
+			quarantineKeys = append(quarantineKeys, key)
+			return nil, nil
+		}
+		if index, isTime := parseItemTimeKey(key); isTime {
+			timeKeys[index] = struct{}{}
+			return nil, nil
+		}
+		index, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			report.unknownKeys++
+			return nil, nil
+		}
+		if index < pq.metadata.ReadIndex {
+			// The item was dispatched, but the deletion failed.
+			report.orphanItems++
+			return []*storage.Operation{storage.DeleteOperation(key)}, nil
+		}
+		_, req, err := pq.encoding.Unmarshal(value)
+		if err != nil {
+			pq.logger.Warn("Moving item that cannot be unmarshaled to quarantine", zap.String(zapKey, key), zap.Error(err))
+			report.quarantined++
+			quarantineKey := getQuarantineKey(now, key)
+			quarantineKeys = append(quarantineKeys, quarantineKey)
+			return []*storage.Operation{
+				storage.SetOperation(quarantineKey, slices.Clone(value)),
+				storage.DeleteOperation(key),
+			}, nil
+		}
+		items = append(items, index)
+		report.itemsSize += pq.itemsSizer.Sizeof(req)
+		report.bytesSize += pq.bytesSizer.Sizeof(req)
+		return nil, nil
+	})
+	if err != nil {
+		pq.logger.Error("Failed verifying the queue storage", zap.Error(err))
+		return
+	}
+	report.verifiedItems = len(items)
+
+	// Delete the enqueue times of the items that do not exist anymore.
+	slices.Sort(items)
+	var cleanupOps []*storage.Operation
+	for index := range timeKeys {
+		if _, found := slices.BinarySearch(items, index); !found {
+			cleanupOps = append(cleanupOps, storage.DeleteOperation(getItemTimeKey(index)))
+		}
+	}
+	if len(cleanupOps) > 0 {
+		if err = pq.client.Batch(ctx, cleanupOps...); err != nil {
+			pq.logger.Warn("Failed deleting orphaned enqueue times", zap.Error(err))
+		} else {
+			report.orphanTimes = len(cleanupOps)
+		}
+	}
+
+	// Delete the oldest quarantined items over the limit.
+	if len(quarantineKeys) > maxQuarantinedItems {
+		slices.SortFunc(quarantineKeys, func(a, b string) int {
+			return cmp.Or(cmp.Compare(parseQuarantineTime(a), parseQuarantineTime(b)), strings.Compare(a, b))
+		})
+		cleanupOps = cleanupOps[:0]
+		for _, key := range quarantineKeys[:len(quarantineKeys)-maxQuarantinedItems] {
+			cleanupOps = append(cleanupOps, storage.DeleteOperation(key))
+		}
+		if err = pq.client.Batch(ctx, cleanupOps...); err != nil {
+			pq.logger.Warn("Failed deleting expired quarantined items", zap.Error(err))
+		} else {
+			report.expiredQuarantine = len(cleanupOps)
+		}
+	}
+
+	readIndex := pq.metadata.ReadIndex
+	if len(items) > 0 {
+		readIndex = items[0]
+	} else if pq.metadata.WriteIndex > readIndex {
+		readIndex = pq.metadata.WriteIndex
+	}
+	// Compact the items, so there is no gap between the read and the write indices. The items are moved in order
+	// to a lower or equal index, so the destination is always free.
+	for i, index := range items {
+		dest := readIndex + uint64(i)
+		if dest == index {
+			continue
+		}
+		if err = pq.moveItem(ctx, index, dest, timeKeys); err != nil {
+			pq.logger.Error("Failed compacting the queue storage", zap.Error(err))
+			return
+		}
+		report.movedItems++
+	}
+	writeIndex := readIndex + uint64(len(items))
+
+	report.indicesFixed = readIndex != pq.metadata.ReadIndex || writeIndex != pq.metadata.WriteIndex
+	report.sizesFixed = report.itemsSize != pq.metadata.ItemsSize || report.bytesSize != pq.metadata.BytesSize
+	if !report.changed() {
+		pq.logger.Debug("Verified queue storage", zap.Int(zapNumberOfItems, report.verifiedItems))
+		return
+	}
+
+	pq.metadata.ReadIndex = readIndex
+	pq.metadata.WriteIndex = writeIndex
+	pq.metadata.ItemsSize = report.itemsSize
+	pq.metadata.BytesSize = report.bytesSize
+	metadataBytes, err := proto.Marshal(&pq.metadata)
+	if err == nil {
+		err = pq.client.Set(ctx, metadataKey, metadataBytes)
+	}
+	if err != nil {
+		pq.logger.Error("Failed to persist the repaired metadata to storage", zap.Error(err))
+	}
+
+	pq.logger.Warn("Repaired inconsistent queue storage",
+		zap.Int(zapNumberOfItems, report.verifiedItems),
+		zap.Int("quarantinedItems", report.quarantined),
+		zap.Int("expiredQuarantinedItems", report.expiredQuarantine),
+		zap.Int("orphanedItems", report.orphanItems),
+		zap.Int("orphanedEnqueueTimes", report.orphanTimes),
+		zap.Int("compactedItems", report.movedItems),
+		zap.Int("unknownKeys", report.unknownKeys),
+		zap.Uint64("readIndex", readIndex),
+		zap.Uint64("writeIndex", writeIndex),
+		zap.Int64("itemsSize", report.itemsSize),
+		zap.Int64("bytesSize", report.bytesSize))
+}
+
+// moveItem moves the item, and its enqueue time if any, from the index to the dest index.
+func (pq *persistentQueue[T]) moveItem(ctx context.Context, index, dest uint64, timeKeys map[uint64]struct{}) error {
+	getOps := []*storage.Operation{storage.GetOperation(getItemKey(index))}
+	_, hasTime := timeKeys[index]
+	if hasTime {
+		getOps = append(getOps, storage.GetOperation(getItemTimeKey(index)))
+	}
+	if err := pq.client.Batch(ctx, getOps...); err != nil {
+		return err
+	}
+	ops := []*storage.Operation{
+		storage.SetOperation(getItemKey(dest), getOps[0].Value),
+		storage.DeleteOperation(getItemKey(index)),
+	}
+	if hasTime {
+		ops = append(ops,
+			storage.SetOperation(getItemTimeKey(dest), getOps[1].Value),
+			storage.DeleteOperation(getItemTimeKey(index)))
+		delete(timeKeys, index)
+		timeKeys[dest] = struct{}{}
+	}
+	return pq.client.Batch(ctx, ops...)
+}
+
+// parseItemTimeKey returns the index of the item if the key is an enqueue time key, see getItemTimeKey.
+func parseItemTimeKey(key string) (uint64, bool) {
+	suffix, found := strings.CutPrefix(key, "t")
+	if !found {
+		return 0, false
+	}
+	index, err := strconv.ParseUint(suffix, 10, 64)
+	return index, err == nil
+}
+
+// getQuarantineKey returns the key where the item stored under the key is quarantined at the given time.
+// The time is part of the key, so the items quarantined under the same index by different runs are kept apart.
+func getQuarantineKey(at time.Time, key string) string {
+	return quarantineKeyPrefix + strconv.FormatInt(at.UnixNano(), 10) + "/" + key
+}
+
+// parseQuarantineTime returns the time in nanoseconds when the item was quarantined, see getQuarantineKey.
+// It returns 0 if the key has no quarantine time, so such items are considered the oldest.
+func parseQuarantineTime(key string) int64 {
+	suffix, _ := strings.CutPrefix(key, quarantineKeyPrefix)
+	at, _, _ := strings.Cut(suffix, "/")
+	nanos, err := strconv.ParseInt(at, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return nanos
+}
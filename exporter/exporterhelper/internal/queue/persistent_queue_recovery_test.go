@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/hosttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newRecoveryTestClient(t *testing.T, ext storage.Extension, metadata *PersistentMetadata, entries map[string][]byte) storage.Client {
+	set := newSettingsWithStorage(request.SizerTypeItems, 1000)
+	client, err := ext.GetClient(context.Background(), component.KindExporter, set.ID, set.Signal.String())
+	require.NoError(t, err)
+	metadataBytes, err := proto.Marshal(metadata)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), metadataKey, metadataBytes))
+	for key, value := range entries {
+		require.NoError(t, client.Set(context.Background(), key, value))
+	}
+	return client
+}
+
+func createVerifiedTestPersistentQueue(t *testing.T, ext storage.Extension, sizerType request.SizerType) *persistentQueue[intRequest] {
+	set := newSettingsWithStorage(sizerType, 1000)
+	set.VerifyStorage = true
+	pq := newPersistentQueue[intRequest](set)
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: ext})))
+	return pq.(*persistentQueue[intRequest])
+}
+
+// quarantinedItems returns the quarantined items by their original key.
+func quarantinedItems(t *testing.T, client storage.Client) map[string][]byte {
+	items := map[string][]byte{}
+	require.NoError(t, client.(storage.Walker).Walk(context.Background(), func(key string, value []byte) ([]*storage.Operation, error) {
+		if strings.HasPrefix(key, quarantineKeyPrefix) {
+			items[key[strings.LastIndex(key, "/")+1:]] = value
+		}
+		return nil, nil
+	}))
+	return items
+}
+
+func requireStorageValue(t *testing.T, client storage.Client, key string, expected []byte) {
+	val, err := client.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, expected, val, "key %q", key)
+}
+
+func TestPersistentQueue_VerifyStorageQuarantine(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	client := newRecoveryTestClient(t, ext, &PersistentMetadata{ReadIndex: 0, WriteIndex: 3, ItemsSize: 60, BytesSize: 600},
+		map[string][]byte{
+			"0": []byte("10"),
+			"1": {0, 1, 2},
+			"2": []byte("30"),
+		})
+
+	pq := createVerifiedTestPersistentQueue(t, ext, request.SizerTypeItems)
+	// The undecodable item is moved to quarantine, and the remaining items are compacted.
+	assert.EqualValues(t, 40, pq.Size())
+	assert.EqualValues(t, 400, pq.metadata.BytesSize)
+	assert.EqualValues(t, 0, pq.metadata.ReadIndex)
+	assert.EqualValues(t, 2, pq.metadata.WriteIndex)
+	assert.Equal(t, map[string][]byte{"1": {0, 1, 2}}, quarantinedItems(t, client))
+	requireStorageValue(t, client, "1", []byte("30"))
+	requireStorageValue(t, client, "2", nil)
+
+	var got []intRequest
+	for range 2 {
+		assert.True(t, consume(pq, func(_ context.Context, req intRequest) error {
+			got = append(got, req)
+			return nil
+		}))
+	}
+	assert.Equal(t, []intRequest{10, 30}, got)
+	assert.EqualValues(t, 0, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The quarantined items are not verified again.
+	pq = createVerifiedTestPersistentQueue(t, ext, request.SizerTypeItems)
+	assert.EqualValues(t, 0, pq.Size())
+	assert.Equal(t, map[string][]byte{"1": {0, 1, 2}}, quarantinedItems(t, client))
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_VerifyStorageQuarantineRetention(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	entries := map[string][]byte{
+		// Quarantined by an older version, without the quarantine time.
+		quarantineKeyPrefix + "0": {0},
+		"0":                       {0, 1, 2},
+	}
+	quarantinedAt := time.Now().Add(-time.Hour)
+	for i := range maxQuarantinedItems {
+		entries[getQuarantineKey(quarantinedAt.Add(time.Duration(i)), strconv.Itoa(i+1))] = []byte{byte(i + 1)}
+	}
+	client := newRecoveryTestClient(t, ext, &PersistentMetadata{ReadIndex: 0, WriteIndex: 1, ItemsSize: 1, BytesSize: 10}, entries)
+
+	pq := createVerifiedTestPersistentQueue(t, ext, request.SizerTypeItems)
+	assert.EqualValues(t, 0, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The newly quarantined item is kept, the two oldest ones are deleted.
+	items := quarantinedItems(t, client)
+	assert.Len(t, items, maxQuarantinedItems)
+	assert.Equal(t, []byte{0, 1, 2}, items["0"])
+	assert.NotContains(t, items, "1")
+	assert.Equal(t, []byte{2}, items["2"])
+}
+
+func TestPersistentQueue_VerifyStorageDisabled(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	client := newRecoveryTestClient(t, ext, &PersistentMetadata{ReadIndex: 0, WriteIndex: 2, ItemsSize: 20, BytesSize: 200},
+		map[string][]byte{
+			"0": {0, 1, 2},
+			"1": []byte("20"),
+		})
+
+	pq := createTestPersistentQueueWithItemsSizer(t, ext, 1000)
+	// Nothing is verified, the metadata is trusted.
+	assert.EqualValues(t, 20, pq.Size())
+	assert.EqualValues(t, 2, pq.metadata.WriteIndex)
+	requireStorageValue(t, client, "0", []byte{0, 1, 2})
+	assert.Empty(t, quarantinedItems(t, client))
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_VerifyStorageOrphans(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	enqueuedAt := timeToBytes(time.Now())
+	client := newRecoveryTestClient(t, ext, &PersistentMetadata{ReadIndex: 5, WriteIndex: 7, ItemsSize: 1, BytesSize: 10},
+		map[string][]byte{
+			// Already dispatched item that was not deleted.
+			"3":  []byte("1"),
+			"t3": enqueuedAt,
+			// Enqueue time without an item.
+			"t4": enqueuedAt,
+			"5":  []byte("2"),
+			"t5": enqueuedAt,
+			// Missing item 6, and item 7 written without updating the metadata.
+			"7":  []byte("3"),
+			"t7": enqueuedAt,
+			// Keys not owned by the queue are kept.
+			"other": []byte("value"),
+		})
+
+	pq := createVerifiedTestPersistentQueue(t, ext, request.SizerTypeItems)
+	assert.EqualValues(t, 5, pq.Size())
+	assert.EqualValues(t, 50, pq.metadata.BytesSize)
+	assert.EqualValues(t, 5, pq.metadata.ReadIndex)
+	assert.EqualValues(t, 7, pq.metadata.WriteIndex)
+	requireStorageValue(t, client, "3", nil)
+	requireStorageValue(t, client, "t3", nil)
+	requireStorageValue(t, client, "t4", nil)
+	requireStorageValue(t, client, "6", []byte("3"))
+	requireStorageValue(t, client, "t6", enqueuedAt)
+	requireStorageValue(t, client, "7", nil)
+	requireStorageValue(t, client, "t7", nil)
+	requireStorageValue(t, client, "other", []byte("value"))
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// The repaired metadata is persisted.
+	pq = createTestPersistentQueueWithItemsSizer(t, ext, 1000)
+	assert.EqualValues(t, 5, pq.Size())
+	assert.EqualValues(t, 7, pq.metadata.WriteIndex)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_VerifyStorageMissingItems(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	newRecoveryTestClient(t, ext, &PersistentMetadata{ReadIndex: 2, WriteIndex: 4, ItemsSize: 10, BytesSize: 100}, nil)
+
+	pq := createVerifiedTestPersistentQueue(t, ext, request.SizerTypeRequests)
+	assert.EqualValues(t, 0, pq.Size())
+	assert.EqualValues(t, 0, pq.metadata.ItemsSize)
+	assert.EqualValues(t, 0, pq.metadata.BytesSize)
+	assert.EqualValues(t, 4, pq.metadata.ReadIndex)
+	assert.EqualValues(t, 4, pq.metadata.WriteIndex)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_VerifyStorageWithoutWalker(t *testing.T) {
+	client := newFakeBoundedStorageClient(1000)
+	require.NoError(t, client.Set(context.Background(), "5", []byte{0, 1, 2}))
+	set := newSettingsWithStorage(request.SizerTypeRequests, 1000)
+	set.VerifyStorage = true
+	pq := newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	pq.initClient(context.Background(), client)
+	// Nothing is verified, the item is not reachable.
+	assert.EqualValues(t, 0, pq.Size())
+	val, err := client.Get(context.Background(), "5")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2}, val)
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestParseItemTimeKey(t *testing.T) {
+	index, ok := parseItemTimeKey(getItemTimeKey(42))
+	assert.True(t, ok)
+	assert.EqualValues(t, 42, index)
+
+	_, ok = parseItemTimeKey("42")
+	assert.False(t, ok)
+	_, ok = parseItemTimeKey("tx")
+	assert.False(t, ok)
+}
@@ -497,7 +497,7 @@ func TestToStorageClient(t *testing.T) {
 			ownerID := component.MustNewID("foo_exporter")
 
 			// execute
-			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+			client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 			// verify
 			if tt.expectedError != nil {
@@ -527,7 +527,7 @@ func TestInvalidStorageExtensionType(t *testing.T) {
 	ownerID := component.MustNewID("foo_exporter")
 
 	// execute
-	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces)
+	client, err := toStorageClient(context.Background(), storageID, host, ownerID, pipeline.SignalTraces.String())
 
 	// we should get an error about the extension type
 	require.ErrorIs(t, err, errWrongExtensionType)
@@ -1185,3 +1185,94 @@ func itemIndexArrayToBytes(arr []uint64) []byte {
 	}
 	return buf
 }
+
+func TestPersistentQueue_DropOldest(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	set := newSettingsWithStorage(request.SizerTypeItems, 5)
+	set.OverflowPolicy = OverflowPolicyDropOldest
+	pq := newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	var dropped []intRequest
+	pq.setDroppedFunc(func(_ context.Context, req intRequest, reason dropReason) {
+		assert.Equal(t, dropReasonOverflow, reason)
+		dropped = append(dropped, req)
+	})
+	require.NoError(t, pq.Start(context.Background(), hosttest.NewHost(map[component.ID]component.Component{{}: ext})))
+
+	require.NoError(t, pq.Offer(context.Background(), 2))
+	require.NoError(t, pq.Offer(context.Background(), 2))
+	require.NoError(t, pq.Offer(context.Background(), 3))
+	assert.Equal(t, []intRequest{2}, dropped)
+	assert.EqualValues(t, 5, pq.Size())
+
+	// A request larger than the capacity does not drop anything.
+	require.ErrorIs(t, pq.Offer(context.Background(), 6), ErrQueueIsFull)
+	assert.Equal(t, []intRequest{2}, dropped)
+
+	assert.True(t, consume(pq, func(_ context.Context, req intRequest) error {
+		assert.EqualValues(t, 2, req)
+		return nil
+	}))
+	assert.True(t, consume(pq, func(_ context.Context, req intRequest) error {
+		assert.EqualValues(t, 3, req)
+		return nil
+	}))
+	assert.EqualValues(t, 0, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+}
+
+func TestPersistentQueue_MaxAge(t *testing.T) {
+	ext := storagetest.NewMockStorageExtension(nil)
+	host := hosttest.NewHost(map[component.ID]component.Component{{}: ext})
+	set := newSettingsWithStorage(request.SizerTypeItems, 10)
+	set.MaxAge = 20 * time.Millisecond
+	var dropped []intRequest
+	newQueue := func() *persistentQueue[intRequest] {
+		pq := newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+		pq.setDroppedFunc(func(_ context.Context, req intRequest, reason dropReason) {
+			assert.Equal(t, dropReasonExpired, reason)
+			dropped = append(dropped, req)
+		})
+		require.NoError(t, pq.Start(context.Background(), host))
+		return pq
+	}
+
+	pq := newQueue()
+	require.NoError(t, pq.Offer(context.Background(), 3))
+	require.NoError(t, pq.Offer(context.Background(), 4))
+	// Simulate a crash while the first request is dispatched, the enqueue time is kept after restart.
+	assert.True(t, consume(pq, func(context.Context, intRequest) error {
+		return experr.NewShutdownErr(nil)
+	}))
+	require.NoError(t, pq.Shutdown(context.Background()))
+	time.Sleep(30 * time.Millisecond)
+
+	pq = newQueue()
+	assert.EqualValues(t, 7, pq.Size())
+	// Expired requests are dropped to make space for new requests.
+	require.NoError(t, pq.Offer(context.Background(), 5))
+	assert.Equal(t, []intRequest{4}, dropped)
+	assert.EqualValues(t, 8, pq.Size())
+
+	// Expired requests are dropped when read.
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, pq.Offer(context.Background(), 1))
+	assert.True(t, consume(pq, func(_ context.Context, req intRequest) error {
+		assert.EqualValues(t, 1, req)
+		return nil
+	}))
+	assert.Equal(t, []intRequest{4, 3, 5}, dropped)
+	assert.EqualValues(t, 0, pq.Size())
+	require.NoError(t, pq.Shutdown(context.Background()))
+
+	// All the items and their enqueue time are removed.
+	client, err := ext.GetClient(context.Background(), component.KindExporter, set.ID, set.Signal.String())
+	require.NoError(t, err)
+	for i := range uint64(5) {
+		val, err := client.Get(context.Background(), getItemKey(i))
+		require.NoError(t, err)
+		assert.Nil(t, val)
+		val, err = client.Get(context.Background(), getItemTimeKey(i))
+		require.NoError(t, err)
+		assert.Nil(t, val)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// StoredMetadata describes the content of a persistent queue as recorded in the storage.
+type StoredMetadata struct {
+	// ItemsSize is the total items size of the queue.
+	ItemsSize int64
+	// BytesSize is the total bytes size of the queue.
+	BytesSize int64
+	// ReadIndex is the index of the next item to be read from the queue.
+	ReadIndex uint64
+	// WriteIndex is the index where the next item will be written to the queue.
+	WriteIndex uint64
+	// DispatchedItems are the indexes of the items that were being processed when the queue was stopped.
+	DispatchedItems []uint64
+}
+
+// StorageReader gives access to the content of a persistent queue while the queue is not running,
+// e.g. to inspect it or to replay its items offline.
+// It must not be used concurrently with a running persistent queue on the same storage client.
+type StorageReader struct {
+	client   storage.Client
+	metadata PersistentMetadata
+}
+
+// NewStorageReader loads the queue metadata from the given storage client.
+// The legacy metadata format is not supported.
+func NewStorageReader(ctx context.Context, client storage.Client) (*StorageReader, error) {
+	sr := &StorageReader{client: client}
+	buf, err := client.Get(ctx, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		// Empty or never used queue.
+		return sr, nil
+	}
+	if err = proto.Unmarshal(buf, &sr.metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queue metadata: %w", err)
+	}
+	return sr, nil
+}
+
+// Metadata returns the current metadata of the queue.
+func (sr *StorageReader) Metadata() StoredMetadata {
+	return StoredMetadata{
+		ItemsSize:       sr.metadata.ItemsSize,
+		BytesSize:       sr.metadata.BytesSize,
+		ReadIndex:       sr.metadata.ReadIndex,
+		WriteIndex:      sr.metadata.WriteIndex,
+		DispatchedItems: slices.Clone(sr.metadata.CurrentlyDispatchedItems),
+	}
+}
+
+// Indexes returns the indexes of the items in the order they would be dispatched by the queue:
+// first the items that were being dispatched, then the items between the read and the write index.
+func (sr *StorageReader) Indexes() []uint64 {
+	indexes := slices.Clone(sr.metadata.CurrentlyDispatchedItems)
+	for i := sr.metadata.ReadIndex; i < sr.metadata.WriteIndex; i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// Get returns the encoded item stored at the given index, or nil if it does not exist.
+func (sr *StorageReader) Get(ctx context.Context, index uint64) ([]byte, error) {
+	return sr.client.Get(ctx, getItemKey(index))
+}
+
+// Remove deletes the item stored at the given index and updates the metadata accordingly.
+// Only the dispatched items and the item at the read index can be removed, so the queue stays consistent.
+func (sr *StorageReader) Remove(ctx context.Context, index uint64, itemsSize, bytesSize int64) error {
+	if i := slices.Index(sr.metadata.CurrentlyDispatchedItems, index); i >= 0 {
+		sr.metadata.CurrentlyDispatchedItems = slices.Delete(sr.metadata.CurrentlyDispatchedItems, i, i+1)
+	} else {
+		if index != sr.metadata.ReadIndex || sr.metadata.ReadIndex == sr.metadata.WriteIndex {
+			return errors.New("only the next item to be read can be removed from the queue")
+		}
+		sr.metadata.ReadIndex++
+	}
+
+	sr.metadata.ItemsSize = max(sr.metadata.ItemsSize-itemsSize, 0)
+	sr.metadata.BytesSize = max(sr.metadata.BytesSize-bytesSize, 0)
+	// Ensure the used size are in sync when queue is drained.
+	if sr.metadata.WriteIndex == sr.metadata.ReadIndex && len(sr.metadata.CurrentlyDispatchedItems) == 0 {
+		sr.metadata.ItemsSize = 0
+		sr.metadata.BytesSize = 0
+	}
+
+	metadataBytes, err := proto.Marshal(&sr.metadata)
+	if err != nil {
+		return err
+	}
+	return sr.client.Batch(ctx, storage.SetOperation(metadataKey, metadataBytes),
+		storage.DeleteOperation(getItemKey(index)), storage.DeleteOperation(getItemTimeKey(index)))
+}
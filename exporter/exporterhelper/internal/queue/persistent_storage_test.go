@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+)
+
+func TestStorageReader(t *testing.T) {
+	client := newFakeBoundedStorageClient(1000)
+	pq := newPersistentQueue[intRequest](newSettingsWithStorage(request.SizerTypeItems, 1000)).(*persistentQueue[intRequest])
+	pq.initClient(context.Background(), client)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, pq.Offer(context.Background(), intRequest(i)))
+	}
+	// Simulate a crash while the first item is dispatched.
+	_, _, _, ok := pq.Read(context.Background())
+	require.True(t, ok)
+
+	sr, err := NewStorageReader(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, StoredMetadata{
+		ItemsSize:       6,
+		BytesSize:       60,
+		ReadIndex:       1,
+		WriteIndex:      3,
+		DispatchedItems: []uint64{0},
+	}, sr.Metadata())
+	assert.Equal(t, []uint64{0, 1, 2}, sr.Indexes())
+
+	buf, err := sr.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), buf)
+
+	require.EqualError(t, sr.Remove(context.Background(), 2, 3, 30), "only the next item to be read can be removed from the queue")
+	require.NoError(t, sr.Remove(context.Background(), 0, 1, 10))
+	require.NoError(t, sr.Remove(context.Background(), 1, 2, 20))
+
+	// Reload the metadata from the storage.
+	sr, err = NewStorageReader(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, StoredMetadata{
+		ItemsSize:  3,
+		BytesSize:  30,
+		ReadIndex:  2,
+		WriteIndex: 3,
+	}, sr.Metadata())
+	buf, err = sr.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+
+	require.NoError(t, sr.Remove(context.Background(), 2, 1, 1))
+	assert.Empty(t, sr.Indexes())
+	assert.Zero(t, sr.Metadata().ItemsSize)
+	assert.Zero(t, sr.Metadata().BytesSize)
+}
+
+func TestStorageReaderEmpty(t *testing.T) {
+	sr, err := NewStorageReader(context.Background(), newFakeBoundedStorageClient(1000))
+	require.NoError(t, err)
+	assert.Equal(t, StoredMetadata{}, sr.Metadata())
+	assert.Empty(t, sr.Indexes())
+	require.Error(t, sr.Remove(context.Background(), 0, 1, 1))
+}
+
+func TestStorageReaderRemoveTime(t *testing.T) {
+	client := newFakeBoundedStorageClient(1000)
+	set := newSettingsWithStorage(request.SizerTypeItems, 1000)
+	set.MaxAge = time.Minute
+	pq := newPersistentQueue[intRequest](set).(*persistentQueue[intRequest])
+	pq.initClient(context.Background(), client)
+	require.NoError(t, pq.Offer(context.Background(), intRequest(1)))
+	buf, err := client.Get(context.Background(), getItemTimeKey(0))
+	require.NoError(t, err)
+	require.NotNil(t, buf)
+
+	sr, err := NewStorageReader(context.Background(), client)
+	require.NoError(t, err)
+	require.NoError(t, sr.Remove(context.Background(), 0, 1, 10))
+	buf, err = client.Get(context.Background(), getItemTimeKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+}
@@ -6,6 +6,7 @@ package queue // import "go.opentelemetry.io/collector/exporter/exporterhelper/i
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
@@ -47,6 +48,48 @@ type Done interface {
 
 type ConsumeFunc[T any] func(context.Context, T, Done)
 
+// OverflowPolicy determines which data is dropped when a request is offered to a full queue that does not block.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyRejectNewest rejects the offered request with ErrQueueIsFull. This is the default policy.
+	OverflowPolicyRejectNewest OverflowPolicy = "reject_newest"
+	// OverflowPolicyDropOldest drops the oldest requests waiting in the queue to make space for the offered request.
+	OverflowPolicyDropOldest OverflowPolicy = "drop_oldest"
+)
+
+// PrioritySettings configures the priority lanes of a memory queue.
+// Requests are read from the highest priority lane first, lane 0 being the highest priority.
+type PrioritySettings[T any] struct {
+	// NumLanes is the number of priority lanes.
+	NumLanes int
+	// GetLane returns the lane of the request, it must be in the range [0, NumLanes).
+	GetLane func(context.Context, T) int
+	// StarvationLimit is the maximum number of requests read from higher priority lanes while
+	// a lower priority lane has requests waiting. When reached, the waiting lane is read next.
+	StarvationLimit int
+}
+
+// dropReason describes why a request was dropped by the queue before being consumed.
+type dropReason string
+
+const (
+	// dropReasonExpired is used when the request waited in the queue for longer than the configured max age.
+	dropReasonExpired dropReason = "expired"
+	// dropReasonOverflow is used when the request was dropped to make space for a newer request.
+	dropReasonOverflow dropReason = "overflow"
+)
+
+var (
+	errRequestExpired = errors.New("request expired in the sending queue")
+	errRequestEvicted = errors.New("request dropped from the sending queue to make space for newer data")
+)
+
+// droppedFunc is called for every request dropped by the queue before being consumed.
+type droppedFunc[T any] func(context.Context, T, dropReason)
+
 // Queue defines a producer-consumer exchange which can be backed by e.g. the memory-based ring buffer queue
 // (boundedMemoryQueue) or via a disk-based queue (persistentQueue)
 // Experimental: This API is at the early stage of development and may change without backward compatibility
@@ -65,13 +108,24 @@ type Queue[T any] interface {
 
 // Settings define internal parameters for a new Queue creation.
 type Settings[T request.Request] struct {
-	SizerType        request.SizerType
-	Capacity         int64
-	NumConsumers     int
-	WaitForResult    bool
-	BlockOnOverflow  bool
-	Signal           pipeline.Signal
-	StorageID        *component.ID
+	SizerType       request.SizerType
+	Capacity        int64
+	NumConsumers    int
+	WaitForResult   bool
+	BlockOnOverflow bool
+	Signal          pipeline.Signal
+	StorageID       *component.ID
+	// StorageName is the name of the storage client requested from the storage extension.
+	// If empty, the signal name is used.
+	StorageName string
+	// VerifyStorage enables the verification and repair of the persistent queue storage on startup.
+	VerifyStorage bool
+	// MaxAge is the maximum amount of time a request can wait in the queue, 0 means no limit.
+	MaxAge time.Duration
+	// OverflowPolicy determines which data is dropped when the queue is full, empty means OverflowPolicyRejectNewest.
+	OverflowPolicy OverflowPolicy
+	// Priority configures the priority lanes, only supported by the memory queue. If nil, a single lane is used.
+	Priority         *PrioritySettings[T]
 	ReferenceCounter ReferenceCounter[T]
 	Encoding         Encoding[T]
 	ID               component.ID
@@ -84,6 +138,7 @@ func NewQueue[T request.Request](set Settings[T], next ConsumeFunc[T]) (Queue[T]
 	if err != nil {
 		return nil, err
 	}
+	q.setDroppedFunc(oq.recordDropped)
 
 	return oq, nil
 }
@@ -97,6 +152,13 @@ func newBaseQueue[T request.Request](set Settings[T]) readableQueue[T] {
 	return newPersistentQueue[T](set)
 }
 
+// NewDeadLetterQueue returns a persistent Queue that only accepts requests. The stored requests are never
+// consumed by the running collector, they use the same storage layout as the persistent queue and
+// can be inspected or replayed offline.
+func NewDeadLetterQueue[T request.Request](set Settings[T]) Queue[T] {
+	return newPersistentQueue[T](set)
+}
+
 // TODO: Investigate why linter "unused" fails if add a private "read" func on the Queue.
 type readableQueue[T any] interface {
 	Queue[T]
@@ -105,4 +167,6 @@ type readableQueue[T any] interface {
 	// The function blocks until an item is available or if the queue is stopped.
 	// If the queue is stopped returns false, otherwise true.
 	Read(context.Context) (context.Context, T, Done, bool)
+	// setDroppedFunc sets the function called for every request dropped before being read.
+	setDroppedFunc(droppedFunc[T])
 }
@@ -45,6 +45,11 @@ func NewDefaultQueueConfig() queuebatch.Config {
 		QueueSize:       1_000,
 		BlockOnOverflow: false,
 		Batch:           batch,
+		Priority:        configoptional.Default(queuebatch.PriorityConfig{StarvationLimit: 10}),
+		AdaptiveConcurrency: configoptional.Default(queuebatch.AdaptiveConcurrencyConfig{
+			MinConsumers:  1,
+			DecreaseRatio: 0.5,
+		}),
 	}
 }
 
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+// AdaptiveConcurrencyConfig defines the configuration to adapt the number of concurrent exports
+// between `min_consumers` and `num_consumers`, similar to the TCP additive increase/multiplicative decrease.
+// The concurrency is increased by one after a full window of successful exports, and is multiplied by
+// `decrease_ratio` when an export fails with a retryable error or is slower than `latency_threshold`.
+type AdaptiveConcurrencyConfig struct {
+	// MinConsumers is the minimum and initial number of concurrent exports.
+	MinConsumers int `mapstructure:"min_consumers"`
+
+	// LatencyThreshold is the export latency above which the concurrency is decreased. If 0, only the errors are considered.
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// DecreaseRatio is the factor applied to the concurrency when the backend is overloaded, between 0 and 1.
+	DecreaseRatio float64 `mapstructure:"decrease_ratio"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *AdaptiveConcurrencyConfig) Validate() error {
+	if cfg.MinConsumers <= 0 {
+		return errors.New("`min_consumers` must be positive")
+	}
+	if cfg.LatencyThreshold < 0 {
+		return fmt.Errorf("`latency_threshold` must be non-negative, found %s", cfg.LatencyThreshold)
+	}
+	if cfg.DecreaseRatio <= 0 || cfg.DecreaseRatio >= 1 {
+		return fmt.Errorf("`decrease_ratio` must be between 0 and 1, found %v", cfg.DecreaseRatio)
+	}
+	return nil
+}
+
+// ConcurrencyLimiter limits the number of concurrent exports, the limit is adapted based on the latency
+// and the errors of the export attempts.
+type ConcurrencyLimiter struct {
+	tb               *metadata.TelemetryBuilder
+	minLimit         int
+	maxLimit         int
+	latencyThreshold time.Duration
+	decreaseRatio    float64
+	stopOnce         sync.Once
+	stopCh           chan struct{}
+
+	mu sync.Mutex
+	// changed is closed when a slot is released or the limit changes, to wake up the waiting exports.
+	changed  chan struct{}
+	limit    int
+	inFlight int
+	// successes counts the successful attempts since the last limit change.
+	successes int
+	// generation is incremented on every decrease, so the attempts started before a decrease do not decrease the limit again.
+	generation uint64
+}
+
+// NewConcurrencyLimiter returns a ConcurrencyLimiter adapting the limit between the configured minimum and maxLimit.
+func NewConcurrencyLimiter(set AllSettings[request.Request], cfg AdaptiveConcurrencyConfig, maxLimit int) (*ConcurrencyLimiter, error) {
+	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+	cl := &ConcurrencyLimiter{
+		tb:               tb,
+		minLimit:         min(cfg.MinConsumers, maxLimit),
+		maxLimit:         maxLimit,
+		latencyThreshold: cfg.LatencyThreshold,
+		decreaseRatio:    cfg.DecreaseRatio,
+		stopCh:           make(chan struct{}),
+		changed:          make(chan struct{}),
+		limit:            min(cfg.MinConsumers, maxLimit),
+	}
+
+	attrs := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("exporter", set.ID.String()),
+		attribute.String("data_type", set.Signal.String())))
+	if err = tb.RegisterExporterQueueConcurrencyCallback(func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(int64(cl.currentLimit()), attrs)
+		return nil
+	}); err != nil {
+		tb.Shutdown()
+		return nil, err
+	}
+	return cl, nil
+}
+
+// AttemptSender returns a sender observing the latency and the outcome of every attempt to send a request
+// to the next sender. It must be placed below the retries, so every failed attempt adapts the limit.
+func (cl *ConcurrencyLimiter) AttemptSender(next sender.Sender[request.Request]) sender.Sender[request.Request] {
+	return sender.NewSender(cl.observe(next.Send))
+}
+
+// observe returns a SendFunc adapting the limit to the latency and the outcome of the calls to next.
+func (cl *ConcurrencyLimiter) observe(next sender.SendFunc[request.Request]) sender.SendFunc[request.Request] {
+	return func(ctx context.Context, req request.Request) error {
+		cl.mu.Lock()
+		generation := cl.generation
+		cl.mu.Unlock()
+
+		start := time.Now()
+		err := next(ctx, req)
+		cl.onDone(generation, time.Since(start), err)
+		return err
+	}
+}
+
+// limitSend returns a SendFunc waiting for an available slot before calling next.
+func (cl *ConcurrencyLimiter) limitSend(next sender.SendFunc[request.Request]) sender.SendFunc[request.Request] {
+	return func(ctx context.Context, req request.Request) error {
+		if err := cl.acquire(ctx); err != nil {
+			return err
+		}
+		defer cl.release()
+		return next(ctx, req)
+	}
+}
+
+// acquire waits for an available slot, until the context is done. Once the limiter is shut down,
+// the exports do not wait anymore, so the queue is drained without being limited.
+func (cl *ConcurrencyLimiter) acquire(ctx context.Context) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for cl.inFlight >= cl.limit {
+		changed := cl.changed
+		cl.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			cl.mu.Lock()
+			return fmt.Errorf("request is cancelled or timed out: %w", context.Cause(ctx))
+		case <-cl.stopCh:
+			cl.mu.Lock()
+			cl.inFlight++
+			return nil
+		case <-changed:
+		}
+		cl.mu.Lock()
+	}
+	cl.inFlight++
+	return nil
+}
+
+func (cl *ConcurrencyLimiter) release() {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.inFlight--
+	cl.notifyLocked()
+}
+
+func (cl *ConcurrencyLimiter) onDone(generation uint64, latency time.Duration, err error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	overloaded := (err != nil && !consumererror.IsPermanent(err) && !experr.IsShutdownErr(err)) ||
+		(cl.latencyThreshold > 0 && latency > cl.latencyThreshold)
+	if overloaded {
+		if generation != cl.generation {
+			// The limit was already decreased since this attempt started.
+			return
+		}
+		cl.generation++
+		cl.successes = 0
+		ratio := cl.decreaseRatio
+		// Prefer the reduced rate requested by the destination if any.
+		var throttleErr *consumererror.Throttle
+		if errors.As(err, &throttleErr) && throttleErr.RateRatio() > 0 {
+			ratio = throttleErr.RateRatio()
+		}
+		cl.limit = max(int(float64(cl.limit)*ratio), cl.minLimit)
+		return
+	}
+	if err != nil {
+		// Permanent errors are not related to the backend load.
+		return
+	}
+	cl.successes++
+	if cl.successes >= cl.limit && cl.limit < cl.maxLimit {
+		cl.successes = 0
+		cl.limit++
+		cl.notifyLocked()
+	}
+}
+
+// notifyLocked wakes up the waiting exports. Callers MUST hold the mutex.
+func (cl *ConcurrencyLimiter) notifyLocked() {
+	close(cl.changed)
+	cl.changed = make(chan struct{})
+}
+
+func (cl *ConcurrencyLimiter) currentLimit() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.limit
+}
+
+// Shutdown stops limiting the exports and releases the waiting ones.
+func (cl *ConcurrencyLimiter) Shutdown() {
+	cl.stopOnce.Do(func() {
+		close(cl.stopCh)
+		cl.tb.Shutdown()
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestAdaptiveConcurrencyConfig_Validate(t *testing.T) {
+	newCfg := func() AdaptiveConcurrencyConfig {
+		return AdaptiveConcurrencyConfig{MinConsumers: 1, LatencyThreshold: time.Second, DecreaseRatio: 0.5}
+	}
+	cfg := newCfg()
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newCfg()
+	cfg.MinConsumers = 0
+	require.EqualError(t, confmap.Validate(cfg), "`min_consumers` must be positive")
+
+	cfg = newCfg()
+	cfg.LatencyThreshold = -time.Second
+	require.EqualError(t, confmap.Validate(cfg), "`latency_threshold` must be non-negative, found -1s")
+
+	cfg = newCfg()
+	cfg.DecreaseRatio = 1
+	require.EqualError(t, confmap.Validate(cfg), "`decrease_ratio` must be between 0 and 1, found 1")
+}
+
+// testConcurrencyLimiter limits and observes the calls to the next sender, as used by the QueueBatch
+// when no ConcurrencyLimiter is configured.
+type testConcurrencyLimiter struct {
+	*ConcurrencyLimiter
+	Send sender.SendFunc[request.Request]
+}
+
+func newTestConcurrencyLimiter(t *testing.T, tt *componenttest.Telemetry, cfg AdaptiveConcurrencyConfig, maxLimit int, next func(context.Context, request.Request) error) *testConcurrencyLimiter {
+	cl, err := NewConcurrencyLimiter(AllSettings[request.Request]{
+		Signal:    pipeline.SignalTraces,
+		ID:        component.MustNewID("otlp"),
+		Telemetry: tt.NewTelemetrySettings(),
+	}, cfg, maxLimit)
+	require.NoError(t, err)
+	t.Cleanup(cl.Shutdown)
+	return &testConcurrencyLimiter{ConcurrencyLimiter: cl, Send: cl.limitSend(cl.observe(next))}
+}
+
+func TestConcurrencyLimiter_AIMD(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	var exportErr error
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 2, DecreaseRatio: 0.5}, 5,
+		func(context.Context, request.Request) error { return exportErr })
+	assert.Equal(t, 2, cl.currentLimit())
+
+	// The limit is increased by one after a full window of successful exports.
+	for range 2 {
+		require.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 3, cl.currentLimit())
+	for range 3 + 4 + 5 {
+		require.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	}
+	assert.Equal(t, 5, cl.currentLimit())
+
+	// Permanent errors do not change the limit.
+	exportErr = consumererror.NewPermanent(errors.New("bad data"))
+	require.Error(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 5, cl.currentLimit())
+
+	// Retryable errors decrease the limit, down to the minimum.
+	exportErr = errors.New("unavailable")
+	require.Error(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cl.currentLimit())
+	require.Error(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cl.currentLimit())
+
+	metadatatest.AssertEqualExporterQueueConcurrency(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("exporter", "otlp"),
+					attribute.String("data_type", "traces")),
+				Value: 2,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestConcurrencyLimiter_Latency(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 1, LatencyThreshold: time.Millisecond, DecreaseRatio: 0.5}, 4,
+		func(context.Context, request.Request) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	cl.limit = 4
+	require.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cl.currentLimit())
+}
+
+func TestConcurrencyLimiter_ThrottleRateRatio(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 1, DecreaseRatio: 0.5}, 10,
+		func(context.Context, request.Request) error {
+			return consumererror.NewThrottle(errors.New("slow down"), time.Second, consumererror.WithRateRatio(0.8))
+		})
+	cl.limit = 10
+	require.Error(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 8, cl.currentLimit())
+}
+
+func TestConcurrencyLimiter_LimitsInFlight(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	block := make(chan struct{})
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 1, DecreaseRatio: 0.75}, 10,
+		func(context.Context, request.Request) error {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			<-block
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return errors.New("unavailable")
+		})
+
+	cl.limit = 4
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Go(func() {
+			assert.Error(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		})
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inFlight == 4
+	}, time.Second, time.Millisecond)
+	close(block)
+	wg.Wait()
+	assert.Equal(t, 4, maxInFlight)
+	// Concurrent failures of the exports started with the same limit decrease the limit only once.
+	assert.Equal(t, 3, cl.currentLimit())
+}
+
+func TestConcurrencyLimiter_WaitsForSlot(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	started := make(chan struct{}, 2)
+	block := make(chan struct{})
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 1, DecreaseRatio: 0.5}, 10,
+		func(context.Context, request.Request) error {
+			started <- struct{}{}
+			<-block
+			return nil
+		})
+
+	wg := sync.WaitGroup{}
+	for range 2 {
+		wg.Go(func() {
+			assert.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+		})
+	}
+	<-started
+	select {
+	case <-started:
+		t.Fatal("second export started while the limit is 1")
+	case <-time.After(50 * time.Millisecond):
+	}
+	block <- struct{}{}
+	<-started
+	close(block)
+	wg.Wait()
+}
+
+func TestConcurrencyLimiter_WaitCancelled(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	block := make(chan struct{})
+	cl := newTestConcurrencyLimiter(t, tt, AdaptiveConcurrencyConfig{MinConsumers: 1, DecreaseRatio: 0.5}, 10,
+		func(context.Context, request.Request) error {
+			<-block
+			return nil
+		})
+
+	wg := sync.WaitGroup{}
+	wg.Go(func() {
+		assert.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	})
+	assert.Eventually(t, func() bool {
+		cl.mu.Lock()
+		defer cl.mu.Unlock()
+		return cl.inFlight == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, cl.Send(ctx, &requesttest.FakeRequest{Items: 1}), context.Canceled)
+
+	// The shutdown releases the waiting exports.
+	wg.Go(func() {
+		assert.NoError(t, cl.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	})
+	cl.Shutdown()
+	close(block)
+	wg.Wait()
+}
+
+func TestConcurrencyLimiter_AttemptSender(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cl, err := NewConcurrencyLimiter(AllSettings[request.Request]{
+		Signal:    pipeline.SignalTraces,
+		ID:        component.MustNewID("otlp"),
+		Telemetry: tt.NewTelemetrySettings(),
+	}, AdaptiveConcurrencyConfig{MinConsumers: 1, DecreaseRatio: 0.5}, 10)
+	require.NoError(t, err)
+	t.Cleanup(cl.Shutdown)
+	cl.limit = 8
+
+	// Every failed attempt decreases the limit, even if the request is eventually sent.
+	attempts := 0
+	attempt := cl.AttemptSender(sender.NewSender(func(context.Context, request.Request) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}))
+	send := cl.limitSend(func(ctx context.Context, req request.Request) error {
+		for {
+			if err := attempt.Send(ctx, req); err == nil {
+				return nil
+			}
+		}
+	})
+	require.NoError(t, send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	assert.Equal(t, 2, cl.currentLimit())
+}
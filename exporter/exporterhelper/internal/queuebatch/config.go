@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -32,18 +33,38 @@ type Config struct {
 	// If true, the component will wait for space; otherwise, operations will immediately return a retryable error.
 	BlockOnOverflow bool `mapstructure:"block_on_overflow"`
 
+	// OverflowPolicy determines which data is dropped when the queue is full and BlockOnOverflow is false.
+	// It accepts "reject_newest" (default) or "drop_oldest".
+	OverflowPolicy queue.OverflowPolicy `mapstructure:"overflow_policy"`
+
+	// MaxAge is the maximum amount of time a request can wait in the queue before being dropped.
+	// If 0, the requests never expire.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue.
 	// TODO: This will be changed to Optional when available.
 	// See https://github.com/open-telemetry/opentelemetry-collector/issues/13822
 	StorageID *component.ID `mapstructure:"storage"`
 
+	// VerifyStorage if true, the persistent queue walks through all its storage entries on startup to repair the
+	// inconsistencies left by crashes or storage failures. The startup time grows with the number of stored items.
+	VerifyStorage bool `mapstructure:"verify_storage"`
+
 	// NumConsumers is the maximum number of concurrent consumers from the queue.
 	// This applies across all different optional configurations from above (e.g. wait_for_result, block_on_overflow, storage, etc.).
 	NumConsumers int `mapstructure:"num_consumers"`
 
+	// AdaptiveConcurrency if enabled adapts the number of concurrent exports between its `min_consumers`
+	// and NumConsumers based on the observed export latency and errors.
+	AdaptiveConcurrency configoptional.Optional[AdaptiveConcurrencyConfig] `mapstructure:"adaptive_concurrency"`
+
 	// BatchConfig it configures how the requests are consumed from the queue and batch together during consumption.
 	Batch configoptional.Optional[BatchConfig] `mapstructure:"batch"`
+
+	// Priority configures priority lanes, so the requests matching a higher priority lane are read first from the queue.
+	// Currently, this option is not available when persistent queue is configured using the storage configuration.
+	Priority configoptional.Optional[PriorityConfig] `mapstructure:"priority"`
 }
 
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
@@ -73,11 +94,38 @@ func (cfg *Config) Validate() error {
 		return errors.New("`queue_size` must be positive")
 	}
 
+	switch cfg.OverflowPolicy {
+	case "", queue.OverflowPolicyRejectNewest:
+	case queue.OverflowPolicyDropOldest:
+		if cfg.BlockOnOverflow {
+			return errors.New("`overflow_policy` \"drop_oldest\" cannot be used with `block_on_overflow`")
+		}
+	default:
+		return fmt.Errorf("`overflow_policy` must be either %q or %q, found %q",
+			queue.OverflowPolicyRejectNewest, queue.OverflowPolicyDropOldest, cfg.OverflowPolicy)
+	}
+
+	if cfg.MaxAge < 0 {
+		return fmt.Errorf("`max_age` must be non-negative, found %s", cfg.MaxAge)
+	}
+
 	// Only support request sizer for persistent queue at this moment.
 	if cfg.StorageID != nil && cfg.WaitForResult {
 		return errors.New("`wait_for_result` is not supported with a persistent queue configured with `storage`")
 	}
 
+	if cfg.StorageID == nil && cfg.VerifyStorage {
+		return errors.New("`verify_storage` requires a persistent queue configured with `storage`")
+	}
+
+	if cfg.AdaptiveConcurrency.HasValue() && cfg.AdaptiveConcurrency.Get().MinConsumers > cfg.NumConsumers {
+		return errors.New("`min_consumers` must be less than or equal to `num_consumers`")
+	}
+
+	if cfg.StorageID != nil && cfg.Priority.HasValue() {
+		return errors.New("`priority` is not supported with a persistent queue configured with `storage`")
+	}
+
 	if cfg.Batch.HasValue() && cfg.Batch.Get().Sizer == cfg.Sizer {
 		// Avoid situations where the queue is not able to hold any data.
 		if cfg.Batch.Get().MinSize > cfg.QueueSize {
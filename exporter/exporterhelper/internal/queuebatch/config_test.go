@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -50,6 +51,49 @@ func TestConfig_Validate(t *testing.T) {
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeBytes
 	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.BlockOnOverflow = false
+	cfg.OverflowPolicy = queue.OverflowPolicyDropOldest
+	cfg.MaxAge = time.Hour
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.OverflowPolicy = "drop_newest"
+	require.EqualError(t, confmap.Validate(cfg), "`overflow_policy` must be either \"reject_newest\" or \"drop_oldest\", found \"drop_newest\"")
+
+	cfg = newTestConfig()
+	cfg.OverflowPolicy = queue.OverflowPolicyDropOldest
+	cfg.BlockOnOverflow = true
+	require.EqualError(t, confmap.Validate(cfg), "`overflow_policy` \"drop_oldest\" cannot be used with `block_on_overflow`")
+
+	cfg = newTestConfig()
+	cfg.StorageID = &storageID
+	cfg.Priority = configoptional.Some(PriorityConfig{
+		Lanes:           []LaneConfig{{Name: "high", Metadata: map[string][]string{"tenant": {"a"}}}},
+		StarvationLimit: 10,
+	})
+	require.EqualError(t, confmap.Validate(cfg), "`priority` is not supported with a persistent queue configured with `storage`")
+
+	cfg = newTestConfig()
+	cfg.MaxAge = -time.Second
+	require.EqualError(t, confmap.Validate(cfg), "`max_age` must be non-negative, found -1s")
+
+	cfg = newTestConfig()
+	cfg.VerifyStorage = true
+	require.EqualError(t, confmap.Validate(cfg), "`verify_storage` requires a persistent queue configured with `storage`")
+	cfg.StorageID = &storageID
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.NumConsumers = 4
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConsumers: 2, DecreaseRatio: 0.5})
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newTestConfig()
+	cfg.NumConsumers = 2
+	cfg.AdaptiveConcurrency = configoptional.Some(AdaptiveConcurrencyConfig{MinConsumers: 4, DecreaseRatio: 0.5})
+	require.EqualError(t, confmap.Validate(cfg), "`min_consumers` must be less than or equal to `num_consumers`")
 }
 
 func TestBatchConfig_Validate_MetadataKeys(t *testing.T) {
@@ -121,6 +165,40 @@ func TestBatchConfig_Validate(t *testing.T) {
 	require.EqualError(t, confmap.Validate(cfg), "`max_size` (1024) must be greater or equal to `min_size` (2048)")
 }
 
+func TestPriorityConfig_Validate(t *testing.T) {
+	newPriorityConfig := func() PriorityConfig {
+		return PriorityConfig{
+			Lanes: []LaneConfig{
+				{Name: "high", Metadata: map[string][]string{"tenant": {"a"}}},
+				{Name: "low", ResourceAttributes: map[string][]string{"service.name": {"b"}}},
+			},
+			StarvationLimit: 10,
+		}
+	}
+	cfg := newPriorityConfig()
+	require.NoError(t, confmap.Validate(cfg))
+
+	cfg = newPriorityConfig()
+	cfg.Lanes = nil
+	require.EqualError(t, confmap.Validate(cfg), "`lanes` must not be empty")
+
+	cfg = newPriorityConfig()
+	cfg.StarvationLimit = 0
+	require.EqualError(t, confmap.Validate(cfg), "`starvation_limit` must be positive")
+
+	cfg = newPriorityConfig()
+	cfg.Lanes[1].Name = "high"
+	require.EqualError(t, confmap.Validate(cfg), "duplicate lane name \"high\"")
+
+	cfg = newPriorityConfig()
+	cfg.Lanes[1].Name = ""
+	require.EqualError(t, confmap.Validate(cfg), "lanes::1: `name` must not be empty")
+
+	cfg = newPriorityConfig()
+	cfg.Lanes[1].ResourceAttributes = nil
+	require.EqualError(t, confmap.Validate(cfg), "lanes::1: lane \"low\" must define `metadata` or `resource_attributes`")
+}
+
 func newTestBatchConfig() BatchConfig {
 	return BatchConfig{
 		FlushTimeout: 200 * time.Millisecond,
@@ -141,6 +219,7 @@ func TestUnmarshal(t *testing.T) {
 				Sizer:        request.SizerTypeItems,
 				MinSize:      8192,
 			}),
+			Priority: configoptional.Default(PriorityConfig{StarvationLimit: 10}),
 		})
 	}
 	tests := []struct {
@@ -200,6 +279,29 @@ func TestUnmarshal(t *testing.T) {
 			// Batch remains unset, sizer override does not apply.
 			expectedCfg: newBaseCfg,
 		},
+		{
+			path: "priority.yaml",
+			expectedCfg: func() configoptional.Optional[Config] {
+				cfg := newBaseCfg()
+				cfg.Get().Priority = configoptional.Some(PriorityConfig{
+					Lanes: []LaneConfig{
+						{
+							Name:     "critical",
+							Metadata: map[string][]string{"x-tenant": {"slo"}},
+							ResourceAttributes: map[string][]string{
+								"service.name": {"checkout", "payment"},
+							},
+						},
+						{
+							Name:               "errors",
+							ResourceAttributes: map[string][]string{"severity": {"error"}},
+						},
+					},
+					StarvationLimit: 10,
+				})
+				return cfg
+			},
+		},
 		{
 			path: "batch_disabled_explicit_sizer.yaml",
 			// Batch is explicitly disabled. Sizer inheritance must not
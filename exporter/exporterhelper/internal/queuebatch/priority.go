@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// PriorityConfig defines the priority lanes used to order the requests waiting in the queue.
+type PriorityConfig struct {
+	// Lanes defines the priority lanes from the highest to the lowest priority. A request is placed in
+	// the first lane it matches, requests that do not match any lane are placed in an implicit lowest priority lane.
+	Lanes []LaneConfig `mapstructure:"lanes"`
+
+	// StarvationLimit is the maximum number of requests read from higher priority lanes while a lower
+	// priority lane has requests waiting. When reached, the next request is read from the waiting lane.
+	StarvationLimit int `mapstructure:"starvation_limit"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// LaneConfig defines how the requests are matched to a priority lane.
+// A request matches the lane if any of the configured values matches.
+type LaneConfig struct {
+	// Name identifies the lane.
+	Name string `mapstructure:"name"`
+
+	// Metadata matches the requests with any of the listed values for the client.Metadata key.
+	// Keys are case-insensitive.
+	Metadata map[string][]string `mapstructure:"metadata"`
+
+	// ResourceAttributes matches the requests containing a resource with any of the listed values
+	// for the attribute key. Only available for logs, metrics and traces.
+	ResourceAttributes map[string][]string `mapstructure:"resource_attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *PriorityConfig) Validate() error {
+	if len(cfg.Lanes) == 0 {
+		return errors.New("`lanes` must not be empty")
+	}
+	if cfg.StarvationLimit <= 0 {
+		return errors.New("`starvation_limit` must be positive")
+	}
+	names := map[string]bool{}
+	for _, lane := range cfg.Lanes {
+		if names[lane.Name] {
+			return fmt.Errorf("duplicate lane name %q", lane.Name)
+		}
+		names[lane.Name] = true
+	}
+	return nil
+}
+
+func (cfg *LaneConfig) Validate() error {
+	if cfg.Name == "" {
+		return errors.New("`name` must not be empty")
+	}
+	if len(cfg.Metadata) == 0 && len(cfg.ResourceAttributes) == 0 {
+		return fmt.Errorf("lane %q must define `metadata` or `resource_attributes`", cfg.Name)
+	}
+	return nil
+}
+
+// newPrioritySettings returns the queue priority settings for the configured lanes.
+func newPrioritySettings(cfg PriorityConfig) *queue.PrioritySettings[request.Request] {
+	return &queue.PrioritySettings[request.Request]{
+		// The last lane is used by the requests not matching any configured lane.
+		NumLanes:        len(cfg.Lanes) + 1,
+		StarvationLimit: cfg.StarvationLimit,
+		GetLane: func(ctx context.Context, req request.Request) int {
+			meta := client.FromContext(ctx).Metadata
+			for i := range cfg.Lanes {
+				if cfg.Lanes[i].matches(meta, req) {
+					return i
+				}
+			}
+			return len(cfg.Lanes)
+		},
+	}
+}
+
+func (cfg *LaneConfig) matches(meta client.Metadata, req request.Request) bool {
+	for key, values := range cfg.Metadata {
+		for _, v := range meta.Get(key) {
+			if slices.Contains(values, v) {
+				return true
+			}
+		}
+	}
+	if len(cfg.ResourceAttributes) == 0 {
+		return false
+	}
+	matchResource := func(res pcommon.Resource) bool {
+		for key, values := range cfg.ResourceAttributes {
+			if v, ok := res.Attributes().Get(key); ok && slices.Contains(values, v.AsString()) {
+				return true
+			}
+		}
+		return false
+	}
+	switch r := req.(type) {
+	case *logsRequest:
+		for i := 0; i < r.ld.ResourceLogs().Len(); i++ {
+			if matchResource(r.ld.ResourceLogs().At(i).Resource()) {
+				return true
+			}
+		}
+	case *metricsRequest:
+		for i := 0; i < r.md.ResourceMetrics().Len(); i++ {
+			if matchResource(r.md.ResourceMetrics().At(i).Resource()) {
+				return true
+			}
+		}
+	case *tracesRequest:
+		for i := 0; i < r.td.ResourceSpans().Len(); i++ {
+			if matchResource(r.td.ResourceSpans().At(i).Resource()) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPrioritySettings_GetLane(t *testing.T) {
+	set := newPrioritySettings(PriorityConfig{
+		Lanes: []LaneConfig{
+			{Name: "tenant", Metadata: map[string][]string{"X-Tenant": {"slo", "critical"}}},
+			{Name: "service", ResourceAttributes: map[string][]string{"service.name": {"checkout"}}},
+		},
+		StarvationLimit: 10,
+	})
+	assert.Equal(t, 3, set.NumLanes)
+	assert.Equal(t, 10, set.StarvationLimit)
+
+	tenantCtx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"critical"}}),
+	})
+	otherTenantCtx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"bulk"}}),
+	})
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", "debug")
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "debug")
+
+	assert.Equal(t, 0, set.GetLane(tenantCtx, newTracesRequest(td)))
+	assert.Equal(t, 1, set.GetLane(otherTenantCtx, newLogsRequest(ld)))
+	assert.Equal(t, 1, set.GetLane(context.Background(), newMetricsRequest(md)))
+	assert.Equal(t, 2, set.GetLane(otherTenantCtx, newTracesRequest(td)))
+	// Resource attributes are not available for other requests.
+	assert.Equal(t, 2, set.GetLane(context.Background(), &requesttest.FakeRequest{Items: 1}))
+}
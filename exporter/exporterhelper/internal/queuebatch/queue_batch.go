@@ -28,18 +28,40 @@ type AllSettings[T any] struct {
 	Signal    pipeline.Signal
 	ID        component.ID
 	Telemetry component.TelemetrySettings
+	// ConcurrencyLimiter is the limiter used when the adaptive concurrency is enabled, it must observe the attempts
+	// to send the requests, e.g. through ConcurrencyLimiter.AttemptSender below the retries. If nil, a limiter
+	// observing the calls to the next sender is created. The QueueBatch shuts it down.
+	ConcurrencyLimiter *ConcurrencyLimiter
 }
 
 type QueueBatch struct {
 	queue   queue.Queue[request.Request]
 	batcher Batcher[request.Request]
+	limiter *ConcurrencyLimiter
 }
 
 func NewQueueBatch(
 	set AllSettings[request.Request],
 	cfg Config,
 	next sender.SendFunc[request.Request],
-) (*QueueBatch, error) {
+) (qb *QueueBatch, err error) {
+	limiter := set.ConcurrencyLimiter
+	if limiter == nil && cfg.AdaptiveConcurrency.HasValue() {
+		limiter, err = NewConcurrencyLimiter(set, *cfg.AdaptiveConcurrency.Get(), cfg.NumConsumers)
+		if err != nil {
+			return nil, err
+		}
+		next = limiter.observe(next)
+	}
+	if limiter != nil {
+		defer func() {
+			if err != nil {
+				limiter.Shutdown()
+			}
+		}()
+		next = limiter.limitSend(next)
+	}
+
 	b, err := NewBatcher(cfg.Batch, batcherSettings[request.Request]{
 		partitioner: set.Partitioner,
 		mergeCtx:    set.MergeCtx,
@@ -56,14 +78,23 @@ func NewQueueBatch(
 		cfg.NumConsumers = 1
 	}
 
+	var priority *queue.PrioritySettings[request.Request]
+	if cfg.Priority.HasValue() {
+		priority = newPrioritySettings(*cfg.Priority.Get())
+	}
+
 	q, err := queue.NewQueue(queue.Settings[request.Request]{
 		SizerType:        cfg.Sizer,
 		Capacity:         cfg.QueueSize,
 		NumConsumers:     cfg.NumConsumers,
 		WaitForResult:    cfg.WaitForResult,
 		BlockOnOverflow:  cfg.BlockOnOverflow,
+		OverflowPolicy:   cfg.OverflowPolicy,
+		MaxAge:           cfg.MaxAge,
+		Priority:         priority,
 		Signal:           set.Signal,
 		StorageID:        cfg.StorageID,
+		VerifyStorage:    cfg.VerifyStorage,
 		ReferenceCounter: set.ReferenceCounter,
 		Encoding:         set.Encoding,
 		ID:               set.ID,
@@ -73,7 +104,7 @@ func NewQueueBatch(
 		return nil, err
 	}
 
-	return &QueueBatch{queue: q, batcher: b}, nil
+	return &QueueBatch{queue: q, batcher: b, limiter: limiter}, nil
 }
 
 // Start is invoked during service startup.
@@ -89,6 +120,10 @@ func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (qs *QueueBatch) Shutdown(ctx context.Context) error {
+	// Stop limiting the concurrency, so the waiting requests are released.
+	if qs.limiter != nil {
+		qs.limiter.Shutdown()
+	}
 	// Stop the queue and batcher, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
 	return errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx))
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+// maxRateLimiterPartitions is the maximum number of partitions tracked by the rate limiter,
+// the least recently used partitions are evicted first.
+const maxRateLimiterPartitions = 10000
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// RateLimiterConfig defines the configuration of the client-side rate limiter, which caps the rate at which
+// the data is sent to the destination using a token bucket.
+type RateLimiterConfig struct {
+	// Sizer determines the unit of the Rate. It accepts "requests", "items", or "bytes".
+	Sizer request.SizerType `mapstructure:"sizer"`
+
+	// Rate is the maximum number of units sent per second.
+	Rate int64 `mapstructure:"rate"`
+
+	// Burst is the maximum number of units that can be sent at once after a period of inactivity.
+	// If 0, it is equal to the Rate.
+	Burst int64 `mapstructure:"burst"`
+
+	// BlockOnLimit determines the behavior when the rate is exceeded. If true, the requests wait for the rate to allow
+	// them, which blocks the queue consumers. Otherwise, the requests are rejected with a retryable error.
+	BlockOnLimit bool `mapstructure:"block_on_limit"`
+
+	// Partition defines the partitioning of the rate limit. When metadata keys are configured, the rate is enforced
+	// separately for every distinct combination of values for the listed metadata keys.
+	Partition queuebatch.PartitionConfig `mapstructure:"partition"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *RateLimiterConfig) Validate() error {
+	if cfg.Rate <= 0 {
+		return fmt.Errorf("`rate` must be positive, found %d", cfg.Rate)
+	}
+	if cfg.Burst < 0 {
+		return fmt.Errorf("`burst` must be non-negative, found %d", cfg.Burst)
+	}
+	return nil
+}
+
+// NewDefaultRateLimiterConfig returns the default config for RateLimiterConfig.
+func NewDefaultRateLimiterConfig() RateLimiterConfig {
+	return RateLimiterConfig{
+		Sizer:        request.SizerTypeItems,
+		BlockOnLimit: true,
+	}
+}
+
+// tokenBucket is a token bucket refilled at a constant rate up to its burst.
+// Requests larger than the burst are allowed when the bucket is full, then the bucket goes in debt.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int64) *tokenBucket {
+	return &tokenBucket{rate: float64(rate), burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	tb.tokens = min(tb.burst, tb.tokens+now.Sub(tb.last).Seconds()*tb.rate)
+	tb.last = now
+}
+
+// take removes n tokens from the bucket and returns 0 if they are available. Otherwise, it returns the time to wait
+// for them, the tokens are removed only if reserve is true, then the caller must wait before sending.
+func (tb *tokenBucket) take(now time.Time, n float64, reserve bool) time.Duration {
+	tb.refill(now)
+	// Requests larger than the burst only need a full bucket.
+	needed := min(n, tb.burst)
+	if tb.tokens >= needed {
+		tb.tokens -= n
+		return 0
+	}
+	wait := time.Duration((needed - tb.tokens) / tb.rate * float64(time.Second))
+	if reserve {
+		tb.tokens -= n
+	}
+	return wait
+}
+
+// rateLimiterSender is a requestSender that limits the rate at which the requests are sent.
+type rateLimiterSender struct {
+	component.StartFunc
+	cfg         RateLimiterConfig
+	sizer       request.Sizer
+	partitioner queuebatch.Partitioner[request.Request]
+	stopOnce    sync.Once
+	stopCh      chan struct{}
+	next        sender.Sender[request.Request]
+
+	mu      sync.Mutex
+	buckets *lru.LRU[string, *tokenBucket]
+}
+
+func newRateLimiterSender(cfg RateLimiterConfig, next sender.Sender[request.Request]) (*rateLimiterSender, error) {
+	buckets, err := lru.NewLRU[string, *tokenBucket](maxRateLimiterPartitions, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimiterSender{
+		cfg:         cfg,
+		sizer:       request.NewSizer(cfg.Sizer),
+		partitioner: queuebatch.NewMetadataKeysPartitioner(cfg.Partition.MetadataKeys),
+		stopCh:      make(chan struct{}),
+		next:        next,
+		buckets:     buckets,
+	}, nil
+}
+
+func (rl *rateLimiterSender) Shutdown(context.Context) error {
+	rl.stopOnce.Do(func() { close(rl.stopCh) })
+	return nil
+}
+
+// Send implements the requestSender interface
+func (rl *rateLimiterSender) Send(ctx context.Context, req request.Request) error {
+	size := float64(rl.sizer.Sizeof(req))
+	bucket := rl.bucket(ctx, req)
+
+	rl.mu.Lock()
+	wait := bucket.take(time.Now(), size, rl.cfg.BlockOnLimit)
+	rl.mu.Unlock()
+
+	if wait > 0 {
+		if !rl.cfg.BlockOnLimit {
+			if rl.partitioner != nil {
+				// Do not use a throttle error, the retry would pause the requests of all the partitions.
+				return consumererror.NewRetryableError(errRateLimited)
+			}
+			return consumererror.NewThrottle(errRateLimited, wait)
+		}
+		if err := rl.wait(ctx, wait); err != nil {
+			// Give back the reserved tokens since the request is not sent.
+			rl.mu.Lock()
+			bucket.tokens += size
+			rl.mu.Unlock()
+			return err
+		}
+	}
+	return rl.next.Send(ctx, req)
+}
+
+func (rl *rateLimiterSender) bucket(ctx context.Context, req request.Request) *tokenBucket {
+	key := ""
+	if rl.partitioner != nil {
+		key = rl.partitioner.GetKey(ctx, req)
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	bucket, ok := rl.buckets.Get(key)
+	if !ok {
+		burst := rl.cfg.Burst
+		if burst == 0 {
+			burst = rl.cfg.Rate
+		}
+		bucket = newTokenBucket(rl.cfg.Rate, burst)
+		rl.buckets.Add(key, bucket)
+	}
+	return bucket
+}
+
+func (rl *rateLimiterSender) wait(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request is cancelled or timed out: %w", errRateLimited)
+	case <-rl.stopCh:
+		return experr.NewShutdownErr(errRateLimited)
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+func TestRateLimiterConfigValidate(t *testing.T) {
+	cfg := NewDefaultRateLimiterConfig()
+	require.EqualError(t, confmap.Validate(&cfg), "`rate` must be positive, found 0")
+
+	cfg.Rate = 100
+	require.NoError(t, confmap.Validate(&cfg))
+
+	cfg.Burst = -1
+	require.EqualError(t, confmap.Validate(&cfg), "`burst` must be non-negative, found -1")
+
+	cfg = NewDefaultRateLimiterConfig()
+	cfg.Rate = 100
+	cfg.Partition.MetadataKeys = []string{"tenant", "Tenant"}
+	require.ErrorContains(t, confmap.Validate(&cfg), "duplicate entry in metadata_keys")
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	tb := newTokenBucket(10, 20)
+	tb.last = now
+
+	assert.Zero(t, tb.take(now, 15, false))
+	// 5 tokens left, 10 are needed.
+	assert.Equal(t, 500*time.Millisecond, tb.take(now, 10, false))
+	assert.Equal(t, 500*time.Millisecond, tb.take(now, 10, true))
+	assert.Equal(t, -5.0, tb.tokens)
+
+	// The bucket is refilled at the rate, up to the burst.
+	now = now.Add(time.Hour)
+	assert.Zero(t, tb.take(now, 0, false))
+	assert.Equal(t, 20.0, tb.tokens)
+
+	// A request larger than the burst is allowed when the bucket is full.
+	assert.Zero(t, tb.take(now, 50, false))
+	assert.Equal(t, 3100*time.Millisecond, tb.take(now, 1, false))
+}
+
+func TestRateLimiterSenderBlock(t *testing.T) {
+	cfg := NewDefaultRateLimiterConfig()
+	cfg.Rate = 100
+	sink := requesttest.NewSink()
+	rl, err := newRateLimiterSender(cfg, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+	require.NoError(t, rl.Start(context.Background(), componenttest.NewNopHost()))
+
+	start := time.Now()
+	require.NoError(t, rl.Send(context.Background(), &requesttest.FakeRequest{Items: 100}))
+	require.NoError(t, rl.Send(context.Background(), &requesttest.FakeRequest{Items: 10}))
+	// The second request waits for 10 items worth of tokens.
+	assert.LessOrEqual(t, 90*time.Millisecond, time.Since(start))
+	assert.Equal(t, 110, sink.ItemsCount())
+
+	// A cancelled request gives back its tokens.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, rl.Send(ctx, &requesttest.FakeRequest{Items: 100}), errRateLimited)
+	bucket := rl.bucket(context.Background(), &requesttest.FakeRequest{})
+	assert.Less(t, -1.0, bucket.tokens)
+
+	require.NoError(t, rl.Send(context.Background(), &requesttest.FakeRequest{Items: 200}))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, rl.Shutdown(context.Background()))
+	}()
+	assert.True(t, experr.IsShutdownErr(rl.Send(context.Background(), &requesttest.FakeRequest{Items: 1})))
+}
+
+func TestRateLimiterSenderReject(t *testing.T) {
+	cfg := NewDefaultRateLimiterConfig()
+	cfg.Sizer = request.SizerTypeRequests
+	cfg.Rate = 1
+	cfg.BlockOnLimit = false
+	sink := requesttest.NewSink()
+	rl, err := newRateLimiterSender(cfg, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+
+	require.NoError(t, rl.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	err = rl.Send(context.Background(), &requesttest.FakeRequest{Items: 5})
+	var throttleErr *consumererror.Throttle
+	require.ErrorAs(t, err, &throttleErr)
+	assert.Greater(t, throttleErr.Delay(), 900*time.Millisecond)
+	assert.Equal(t, 1, sink.RequestsCount())
+	require.NoError(t, rl.Shutdown(context.Background()))
+	// A second Shutdown is a no-op.
+	require.NoError(t, rl.Shutdown(context.Background()))
+}
+
+func TestRateLimiterSenderPartitions(t *testing.T) {
+	cfg := NewDefaultRateLimiterConfig()
+	cfg.Rate = 10
+	cfg.BlockOnLimit = false
+	cfg.Partition.MetadataKeys = []string{"tenant"}
+	sink := requesttest.NewSink()
+	rl, err := newRateLimiterSender(cfg, sender.NewSender(sink.Export))
+	require.NoError(t, err)
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+	}
+	require.NoError(t, rl.Send(tenantCtx("a"), &requesttest.FakeRequest{Items: 10}))
+	err = rl.Send(tenantCtx("a"), &requesttest.FakeRequest{Items: 10})
+	require.ErrorIs(t, err, errRateLimited)
+	var ce *consumererror.Error
+	require.ErrorAs(t, err, &ce)
+	assert.True(t, ce.IsRetryable())
+	assert.False(t, errors.As(err, new(*consumererror.Throttle)))
+
+	// Every tenant has its own rate.
+	require.NoError(t, rl.Send(tenantCtx("b"), &requesttest.FakeRequest{Items: 10}))
+	assert.Equal(t, 20, sink.ItemsCount())
+	require.NoError(t, rl.Shutdown(context.Background()))
+}
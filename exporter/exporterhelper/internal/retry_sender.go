@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v7"
@@ -23,34 +24,16 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
 )
 
-// TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
-type throttleRetry struct {
-	err   error
-	delay time.Duration
-}
-
-func (t throttleRetry) Error() string {
-	return "Throttle (" + t.delay.String() + "), error: " + t.err.Error()
-}
-
-func (t throttleRetry) Unwrap() error {
-	return t.err
-}
-
-// NewThrottleRetry creates a new throttle retry error.
-func NewThrottleRetry(err error, delay time.Duration) error {
-	return throttleRetry{
-		err:   err,
-		delay: delay,
-	}
-}
-
 type retrySender struct {
 	component.StartFunc
 	cfg    configretry.BackOffConfig
 	stopCh chan struct{}
 	logger *zap.Logger
 	next   sender.Sender[request.Request]
+
+	mu sync.Mutex
+	// pausedUntil is set when the destination throttles an export, no request is sent before this time.
+	pausedUntil time.Time
 }
 
 func newRetrySender(config configretry.BackOffConfig, set exporter.Settings, next sender.Sender[request.Request]) *retrySender {
@@ -84,6 +67,11 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 		maxElapsedTime = time.Now().Add(rs.cfg.MaxElapsedTime)
 	}
 	for {
+		// Wait while the destination throttles the exporter, so all the requests are paused instead of retried on their own.
+		if err := rs.waitPause(ctx); err != nil {
+			return err
+		}
+
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(attribute.Int64("retry_num", retryNum)),
@@ -105,18 +93,19 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 
 		backoffDelay := expBackoff.NextBackOff()
 		if backoffDelay == backoff.Stop {
-			return fmt.Errorf("no more retries left: %w", err)
+			return experr.NewRetriesExhaustedErr(err)
 		}
 
-		throttleErr := throttleRetry{}
+		var throttleErr *consumererror.Throttle
 		if errors.As(err, &throttleErr) {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+			backoffDelay = max(backoffDelay, throttleErr.Delay())
+			rs.pause(throttleErr.Delay())
 		}
 
 		nextRetryTime := time.Now().Add(backoffDelay)
 		if !maxElapsedTime.IsZero() && maxElapsedTime.Before(nextRetryTime) {
 			// The delay is longer than the maxElapsedTime.
-			return fmt.Errorf("no more retries left: %w", err)
+			return experr.NewRetriesExhaustedErr(err)
 		}
 
 		if deadline, has := ctx.Deadline(); has && deadline.Before(nextRetryTime) {
@@ -150,3 +139,36 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 		}
 	}
 }
+
+// pause stops sending any request for the given delay.
+func (rs *retrySender) pause(delay time.Duration) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(rs.pausedUntil) {
+		rs.pausedUntil = until
+	}
+}
+
+// waitPause blocks until the exporter is not paused anymore.
+func (rs *retrySender) waitPause(ctx context.Context) error {
+	rs.mu.Lock()
+	pausedUntil := rs.pausedUntil
+	rs.mu.Unlock()
+	delay := time.Until(pausedUntil)
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, has := ctx.Deadline(); has && deadline.Before(pausedUntil) {
+		return errors.New("request will be cancelled before the end of the throttling")
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request is cancelled or timed out: %w", context.Cause(ctx))
+	case <-rs.stopCh:
+		return experr.NewShutdownErr(errors.New("exporter is throttled"))
+	case <-timer.C:
+		return nil
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/experr"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
@@ -82,7 +84,7 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	sink := requesttest.NewSink()
 	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
 	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
-	retry := fmt.Errorf("wrappe error: %w", NewThrottleRetry(errors.New("throttle error"), 100*time.Millisecond))
+	retry := fmt.Errorf("wrappe error: %w", consumererror.NewThrottle(errors.New("throttle error"), 100*time.Millisecond))
 	start := time.Now()
 	sink.SetExportErr(retry)
 	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
@@ -93,6 +95,52 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	require.NoError(t, rs.Shutdown(context.Background()))
 }
 
+func TestRetrySenderThrottlePausesAllRequests(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	var mu sync.Mutex
+	var sent []time.Time
+	throttled := false
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(func(context.Context, request.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, time.Now())
+		if !throttled {
+			throttled = true
+			return consumererror.NewThrottle(errors.New("throttle error"), 200*time.Millisecond)
+		}
+		return nil
+	}))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	start := time.Now()
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	// Another request sent while the exporter is throttled waits for the end of the pause.
+	rs.pause(200 * time.Millisecond)
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1}))
+	require.Len(t, sent, 3)
+	assert.LessOrEqual(t, 200*time.Millisecond, sent[1].Sub(start))
+	assert.LessOrEqual(t, 200*time.Millisecond, sent[2].Sub(sent[1]))
+
+	// A request that cannot wait for the end of the pause fails immediately.
+	rs.pause(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.EqualError(t, rs.Send(ctx, &requesttest.FakeRequest{Items: 1}), "request will be cancelled before the end of the throttling")
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestRetrySenderThrottleShutdown(t *testing.T) {
+	rs := newRetrySender(configretry.NewDefaultBackOffConfig(), exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(func(context.Context, request.Request) error { return nil }))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	rs.pause(time.Hour)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, rs.Shutdown(context.Background()))
+	}()
+	err := rs.Send(context.Background(), &requesttest.FakeRequest{Items: 1})
+	assert.True(t, experr.IsShutdownErr(err))
+}
+
 func TestRetrySenderWithContextTimeout(t *testing.T) {
 	const testTimeout = 10 * time.Second
 	rCfg := configretry.NewDefaultBackOffConfig()
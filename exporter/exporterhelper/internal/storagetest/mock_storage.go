@@ -92,6 +92,22 @@ func (m *MockStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 	return nil
 }
 
+// Walk implements storage.Walker.
+func (m *MockStorageClient) Walk(ctx context.Context, fn storage.WalkFunc) error {
+	var ops []*storage.Operation
+	var err error
+	m.st.Range(func(key, value any) bool {
+		var keyOps []*storage.Operation
+		keyOps, err = fn(key.(string), value.([]byte))
+		ops = append(ops, keyOps...)
+		return err == nil
+	})
+	if err != nil && !errors.Is(err, storage.SkipAll) {
+		return err
+	}
+	return m.Batch(ctx, ops...)
+}
+
 func (m *MockStorageClient) IsClosed() bool {
 	return m.closed.Load()
 }
@@ -25,6 +25,15 @@ type QueueBatchConfig = queuebatch.Config
 // BatchConfig defines a configuration for batching requests based on a timeout and a minimum number of items.
 type BatchConfig = queuebatch.BatchConfig
 
+// PriorityConfig defines the priority lanes used to order the requests waiting in the queue.
+type PriorityConfig = queuebatch.PriorityConfig
+
+// LaneConfig defines how the requests are matched to a priority lane.
+type LaneConfig = queuebatch.LaneConfig
+
+// AdaptiveConcurrencyConfig defines the configuration to adapt the number of concurrent exports of the queue.
+type AdaptiveConcurrencyConfig = queuebatch.AdaptiveConcurrencyConfig
+
 // QueueBatchEncoding defines the encoding to be used if persistent queue is configured.
 // Duplicate definition with queuebatch.Encoding since aliasing generics is not supported by default.
 type QueueBatchEncoding[T any] interface {
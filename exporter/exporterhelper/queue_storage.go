@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queue"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// QueueStorageMetadata describes the content of a persistent queue, or of a dead letter storage.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type QueueStorageMetadata = queue.StoredMetadata
+
+// QueueStorageReader gives access to the content of a persistent queue, or of a dead letter storage,
+// while the exporter owning it is not running. The items are encoded using the pdata/xpdata/request format.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+type QueueStorageReader = queue.StorageReader
+
+// NewQueueStorageReader loads the queue metadata from the given storage client.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func NewQueueStorageReader(ctx context.Context, client storage.Client) (*QueueStorageReader, error) {
+	return queue.NewStorageReader(ctx, client)
+}
+
+// QueueStorageName returns the name of the storage client used by the exporter persistent queue for the given signal.
+// If deadLetter is true, it returns the name of the storage client used by the dead letter storage.
+// Experimental: This API is at the early stage of development and may change without backward compatibility
+// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved.
+func QueueStorageName(signal pipeline.Signal, deadLetter bool) string {
+	if deadLetter {
+		return internal.DeadLetterStorageName(signal)
+	}
+	return signal.String()
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// RateLimiterConfig defines configuration for the client-side rate limiter of the exporter.
+type RateLimiterConfig = internal.RateLimiterConfig
+
+// NewDefaultRateLimiterConfig returns the default config for RateLimiterConfig.
+// By default, the rate is measured in items and the requests wait when the rate is exceeded. The rate must be set.
+var NewDefaultRateLimiterConfig = internal.NewDefaultRateLimiterConfig
+
+// WithRateLimiter enables the client-side rate limiter, which caps the rate at which the data is sent to the
+// destination using a token bucket, optionally partitioned by client metadata keys.
+// The default is to not limit the rate.
+func WithRateLimiter(config configoptional.Optional[RateLimiterConfig]) Option {
+	return internal.WithRateLimiter(config)
+}
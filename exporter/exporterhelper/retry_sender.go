@@ -6,10 +6,12 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"time"
 
-	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 // NewThrottleRetry creates a new throttle retry error.
+//
+// Deprecated: [v0.160.0] Use consumererror.NewThrottle instead.
 func NewThrottleRetry(err error, delay time.Duration) error {
-	return internal.NewThrottleRetry(err, delay)
+	return consumererror.NewThrottle(err, delay)
 }
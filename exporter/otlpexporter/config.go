@@ -18,10 +18,13 @@ import (
 
 // Config defines configuration for OTLP exporter.
 type Config struct {
-	TimeoutConfig exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	QueueConfig   configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
-	RetryConfig   configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
-	ClientConfig  configgrpc.ClientConfig                                  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	TimeoutConfig        exporterhelper.TimeoutConfig                                 `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig          configoptional.Optional[exporterhelper.QueueBatchConfig]     `mapstructure:"sending_queue"`
+	RetryConfig          configretry.BackOffConfig                                    `mapstructure:"retry_on_failure"`
+	DeadLetterConfig     configoptional.Optional[exporterhelper.DeadLetterConfig]     `mapstructure:"dead_letter"`
+	CircuitBreakerConfig configoptional.Optional[exporterhelper.CircuitBreakerConfig] `mapstructure:"circuit_breaker"`
+	RateLimiterConfig    configoptional.Optional[exporterhelper.RateLimiterConfig]    `mapstructure:"rate_limiter"`
+	ClientConfig         configgrpc.ClientConfig                                      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
 	// prevent unkeyed literal initialization
 	_ struct{}
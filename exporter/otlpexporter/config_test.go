@@ -50,6 +50,22 @@ func TestUnmarshalConfig(t *testing.T) {
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
 			},
+			DeadLetterConfig: configoptional.Some(exporterhelper.DeadLetterConfig{
+				StorageID: component.MustNewIDWithName("file_storage", "dlq"),
+				QueueSize: 500,
+			}),
+			CircuitBreakerConfig: configoptional.Some(exporterhelper.CircuitBreakerConfig{
+				ConsecutiveFailures: 10,
+				MinRequests:         20,
+				Window:              time.Minute,
+				OpenDuration:        time.Minute,
+				HalfOpenRequests:    1,
+			}),
+			RateLimiterConfig: configoptional.Some(exporterhelper.RateLimiterConfig{
+				Sizer:        exporterhelper.RequestSizerTypeBytes,
+				Rate:         1000000,
+				BlockOnLimit: true,
+			}),
 			QueueConfig: configoptional.Some(exporterhelper.QueueBatchConfig{
 				Sizer:        exporterhelper.RequestSizerTypeItems,
 				NumConsumers: 2,
@@ -60,6 +76,11 @@ func TestUnmarshalConfig(t *testing.T) {
 					MinSize:      1000,
 					MaxSize:      10000,
 				}),
+				Priority: configoptional.Default(exporterhelper.PriorityConfig{StarvationLimit: 10}),
+				AdaptiveConcurrency: configoptional.Default(exporterhelper.AdaptiveConcurrencyConfig{
+					MinConsumers:  1,
+					DecreaseRatio: 0.5,
+				}),
 			}),
 			ClientConfig: configgrpc.ClientConfig{
 				Headers: configopaque.MapList{
@@ -99,7 +120,10 @@ func TestUnmarshalDefaultBatchConfig(t *testing.T) {
 			TimeoutConfig: exporterhelper.TimeoutConfig{
 				Timeout: 10 * time.Second,
 			},
-			RetryConfig: configretry.NewDefaultBackOffConfig(),
+			RetryConfig:          configretry.NewDefaultBackOffConfig(),
+			DeadLetterConfig:     configoptional.Default(exporterhelper.NewDefaultDeadLetterConfig()),
+			CircuitBreakerConfig: configoptional.Default(exporterhelper.NewDefaultCircuitBreakerConfig()),
+			RateLimiterConfig:    configoptional.Default(exporterhelper.NewDefaultRateLimiterConfig()),
 			QueueConfig: configoptional.Some(exporterhelper.QueueBatchConfig{
 				Sizer:        exporterhelper.RequestSizerTypeRequests,
 				QueueSize:    1000,
@@ -109,6 +133,11 @@ func TestUnmarshalDefaultBatchConfig(t *testing.T) {
 					Sizer:        exporterhelper.RequestSizerTypeItems,
 					MinSize:      8192,
 				}),
+				Priority: configoptional.Default(exporterhelper.PriorityConfig{StarvationLimit: 10}),
+				AdaptiveConcurrency: configoptional.Default(exporterhelper.AdaptiveConcurrencyConfig{
+					MinConsumers:  1,
+					DecreaseRatio: 0.5,
+				}),
 			}),
 			ClientConfig: configgrpc.ClientConfig{
 				Endpoint:        "1.2.3.4:1234",
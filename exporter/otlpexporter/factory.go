@@ -47,10 +47,13 @@ func createDefaultConfig() component.Config {
 	clientCfg.Keepalive = configoptional.None[configgrpc.KeepaliveClientConfig]()
 
 	return &Config{
-		TimeoutConfig: exporterhelper.NewDefaultTimeoutConfig(),
-		RetryConfig:   configretry.NewDefaultBackOffConfig(),
-		QueueConfig:   configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
-		ClientConfig:  clientCfg,
+		TimeoutConfig:        exporterhelper.NewDefaultTimeoutConfig(),
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		QueueConfig:          configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		DeadLetterConfig:     configoptional.Default(exporterhelper.NewDefaultDeadLetterConfig()),
+		CircuitBreakerConfig: configoptional.Default(exporterhelper.NewDefaultCircuitBreakerConfig()),
+		RateLimiterConfig:    configoptional.Default(exporterhelper.NewDefaultRateLimiterConfig()),
+		ClientConfig:         clientCfg,
 	}
 }
 
@@ -83,6 +86,9 @@ func createTraces(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithAttrs(endpointAttributes(oCfg)...),
@@ -102,6 +108,9 @@ func createMetrics(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithAttrs(endpointAttributes(oCfg)...),
@@ -121,6 +130,9 @@ func createLogs(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithAttrs(endpointAttributes(oCfg)...),
@@ -140,6 +152,9 @@ func createProfilesExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutConfig),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithAttrs(endpointAttributes(oCfg)...),
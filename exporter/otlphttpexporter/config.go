@@ -46,9 +46,12 @@ func (e *EncodingType) UnmarshalText(text []byte) error {
 
 // Config defines configuration for OTLP/HTTP exporter.
 type Config struct {
-	ClientConfig confighttp.ClientConfig                                  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	QueueConfig  configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
-	RetryConfig  configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	ClientConfig         confighttp.ClientConfig                                      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig          configoptional.Optional[exporterhelper.QueueBatchConfig]     `mapstructure:"sending_queue"`
+	RetryConfig          configretry.BackOffConfig                                    `mapstructure:"retry_on_failure"`
+	DeadLetterConfig     configoptional.Optional[exporterhelper.DeadLetterConfig]     `mapstructure:"dead_letter"`
+	CircuitBreakerConfig configoptional.Optional[exporterhelper.CircuitBreakerConfig] `mapstructure:"circuit_breaker"`
+	RateLimiterConfig    configoptional.Optional[exporterhelper.RateLimiterConfig]    `mapstructure:"rate_limiter"`
 
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
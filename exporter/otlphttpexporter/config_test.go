@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
@@ -52,6 +53,22 @@ func TestUnmarshalConfig(t *testing.T) {
 				MaxInterval:         1 * time.Minute,
 				MaxElapsedTime:      10 * time.Minute,
 			},
+			DeadLetterConfig: configoptional.Some(exporterhelper.DeadLetterConfig{
+				StorageID: component.MustNewIDWithName("file_storage", "dlq"),
+				QueueSize: 500,
+			}),
+			CircuitBreakerConfig: configoptional.Some(exporterhelper.CircuitBreakerConfig{
+				ConsecutiveFailures: 10,
+				MinRequests:         20,
+				Window:              time.Minute,
+				OpenDuration:        time.Minute,
+				HalfOpenRequests:    1,
+			}),
+			RateLimiterConfig: configoptional.Some(exporterhelper.RateLimiterConfig{
+				Sizer:        exporterhelper.RequestSizerTypeBytes,
+				Rate:         1000000,
+				BlockOnLimit: true,
+			}),
 			QueueConfig: configoptional.Some(exporterhelper.QueueBatchConfig{
 				Sizer:        exporterhelper.RequestSizerTypeRequests,
 				NumConsumers: 2,
@@ -61,6 +78,11 @@ func TestUnmarshalConfig(t *testing.T) {
 					FlushTimeout: 200 * time.Millisecond,
 					MinSize:      8192,
 				}),
+				Priority: configoptional.Default(exporterhelper.PriorityConfig{StarvationLimit: 10}),
+				AdaptiveConcurrency: configoptional.Default(exporterhelper.AdaptiveConcurrencyConfig{
+					MinConsumers:  1,
+					DecreaseRatio: 0.5,
+				}),
 			}),
 			Encoding: EncodingProto,
 			ClientConfig: confighttp.ClientConfig{
@@ -49,10 +49,13 @@ func createDefaultConfig() component.Config {
 	clientConfig.WriteBufferSize = 512 * 1024
 
 	return &Config{
-		RetryConfig:  configretry.NewDefaultBackOffConfig(),
-		QueueConfig:  configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
-		Encoding:     EncodingProto,
-		ClientConfig: clientConfig,
+		RetryConfig:          configretry.NewDefaultBackOffConfig(),
+		QueueConfig:          configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		DeadLetterConfig:     configoptional.Default(exporterhelper.NewDefaultDeadLetterConfig()),
+		CircuitBreakerConfig: configoptional.Default(exporterhelper.NewDefaultCircuitBreakerConfig()),
+		RateLimiterConfig:    configoptional.Default(exporterhelper.NewDefaultRateLimiterConfig()),
+		Encoding:             EncodingProto,
+		ClientConfig:         clientConfig,
 	}
 }
 
@@ -117,6 +120,9 @@ func createTraces(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithAttrs(endpointAttributes(endpointURL)...),
 	)
 }
@@ -146,6 +152,9 @@ func createMetrics(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithAttrs(endpointAttributes(endpointURL)...),
 	)
 }
@@ -175,6 +184,9 @@ func createLogs(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithAttrs(endpointAttributes(endpointURL)...),
 	)
 }
@@ -204,6 +216,9 @@ func createProfiles(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetryConfig),
 		exporterhelper.WithQueue(oCfg.QueueConfig),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterConfig),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerConfig),
+		exporterhelper.WithRateLimiter(oCfg.RateLimiterConfig),
 		exporterhelper.WithAttrs(endpointAttributes(endpointURL)...),
 	)
 }
@@ -22,7 +22,6 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/statusutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -251,10 +250,10 @@ func (e *baseExporter) export(ctx context.Context, requestURL string, request []
 		//
 		// First try to parse delay-seconds, since that is what the receiver will send.
 		if seconds, err := strconv.Atoi(values[0]); err == nil {
-			return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(seconds)*time.Second)
+			return consumererror.NewThrottle(formattedErr, time.Duration(seconds)*time.Second)
 		}
 		if date, err := time.Parse(time.RFC1123, values[0]); err == nil {
-			return exporterhelper.NewThrottleRetry(formattedErr, time.Until(date))
+			return consumererror.NewThrottle(formattedErr, time.Until(date))
 		}
 	}
 	return formattedErr
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the health extension.
+type Config struct {
+	ServerConfig confighttp.ServerConfig `mapstructure:",squash"`
+
+	// ComponentHealth are the rules deciding whether a component is ready from its status.
+	ComponentHealth ComponentHealthConfig `mapstructure:"component_health"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ComponentHealthConfig has the rules deciding whether a component is ready from its status. A component is
+// ready once it is started and until it is stopped, unless it reports an error the rules include.
+type ComponentHealthConfig struct {
+	// IncludePermanentErrors indicates whether a component reporting a permanent error is not ready.
+	// (default = true)
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+	// IncludeRecoverableErrors indicates whether a component reporting a recoverable error is not ready once
+	// the error is older than RecoveryDuration.
+	// (default = false)
+	IncludeRecoverableErrors bool `mapstructure:"include_recoverable_errors"`
+	// RecoveryDuration is how long a component reporting a recoverable error has to recover before it is not
+	// ready, when IncludeRecoverableErrors is set.
+	// (default = 30s)
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ServerConfig.NetAddr.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"health\" extension")
+	}
+	if cfg.ComponentHealth.RecoveryDuration < 0 {
+		return errors.New("\"recovery_duration\" must not be negative")
+	}
+	return nil
+}
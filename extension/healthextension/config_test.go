@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	require.EqualError(t, (&Config{}).Validate(), `"endpoint" is required when using the "health" extension`)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ComponentHealth.RecoveryDuration = -time.Second
+	require.EqualError(t, cfg.Validate(), `"recovery_duration" must not be negative`)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	expectedServerConfig := confighttp.NewDefaultServerConfig()
+	expectedServerConfig.NetAddr.Endpoint = "localhost:13134"
+
+	assert.Equal(t, &Config{
+		ServerConfig: expectedServerConfig,
+		ComponentHealth: ComponentHealthConfig{
+			IncludeRecoverableErrors: true,
+			RecoveryDuration:         time.Minute,
+		},
+	}, cfg)
+}
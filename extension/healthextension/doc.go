@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package healthextension implements an extension that exposes the liveness
+// and the readiness of the Collector, aggregated from the status of its
+// components, over HTTP.
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
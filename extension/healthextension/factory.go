@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint         = "localhost:13133"
+	defaultRecoveryDuration = 30 * time.Second
+)
+
+// NewFactory creates a factory for the health extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	serverConfig := confighttp.NewDefaultServerConfig()
+	serverConfig.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: serverConfig,
+		ComponentHealth: ComponentHealthConfig{
+			IncludePermanentErrors: true,
+			RecoveryDuration:       defaultRecoveryDuration,
+		},
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newServer(cfg.(*Config), set.TelemetrySettings), nil
+}
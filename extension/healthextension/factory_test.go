@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	expectedServerConfig := confighttp.NewDefaultServerConfig()
+	expectedServerConfig.NetAddr.Endpoint = "localhost:13133"
+
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ServerConfig: expectedServerConfig,
+		ComponentHealth: ComponentHealthConfig{
+			IncludePermanentErrors: true,
+			RecoveryDuration:       30 * time.Second,
+		},
+	}, cfg)
+
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+	ext, err := create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	livezPath  = "/livez"
+	readyzPath = "/readyz"
+)
+
+type healthExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+	now       func() time.Time
+
+	mu sync.Mutex
+	// pipelinesReady is whether the pipelines are built and their receivers started, as notified through the
+	// extensioncapabilities.PipelineWatcher interface.
+	pipelinesReady bool
+	// events are the last status events of the component instances that are not stopped.
+	events map[componentstatus.InstanceID]*componentstatus.Event
+}
+
+var (
+	_ extensioncapabilities.PipelineWatcher = (*healthExtension)(nil)
+	_ componentstatus.Watcher               = (*healthExtension)(nil)
+)
+
+func (hx *healthExtension) Start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodGet+" "+livezPath, hx.handleLivez)
+	mux.HandleFunc(http.MethodGet+" "+readyzPath, hx.handleReadyz)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := hx.config.ServerConfig.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	hx.telemetry.Logger.Info("Starting health extension", zap.Any("config", hx.config))
+	hx.server, err = hx.config.ServerConfig.ToServer(ctx, host.GetExtensions(), hx.telemetry, mux)
+	if err != nil {
+		return err
+	}
+	hx.stopCh = make(chan struct{})
+	go func() {
+		defer close(hx.stopCh)
+
+		if errHTTP := hx.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (hx *healthExtension) Shutdown(context.Context) error {
+	if hx.server == nil {
+		return nil
+	}
+	err := hx.server.Close()
+	if hx.stopCh != nil {
+		<-hx.stopCh
+	}
+	return err
+}
+
+// Ready implements extensioncapabilities.PipelineWatcher.
+func (hx *healthExtension) Ready() error {
+	hx.mu.Lock()
+	defer hx.mu.Unlock()
+	hx.pipelinesReady = true
+	return nil
+}
+
+// NotReady implements extensioncapabilities.PipelineWatcher.
+func (hx *healthExtension) NotReady() error {
+	hx.mu.Lock()
+	defer hx.mu.Unlock()
+	hx.pipelinesReady = false
+	return nil
+}
+
+// ComponentStatusChanged implements componentstatus.Watcher.
+func (hx *healthExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	hx.mu.Lock()
+	defer hx.mu.Unlock()
+	// The stopped components are forgotten, e.g. the components removed by a partial reload of the
+	// configuration.
+	if event.Status() == componentstatus.StatusStopped {
+		delete(hx.events, *source)
+		return
+	}
+	hx.events[*source] = event
+}
+
+// componentHealth is the health of a component instance.
+type componentHealth struct {
+	Status    string    `json:"status"`
+	Ready     bool      `json:"ready"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// groupHealth is the health of a group of component instances, a pipeline or the extensions.
+type groupHealth struct {
+	// Status is the most severe status of the components.
+	Status     string                      `json:"status"`
+	Ready      bool                        `json:"ready"`
+	Components map[string]*componentHealth `json:"components"`
+
+	severity int
+}
+
+func newGroupHealth() *groupHealth {
+	return &groupHealth{
+		Status:     componentstatus.StatusNone.String(),
+		Ready:      true,
+		Components: map[string]*componentHealth{},
+	}
+}
+
+func (g *groupHealth) add(key string, status componentstatus.Status, c *componentHealth) {
+	g.Components[key] = c
+	g.Ready = g.Ready && c.Ready
+	if severity(status) > g.severity {
+		g.severity = severity(status)
+		g.Status = c.Status
+	}
+}
+
+// health is the health of the Collector.
+type health struct {
+	// Alive is whether no component reported a fatal error.
+	Alive bool `json:"alive"`
+	// Ready is whether the pipelines are ready and all the components are ready.
+	Ready bool `json:"ready"`
+	// Status is the most severe status of the components.
+	Status         string                  `json:"status"`
+	PipelinesReady bool                    `json:"pipelines_ready"`
+	Pipelines      map[string]*groupHealth `json:"pipelines"`
+	Extensions     *groupHealth            `json:"extensions,omitempty"`
+}
+
+// health aggregates the last status events of the components into the health of the Collector.
+func (hx *healthExtension) health() *health {
+	hx.mu.Lock()
+	defer hx.mu.Unlock()
+
+	now := hx.now()
+	h := &health{
+		Alive:          true,
+		Status:         componentstatus.StatusNone.String(),
+		PipelinesReady: hx.pipelinesReady,
+		Pipelines:      map[string]*groupHealth{},
+		Extensions:     newGroupHealth(),
+	}
+	maxSeverity := 0
+	for source, event := range hx.events {
+		c := &componentHealth{
+			Status:    event.Status().String(),
+			Ready:     hx.ready(event, now),
+			Timestamp: event.Timestamp(),
+		}
+		if event.Err() != nil {
+			c.Error = event.Err().Error()
+		}
+		key := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+
+		if source.Kind() == component.KindExtension {
+			h.Extensions.add(key, event.Status(), c)
+		}
+		source.AllPipelineIDs(func(id pipeline.ID) bool {
+			g, ok := h.Pipelines[id.String()]
+			if !ok {
+				g = newGroupHealth()
+				h.Pipelines[id.String()] = g
+			}
+			g.add(key, event.Status(), c)
+			return true
+		})
+
+		h.Alive = h.Alive && event.Status() != componentstatus.StatusFatalError
+		if severity(event.Status()) > maxSeverity {
+			maxSeverity = severity(event.Status())
+			h.Status = c.Status
+		}
+	}
+
+	h.Ready = h.PipelinesReady && h.Extensions.Ready
+	for _, g := range h.Pipelines {
+		h.Ready = h.Ready && g.Ready
+	}
+	return h
+}
+
+// ready returns whether a component whose last status event is the given event is ready, following the
+// component health rules.
+func (hx *healthExtension) ready(event *componentstatus.Event, now time.Time) bool {
+	switch event.Status() {
+	case componentstatus.StatusOK:
+		return true
+	case componentstatus.StatusRecoverableError:
+		return !hx.config.ComponentHealth.IncludeRecoverableErrors ||
+			now.Sub(event.Timestamp()) < hx.config.ComponentHealth.RecoveryDuration
+	case componentstatus.StatusPermanentError:
+		return !hx.config.ComponentHealth.IncludePermanentErrors
+	}
+	return false
+}
+
+// severity orders the statuses, the most severe status of a group of components is its status.
+func severity(status componentstatus.Status) int {
+	switch status {
+	case componentstatus.StatusOK:
+		return 1
+	case componentstatus.StatusStarting:
+		return 2
+	case componentstatus.StatusRecoverableError:
+		return 3
+	case componentstatus.StatusStopping:
+		return 4
+	case componentstatus.StatusStopped:
+		return 5
+	case componentstatus.StatusPermanentError:
+		return 6
+	case componentstatus.StatusFatalError:
+		return 7
+	}
+	return 0
+}
+
+func (hx *healthExtension) handleLivez(w http.ResponseWriter, _ *http.Request) {
+	h := hx.health()
+	writeHealth(w, h, h.Alive)
+}
+
+func (hx *healthExtension) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	h := hx.health()
+	name := r.URL.Query().Get("pipeline")
+	if name == "" {
+		writeHealth(w, h, h.Ready)
+		return
+	}
+
+	g, ok := h.Pipelines[name]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown pipeline %q", name), http.StatusNotFound)
+		return
+	}
+	h.Ready = h.PipelinesReady && g.Ready
+	h.Status = g.Status
+	h.Pipelines = map[string]*groupHealth{name: g}
+	h.Extensions = nil
+	writeHealth(w, h, h.Ready)
+}
+
+// writeHealth writes the health as JSON, with the status OK if the probe succeeds and Service Unavailable
+// otherwise.
+func writeHealth(w http.ResponseWriter, h *health, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(h)
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:    config,
+		telemetry: telemetry,
+		now:       time.Now,
+		events:    map[componentstatus.InstanceID]*componentstatus.Event{},
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	tracesID  = pipeline.NewID(pipeline.SignalTraces)
+	metricsID = pipeline.NewID(pipeline.SignalMetrics)
+
+	otlpReceiverID = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, tracesID, metricsID)
+	batchTracesID  = componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor, tracesID)
+	batchMetricsID = componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor, metricsID)
+	debugID        = componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter, tracesID, metricsID)
+	healthID       = componentstatus.NewInstanceID(component.MustNewID("health"), component.KindExtension)
+)
+
+// newReadyExtension returns a health extension notified that the pipelines and all their components are ready.
+func newReadyExtension(t *testing.T) *healthExtension {
+	hx := newServer(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	for _, id := range []*componentstatus.InstanceID{otlpReceiverID, batchTracesID, batchMetricsID, debugID, healthID} {
+		hx.ComponentStatusChanged(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+		hx.ComponentStatusChanged(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	require.NoError(t, hx.Ready())
+	return hx
+}
+
+func probe(t *testing.T, handler http.HandlerFunc, target string) (int, *health) {
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+	if rec.Code == http.StatusNotFound {
+		return rec.Code, nil
+	}
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	h := &health{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), h))
+	return rec.Code, h
+}
+
+func TestHealthExtensionUsage(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig.NetAddr.Endpoint = addr
+
+	hx := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, hx.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hx.Shutdown(context.Background())) })
+
+	get := func(path string) int {
+		resp, err := http.Get("http://" + addr + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// The collector is alive but not ready until the pipelines are.
+	hx.ComponentStatusChanged(otlpReceiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	assert.Equal(t, http.StatusOK, get(livezPath))
+	assert.Equal(t, http.StatusServiceUnavailable, get(readyzPath))
+
+	require.NoError(t, hx.Ready())
+	assert.Equal(t, http.StatusOK, get(readyzPath))
+
+	require.NoError(t, hx.NotReady())
+	assert.Equal(t, http.StatusServiceUnavailable, get(readyzPath))
+}
+
+func TestHealthReady(t *testing.T) {
+	hx := newReadyExtension(t)
+
+	code, h := probe(t, hx.handleReadyz, readyzPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, h.Alive)
+	assert.True(t, h.Ready)
+	assert.True(t, h.PipelinesReady)
+	assert.Equal(t, "StatusOK", h.Status)
+	require.Len(t, h.Pipelines, 2)
+	assert.Equal(t, []string{"exporter:debug", "processor:batch", "receiver:otlp"}, keys(h.Pipelines["traces"].Components))
+	assert.Equal(t, []string{"extension:health"}, keys(h.Extensions.Components))
+}
+
+func TestHealthComponentRules(t *testing.T) {
+	errExport := errors.New("export failed")
+	tests := []struct {
+		name      string
+		configure func(cfg *ComponentHealthConfig)
+		event     *componentstatus.Event
+		elapsed   time.Duration
+		ready     bool
+	}{
+		{
+			name:  "starting",
+			event: componentstatus.NewEvent(componentstatus.StatusStarting),
+		},
+		{
+			name:    "recoverable_error_ignored",
+			event:   componentstatus.NewRecoverableErrorEvent(errExport),
+			elapsed: time.Hour,
+			ready:   true,
+		},
+		{
+			name:      "recoverable_error_recovering",
+			configure: func(cfg *ComponentHealthConfig) { cfg.IncludeRecoverableErrors = true },
+			event:     componentstatus.NewRecoverableErrorEvent(errExport),
+			elapsed:   10 * time.Second,
+			ready:     true,
+		},
+		{
+			name:      "recoverable_error_not_recovered",
+			configure: func(cfg *ComponentHealthConfig) { cfg.IncludeRecoverableErrors = true },
+			event:     componentstatus.NewRecoverableErrorEvent(errExport),
+			elapsed:   time.Minute,
+		},
+		{
+			name:  "permanent_error",
+			event: componentstatus.NewPermanentErrorEvent(errExport),
+		},
+		{
+			name:      "permanent_error_ignored",
+			configure: func(cfg *ComponentHealthConfig) { cfg.IncludePermanentErrors = false },
+			event:     componentstatus.NewPermanentErrorEvent(errExport),
+			ready:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hx := newReadyExtension(t)
+			if tt.configure != nil {
+				tt.configure(&hx.config.ComponentHealth)
+			}
+			hx.now = func() time.Time { return tt.event.Timestamp().Add(tt.elapsed) }
+			hx.ComponentStatusChanged(batchTracesID, tt.event)
+
+			code, h := probe(t, hx.handleReadyz, readyzPath)
+			assert.Equal(t, tt.ready, h.Ready)
+			assert.Equal(t, tt.ready, code == http.StatusOK)
+			assert.Equal(t, tt.event.Status().String(), h.Status)
+			assert.Equal(t, tt.ready, h.Pipelines["traces"].Ready)
+			assert.True(t, h.Pipelines["metrics"].Ready)
+			if tt.event.Err() != nil {
+				assert.Equal(t, errExport.Error(), h.Pipelines["traces"].Components["processor:batch"].Error)
+			}
+
+			// Only the traces pipeline is affected.
+			code, h = probe(t, hx.handleReadyz, readyzPath+"?pipeline=metrics")
+			assert.Equal(t, http.StatusOK, code)
+			assert.True(t, h.Ready)
+			assert.Equal(t, []string{"metrics"}, keys(h.Pipelines))
+			assert.Nil(t, h.Extensions)
+
+			code, _ = probe(t, hx.handleLivez, livezPath)
+			assert.Equal(t, http.StatusOK, code)
+		})
+	}
+}
+
+func TestHealthFatalError(t *testing.T) {
+	hx := newReadyExtension(t)
+	hx.ComponentStatusChanged(debugID, componentstatus.NewFatalErrorEvent(errors.New("listen failed")))
+
+	code, h := probe(t, hx.handleLivez, livezPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, h.Alive)
+	assert.Equal(t, "StatusFatalError", h.Status)
+
+	code, _ = probe(t, hx.handleReadyz, readyzPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHealthStoppedComponents(t *testing.T) {
+	hx := newReadyExtension(t)
+
+	// A stopped component, e.g. removed by a partial reload, is forgotten.
+	hx.ComponentStatusChanged(batchMetricsID, componentstatus.NewEvent(componentstatus.StatusStopping))
+	code, h := probe(t, hx.handleReadyz, readyzPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusStopping", h.Pipelines["metrics"].Status)
+
+	hx.ComponentStatusChanged(batchMetricsID, componentstatus.NewEvent(componentstatus.StatusStopped))
+	code, h = probe(t, hx.handleReadyz, readyzPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"exporter:debug", "receiver:otlp"}, keys(h.Pipelines["metrics"].Components))
+}
+
+func TestHealthUnknownPipeline(t *testing.T) {
+	hx := newReadyExtension(t)
+	code, _ := probe(t, hx.handleReadyz, readyzPath+"?pipeline=logs")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func keys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,18 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+// Package metadata contains the autogenerated telemetry and
+// build information for the extension/health component.
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("health")
+	ScopeName = "go.opentelemetry.io/collector/extension/healthextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the tap extension.
+type Config struct {
+	ServerConfig confighttp.ServerConfig `mapstructure:",squash"`
+
+	// DefaultInterval is the interval between two samples of a subscription not setting it.
+	// (default = 1s)
+	DefaultInterval time.Duration `mapstructure:"default_interval"`
+	// MinInterval is the minimum interval between two samples a subscription can set.
+	// (default = 100ms)
+	MinInterval time.Duration `mapstructure:"min_interval"`
+	// MaxSubscriptions is the maximum number of concurrent subscriptions.
+	// (default = 10)
+	MaxSubscriptions int `mapstructure:"max_subscriptions"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ServerConfig.NetAddr.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"tap\" extension")
+	}
+	if cfg.MinInterval <= 0 {
+		return errors.New("\"min_interval\" must be positive")
+	}
+	if cfg.DefaultInterval < cfg.MinInterval {
+		return errors.New("\"default_interval\" must not be lower than \"min_interval\"")
+	}
+	if cfg.MaxSubscriptions <= 0 {
+		return errors.New("\"max_subscriptions\" must be positive")
+	}
+	return nil
+}
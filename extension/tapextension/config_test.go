@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	require.EqualError(t, (&Config{}).Validate(), `"endpoint" is required when using the "tap" extension`)
+
+	tests := []struct {
+		name      string
+		configure func(cfg *Config)
+		expected  string
+	}{
+		{
+			name:      "min_interval",
+			configure: func(cfg *Config) { cfg.MinInterval = 0 },
+			expected:  `"min_interval" must be positive`,
+		},
+		{
+			name:      "default_interval",
+			configure: func(cfg *Config) { cfg.DefaultInterval = time.Millisecond },
+			expected:  `"default_interval" must not be lower than "min_interval"`,
+		},
+		{
+			name:      "max_subscriptions",
+			configure: func(cfg *Config) { cfg.MaxSubscriptions = 0 },
+			expected:  `"max_subscriptions" must be positive`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.configure(cfg)
+			require.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	expectedServerConfig := confighttp.NewDefaultServerConfig()
+	expectedServerConfig.NetAddr.Endpoint = "localhost:13135"
+
+	assert.Equal(t, &Config{
+		ServerConfig:     expectedServerConfig,
+		DefaultInterval:  5 * time.Second,
+		MinInterval:      time.Second,
+		MaxSubscriptions: 2,
+	}, cfg)
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package tapextension implements an extension that streams samples of the
+// data flowing through the pipelines of the Collector over HTTP and WebSocket.
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
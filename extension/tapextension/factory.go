@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/tapextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint         = "localhost:13134"
+	defaultInterval         = time.Second
+	defaultMinInterval      = 100 * time.Millisecond
+	defaultMaxSubscriptions = 10
+)
+
+// NewFactory creates a factory for the tap extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	serverConfig := confighttp.NewDefaultServerConfig()
+	serverConfig.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig:     serverConfig,
+		DefaultInterval:  defaultInterval,
+		MinInterval:      defaultMinInterval,
+		MaxSubscriptions: defaultMaxSubscriptions,
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newServer(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/tapextension/internal/metadata"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	expectedServerConfig := confighttp.NewDefaultServerConfig()
+	expectedServerConfig.NetAddr.Endpoint = "localhost:13134"
+
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ServerConfig:     expectedServerConfig,
+		DefaultInterval:  time.Second,
+		MinInterval:      100 * time.Millisecond,
+		MaxSubscriptions: 10,
+	}, cfg)
+
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+	ext, err := create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,18 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+// Package metadata contains the autogenerated telemetry and
+// build information for the extension/tap component.
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("tap")
+	ScopeName = "go.opentelemetry.io/collector/extension/tapextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
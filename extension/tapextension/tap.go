@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+const tapPath = "/tap"
+
+type tapExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+	// doneCh is closed on shutdown to end the subscriptions, whose connections are not closed by the server
+	// once upgraded to WebSocket.
+	doneCh   chan struct{}
+	doneOnce sync.Once
+
+	// tapper is the host, if it supports taps.
+	tapper        hostcapabilities.Tapper
+	subscriptions atomic.Int64
+}
+
+func (tx *tapExtension) Start(ctx context.Context, host component.Host) error {
+	tapper, ok := host.(hostcapabilities.Tapper)
+	if !ok {
+		tx.telemetry.Logger.Warn("The host does not support taps, the subscriptions will be refused")
+	}
+	tx.tapper = tapper
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodGet+" "+tapPath, tx.handleTap)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := tx.config.ServerConfig.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx.telemetry.Logger.Info("Starting tap extension", zap.Any("config", tx.config))
+	tx.server, err = tx.config.ServerConfig.ToServer(ctx, host.GetExtensions(), tx.telemetry, mux)
+	if err != nil {
+		return err
+	}
+	tx.stopCh = make(chan struct{})
+	go func() {
+		defer close(tx.stopCh)
+
+		if errHTTP := tx.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (tx *tapExtension) Shutdown(context.Context) error {
+	tx.doneOnce.Do(func() { close(tx.doneCh) })
+	if tx.server == nil {
+		return nil
+	}
+	err := tx.server.Close()
+	if tx.stopCh != nil {
+		<-tx.stopCh
+	}
+	return err
+}
+
+// subscription is the tap point requested by a client.
+type subscription struct {
+	pipelineID pipeline.ID
+	kind       component.Kind
+	id         component.ID
+	interval   time.Duration
+}
+
+// parseSubscription parses the query parameters of a request: the pipeline, the node, i.e. the kind and the
+// ID of a component, e.g. processor:batch, and optionally the interval between two samples.
+func (tx *tapExtension) parseSubscription(query url.Values) (subscription, error) {
+	sub := subscription{interval: tx.config.DefaultInterval}
+	if err := sub.pipelineID.UnmarshalText([]byte(query.Get("pipeline"))); err != nil {
+		return sub, fmt.Errorf("invalid pipeline: %w", err)
+	}
+
+	kind, id, ok := strings.Cut(query.Get("node"), ":")
+	if !ok {
+		return sub, errors.New(`invalid node: must be "<kind>:<component ID>", e.g. "processor:batch"`)
+	}
+	switch kind {
+	case "receiver":
+		sub.kind = component.KindReceiver
+	case "processor":
+		sub.kind = component.KindProcessor
+	case "exporter":
+		sub.kind = component.KindExporter
+	case "connector":
+		sub.kind = component.KindConnector
+	default:
+		return sub, fmt.Errorf("invalid node: unknown kind %q, must be receiver, processor, exporter or connector", kind)
+	}
+	if err := sub.id.UnmarshalText([]byte(id)); err != nil {
+		return sub, fmt.Errorf("invalid node: %w", err)
+	}
+
+	if interval := query.Get("interval"); interval != "" {
+		var err error
+		if sub.interval, err = time.ParseDuration(interval); err != nil {
+			return sub, fmt.Errorf("invalid interval: %w", err)
+		}
+		if sub.interval < tx.config.MinInterval {
+			return sub, fmt.Errorf("invalid interval: must not be lower than %v", tx.config.MinInterval)
+		}
+	}
+	return sub, nil
+}
+
+func (tx *tapExtension) handleTap(w http.ResponseWriter, r *http.Request) {
+	if tx.tapper == nil {
+		http.Error(w, "the host does not support taps", http.StatusServiceUnavailable)
+		return
+	}
+	sub, err := tx.parseSubscription(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if tx.subscriptions.Add(1) > int64(tx.config.MaxSubscriptions) {
+		tx.subscriptions.Add(-1)
+		http.Error(w, "too many subscriptions", http.StatusTooManyRequests)
+		return
+	}
+	defer tx.subscriptions.Add(-1)
+
+	samples, cancel, err := tx.tapper.Tap(sub.pipelineID, sub.kind, sub.id, sub.interval)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	// The tap is torn down once the client disconnects.
+	defer cancel()
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		websocket.Server{
+			Handshake: checkOrigin,
+			Handler:   func(ws *websocket.Conn) { tx.sendMessages(ws, samples) },
+		}.ServeHTTP(w, r)
+		return
+	}
+	tx.stream(w, r, samples)
+}
+
+// stream writes the samples as newline delimited JSON until the client disconnects.
+func (tx *tapExtension) stream(w http.ResponseWriter, r *http.Request, samples <-chan []byte) {
+	rc := http.NewResponseController(w)
+	// The write timeout of the server applies to the whole response.
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-tx.doneCh:
+			return
+		case sample, ok := <-samples:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(append(sample, '\n')); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendMessages sends the samples as WebSocket text messages until the client disconnects.
+func (tx *tapExtension) sendMessages(ws *websocket.Conn, samples <-chan []byte) {
+	// The deadlines of the server still apply to the hijacked connection.
+	_ = ws.SetDeadline(time.Time{})
+	// The client disconnecting is detected by reading the connection, the messages it sends are ignored.
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		_, _ = io.Copy(io.Discard, ws)
+	}()
+	defer func() {
+		_ = ws.Close()
+		<-disconnected
+	}()
+	for {
+		select {
+		case <-disconnected:
+			return
+		case <-tx.doneCh:
+			return
+		case sample, ok := <-samples:
+			if !ok {
+				return
+			}
+			if err := websocket.Message.Send(ws, string(sample)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// checkOrigin accepts the WebSocket clients not sending an origin, i.e. not browsers, and the browsers
+// loading a page from the extension, so that no other page can read the samples.
+func checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	if origin != nil && origin.Host != r.Host {
+		return fmt.Errorf("origin %q not allowed", origin.String())
+	}
+	return nil
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *tapExtension {
+	return &tapExtension{
+		config:    config,
+		telemetry: telemetry,
+		doneCh:    make(chan struct{}),
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapextension
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const sample = `{"resourceSpans":[]}`
+
+// tapHost is a host tapping the batch processor of the traces pipeline.
+type tapHost struct {
+	component.Host
+	samples  chan []byte
+	interval time.Duration
+	tapped   atomic.Int64
+}
+
+func newTapHost() *tapHost {
+	return &tapHost{Host: componenttest.NewNopHost(), samples: make(chan []byte, 1)}
+}
+
+func (h *tapHost) Tap(pipelineID pipeline.ID, kind component.Kind, id component.ID, interval time.Duration) (<-chan []byte, func(), error) {
+	if pipelineID != pipeline.NewID(pipeline.SignalTraces) || kind != component.KindProcessor || id != component.MustNewID("batch") {
+		return nil, nil, errors.New("not found")
+	}
+	h.interval = interval
+	h.tapped.Add(1)
+	return h.samples, func() { h.tapped.Add(-1) }, nil
+}
+
+func startTapExtension(t *testing.T, host component.Host) string {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig.NetAddr.Endpoint = addr
+
+	tx := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, tx.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, tx.Shutdown(context.Background())) })
+	return addr
+}
+
+func TestShutdownTwice(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	tx := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, tx.Start(context.Background(), newTapHost()))
+	require.NoError(t, tx.Shutdown(context.Background()))
+	// A second Shutdown is a no-op.
+	require.NoError(t, tx.Shutdown(context.Background()))
+}
+
+func TestTapStream(t *testing.T) {
+	host := newTapHost()
+	addr := startTapExtension(t, host)
+
+	resp, err := http.Get("http://" + addr + tapPath + "?pipeline=traces&node=processor:batch&interval=2s")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Equal(t, 2*time.Second, host.interval)
+
+	host.samples <- []byte(sample)
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, sample+"\n", line)
+
+	// The tap is torn down once the client disconnects.
+	require.NoError(t, resp.Body.Close())
+	assert.Eventually(t, func() bool { return host.tapped.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTapStreamNodeRemoved(t *testing.T) {
+	host := newTapHost()
+	addr := startTapExtension(t, host)
+
+	resp, err := http.Get("http://" + addr + tapPath + "?pipeline=traces&node=processor:batch")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The response ends once the samples channel is closed.
+	close(host.samples)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Eventually(t, func() bool { return host.tapped.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTapWebSocket(t *testing.T) {
+	host := newTapHost()
+	addr := startTapExtension(t, host)
+
+	ws, err := websocket.Dial("ws://"+addr+tapPath+"?pipeline=traces&node=processor:batch", "", "http://"+addr)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, host.interval)
+
+	host.samples <- []byte(sample)
+	var msg string
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+	assert.Equal(t, sample, msg)
+
+	// The tap is torn down once the client disconnects.
+	require.NoError(t, ws.Close())
+	assert.Eventually(t, func() bool { return host.tapped.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTapWebSocketOrigin(t *testing.T) {
+	host := newTapHost()
+	addr := startTapExtension(t, host)
+
+	_, err := websocket.Dial("ws://"+addr+tapPath+"?pipeline=traces&node=processor:batch", "", "http://example.com")
+	require.Error(t, err)
+	assert.Eventually(t, func() bool { return host.tapped.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTapErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		noTaps        bool
+		target        string
+		subscriptions int64
+		code          int
+		body          string
+	}{
+		{
+			name:   "host_without_taps",
+			noTaps: true,
+			target: "?pipeline=traces&node=processor:batch",
+			code:   http.StatusServiceUnavailable,
+			body:   "the host does not support taps\n",
+		},
+		{
+			name:   "invalid_pipeline",
+			target: "?pipeline=foo&node=processor:batch",
+			code:   http.StatusBadRequest,
+			body:   "invalid pipeline: in \"foo\" id: unknown pipeline signal: \"foo\"\n",
+		},
+		{
+			name:   "missing_node",
+			target: "?pipeline=traces",
+			code:   http.StatusBadRequest,
+			body:   "invalid node: must be \"<kind>:<component ID>\", e.g. \"processor:batch\"\n",
+		},
+		{
+			name:   "invalid_kind",
+			target: "?pipeline=traces&node=extension:zpages",
+			code:   http.StatusBadRequest,
+			body:   "invalid node: unknown kind \"extension\", must be receiver, processor, exporter or connector\n",
+		},
+		{
+			name:   "invalid_interval",
+			target: "?pipeline=traces&node=processor:batch&interval=1",
+			code:   http.StatusBadRequest,
+			body:   "invalid interval: time: missing unit in duration \"1\"\n",
+		},
+		{
+			name:   "interval_too_low",
+			target: "?pipeline=traces&node=processor:batch&interval=10ms",
+			code:   http.StatusBadRequest,
+			body:   "invalid interval: must not be lower than 100ms\n",
+		},
+		{
+			name:          "too_many_subscriptions",
+			target:        "?pipeline=traces&node=processor:batch",
+			subscriptions: 10,
+			code:          http.StatusTooManyRequests,
+			body:          "too many subscriptions\n",
+		},
+		{
+			name:   "unknown_node",
+			target: "?pipeline=traces&node=exporter:debug",
+			code:   http.StatusNotFound,
+			body:   "not found\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newServer(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+			if !tt.noTaps {
+				tx.tapper = newTapHost()
+			}
+			tx.subscriptions.Store(tt.subscriptions)
+
+			rec := httptest.NewRecorder()
+			tx.handleTap(rec, httptest.NewRequest(http.MethodGet, tapPath+tt.target, http.NoBody))
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Equal(t, tt.body, rec.Body.String())
+			assert.Equal(t, tt.subscriptions, tx.subscriptions.Load())
+		})
+	}
+}
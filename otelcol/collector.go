@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/topology"
 )
 
 // State defines Collector's state.
@@ -123,6 +124,10 @@ type Collector struct {
 	// currentFingerprint holds a fingerprint of the last successfully applied
 	// configuration, derived from the raw (pre-decode) configuration map.
 	currentFingerprint *configFingerprint
+
+	// runningConf is the raw configuration of the running service, the candidate configurations are
+	// compared to it.
+	runningConf atomic.Pointer[confmap.Conf]
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -193,14 +198,14 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
-	if err = confmap.Validate(cfg); err != nil {
+	if err = col.configProvider.withProvenance(confmap.Validate(cfg)); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	// Fingerprint the configuration to compare configuration changes. This
 	// must happen here before any component can mutate the configuration.
 	var fingerprint *configFingerprint
-	if service.ReceiverPartialReloadEnabled() {
+	if partialReloadEnabled() {
 		fp, fpErr := fingerprintForPartialReload(rawConf, cfg)
 		if fpErr != nil {
 			return fpErr
@@ -233,6 +238,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		BuildInfo:      col.set.BuildInfo,
 		ConfigSnapshot: extensioncapabilities.NewConfigSnapshot(conf, unexpandedConf),
 		CollectorConf:  conf,
+		CompareConfig:  col.compareConfig,
 
 		ReceiversConfigs:    cfg.Receivers,
 		ReceiversFactories:  factories.Receivers,
@@ -279,17 +285,18 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	if err = col.service.Start(ctx); err != nil {
 		return multierr.Combine(err, col.service.Shutdown(ctx))
 	}
-	if service.ReceiverPartialReloadEnabled() {
+	if partialReloadEnabled() {
 		col.currentFingerprint = fingerprint
 	}
+	col.runningConf.Store(rawConf)
 	col.setCollectorState(StateRunning)
 
 	return nil
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
-	if service.ReceiverPartialReloadEnabled() && col.currentFingerprint != nil {
-		reloaded, err := col.tryPartialReceiverReload(ctx)
+	if partialReloadEnabled() && col.currentFingerprint != nil {
+		reloaded, err := col.tryPartialReload(ctx)
 		if reloaded && err == nil {
 			// Partial reload succeeded; the service keeps running.
 			return nil
@@ -300,12 +307,12 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 			// nodes removed, new nodes without components), so it cannot be
 			// resumed or repaired incrementally. Fall back to a full reload
 			// below, which discards the existing service and graph entirely
-			// and rebuilds from scratch, consistent with how a non-receiver
+			// and rebuilds from scratch, consistent with how a service
 			// config change is handled.
-			col.service.Logger().Warn("Partial receiver reload failed, falling back to full reload", zap.Error(err))
+			col.service.Logger().Warn("Partial reload failed, falling back to full reload", zap.Error(err))
 		}
-		// Otherwise, the config change was not receiver-only; fall through to
-		// a full reload.
+		// Otherwise, the config change cannot be partially reloaded; fall
+		// through to a full reload.
 	}
 
 	col.service.Logger().Warn("Config updated, restart service")
@@ -322,11 +329,19 @@ func (col *Collector) reloadConfiguration(ctx context.Context) error {
 	return nil
 }
 
-// tryPartialReceiverReload attempts to reload only the receiver components if
-// the configuration change is limited to receivers. The bool return indicates
-// whether a partial reload was attempted (true) or the change requires a full
-// reload (false). When true, the error indicates whether the reload succeeded.
-func (col *Collector) tryPartialReceiverReload(ctx context.Context) (bool, error) {
+// partialReloadEnabled reports whether any phase of the partial reload is
+// enabled, in which case the configurations are fingerprinted.
+func partialReloadEnabled() bool {
+	return service.ReceiverPartialReloadEnabled() || service.PipelinePartialReloadEnabled()
+}
+
+// tryPartialReload attempts to reload only the components affected by the
+// configuration change: the receivers if the change is limited to receivers,
+// or the components of the changed pipelines if the change is limited to the
+// pipelines and their components. The bool return indicates whether a
+// partial reload was attempted (true) or the change requires a full reload
+// (false). When true, the error indicates whether the reload succeeded.
+func (col *Collector) tryPartialReload(ctx context.Context) (bool, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
 		return false, err
@@ -337,7 +352,7 @@ func (col *Collector) tryPartialReceiverReload(ctx context.Context) (bool, error
 		return false, err
 	}
 
-	if validateErr := confmap.Validate(newCfg); validateErr != nil {
+	if validateErr := col.configProvider.withProvenance(confmap.Validate(newCfg)); validateErr != nil {
 		return false, validateErr
 	}
 
@@ -346,40 +361,93 @@ func (col *Collector) tryPartialReceiverReload(ctx context.Context) (bool, error
 		return false, err
 	}
 
-	if !receiversOnlyChanged(*col.currentFingerprint, newFingerprint, isConnectorID(newCfg.Connectors)) {
+	switch {
+	case service.ReceiverPartialReloadEnabled() &&
+		receiversOnlyChanged(*col.currentFingerprint, newFingerprint, isConnectorID(newCfg.Connectors)):
+		col.service.Logger().Info("Config updated, performing partial receiver reload")
+		if err = col.service.UpdateReceivers(ctx,
+			changedReceivers(*col.currentFingerprint, newFingerprint),
+			newCfg.Receivers,
+			factories.Receivers,
+			newCfg.Service.Pipelines,
+		); err != nil {
+			return true, fmt.Errorf("partial receiver reload failed: %w", err)
+		}
+	case service.PipelinePartialReloadEnabled() && serviceUnchanged(*col.currentFingerprint, newFingerprint):
+		col.service.Logger().Info("Config updated, performing partial pipelines reload")
+		if err = col.service.UpdatePipelines(ctx, service.Settings{
+			ReceiversConfigs:    newCfg.Receivers,
+			ReceiversFactories:  factories.Receivers,
+			ProcessorsConfigs:   newCfg.Processors,
+			ProcessorsFactories: factories.Processors,
+			ExportersConfigs:    newCfg.Exporters,
+			ExportersFactories:  factories.Exporters,
+			ConnectorsConfigs:   newCfg.Connectors,
+			ConnectorsFactories: factories.Connectors,
+		}, newCfg.Service, changedComponents(*col.currentFingerprint, newFingerprint)); err != nil {
+			return true, fmt.Errorf("partial pipelines reload failed: %w", err)
+		}
+	default:
 		return false, nil
 	}
 
-	col.service.Logger().Info("Config updated, performing partial receiver reload")
-	if err = col.service.UpdateReceivers(ctx,
-		changedReceivers(*col.currentFingerprint, newFingerprint),
-		newCfg.Receivers,
-		factories.Receivers,
-		newCfg.Service.Pipelines,
-	); err != nil {
-		return true, fmt.Errorf("partial receiver reload failed: %w", err)
-	}
-
 	col.currentFingerprint = &newFingerprint
+	col.runningConf.Store(rawConf)
 	return true, nil
 }
 
 func (col *Collector) DryRun(ctx context.Context) error {
+	return col.dryRun(ctx, false)
+}
+
+// dryRun is DryRun, optionally validating the configuration against the schema of the components before
+// unmarshalling it, in which case all the violations of the schema are reported.
+func (col *Collector) dryRun(ctx context.Context, validateSchema bool) error {
+	set, cfg, err := col.dryRunSettings(ctx, validateSchema)
+	if err != nil {
+		return err
+	}
+	return service.Validate(ctx, set, cfg)
+}
+
+// topology returns the topology of the pipelines of the configuration, built without starting them. The
+// configuration is optionally validated against the schema of the components first, see dryRun.
+func (col *Collector) topology(ctx context.Context, validateSchema bool) (*topology.Topology, error) {
+	set, cfg, err := col.dryRunSettings(ctx, validateSchema)
+	if err != nil {
+		return nil, err
+	}
+	return service.Topology(ctx, set, cfg)
+}
+
+// dryRunSettings resolves and validates the configuration, and returns the settings and configuration of a
+// service building its pipelines without running them.
+func (col *Collector) dryRunSettings(ctx context.Context, validateSchema bool) (service.Settings, service.Config, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return service.Settings{}, service.Config{}, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 
-	cfg, err := col.configProvider.Get(ctx, factories)
+	conf, err := col.configProvider.resolve(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return service.Settings{}, service.Config{}, fmt.Errorf("failed to get config: %w", err)
+	}
+	if validateSchema {
+		if err = validateConfigSchema(conf, factories); err != nil {
+			return service.Settings{}, service.Config{}, fmt.Errorf("invalid configuration: the configuration does not match the schema of the components:\n%w",
+				col.configProvider.withProvenance(err))
+		}
+	}
+	cfg, err := col.configProvider.unmarshal(conf, factories)
+	if err != nil {
+		return service.Settings{}, service.Config{}, fmt.Errorf("failed to get config: %w", err)
 	}
 
-	if err := confmap.Validate(cfg); err != nil {
-		return err
+	if err := col.configProvider.withProvenance(confmap.Validate(cfg)); err != nil {
+		return service.Settings{}, service.Config{}, err
 	}
 
-	return service.Validate(ctx, service.Settings{
+	return service.Settings{
 		BuildInfo:           col.set.BuildInfo,
 		ReceiversConfigs:    cfg.Receivers,
 		ReceiversFactories:  factories.Receivers,
@@ -392,7 +460,7 @@ func (col *Collector) DryRun(ctx context.Context) error {
 		TelemetryFactory:    factories.Telemetry,
 	}, service.Config{
 		Pipelines: cfg.Service.Pipelines,
-	})
+	}, nil
 }
 
 func newFallbackLogger(options []zap.Option) (*zap.Logger, error) {
@@ -669,7 +669,7 @@ func TestCollectorPartialReceiverReloadAddRemove(t *testing.T) {
 }
 
 // TestCollectorNonReceiverChangeFullReload verifies that when a config change is
-// not receiver-only (here an exporter changes), tryPartialReceiverReload reports
+// not receiver-only (here an exporter changes), tryPartialReload reports
 // it cannot handle the change and the collector falls back to a full restart.
 func TestCollectorNonReceiverChangeFullReload(t *testing.T) {
 	require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReload", true))
@@ -752,6 +752,98 @@ func TestCollectorNonReceiverChangeFullReload(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+// TestCollectorPartialPipelinesReload verifies that with the pipeline phase
+// enabled, a change of an exporter only reloads the pipelines, while a change
+// of the enabled extensions still requires a full restart.
+func TestCollectorPartialPipelinesReload(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReload", true))
+	require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReloadPipelines", true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReload", false))
+		require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReloadPipelines", false))
+	}()
+
+	observerCore, observedLogs := observer.New(zapcore.InfoLevel)
+
+	var exporterChanged, extensionEnabled atomic.Bool
+	confMap := func() map[string]any {
+		exporterEndpoint := "exporter"
+		if exporterChanged.Load() {
+			exporterEndpoint = "exporter-changed"
+		}
+		var extensions []any
+		if extensionEnabled.Load() {
+			extensions = []any{"nop"}
+		}
+		return map[string]any{
+			"receivers":  map[string]any{"normalizing": map[string]any{"endpoint": "receiver"}},
+			"exporters":  map[string]any{"normalizing": map[string]any{"endpoint": exporterEndpoint}},
+			"extensions": map[string]any{"nop": nil},
+			"service": map[string]any{
+				"extensions": extensions,
+				"pipelines": map[string]any{
+					"traces": map[string]any{
+						"receivers": []any{"normalizing"},
+						"exporters": []any{"normalizing"},
+					},
+				},
+			},
+		}
+	}
+
+	var watcher confmap.WatcherFunc
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		return confmap.NewRetrieved(confMap())
+	})
+
+	factories := normalizingFactories(t)
+	factories.Telemetry = telemetry.NewFactory(
+		func() component.Config { return fakeTelemetryConfig{} },
+		telemetrytest.WithLogger(zap.New(observerCore), nil),
+	)
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:cfg"},
+				ProviderFactories: []confmap.ProviderFactory{provider},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// Change the exporter config: only the pipelines are reloaded.
+	exporterChanged.Store(true)
+	watcher(&confmap.ChangeEvent{})
+
+	assert.Eventually(t, func() bool {
+		return observedLogs.FilterMessage("Config updated, performing partial pipelines reload").Len() == 1
+	}, 2*time.Second, 50*time.Millisecond)
+	assert.Equal(t, StateRunning, col.GetState())
+	assert.Zero(t, observedLogs.FilterMessage("Config updated, restart service").Len())
+
+	// Enable the extension: the service must be restarted.
+	extensionEnabled.Store(true)
+	watcher(&confmap.ChangeEvent{})
+
+	assert.Eventually(t, func() bool {
+		return observedLogs.FilterMessage("Config updated, restart service").Len() == 1
+	}, 2*time.Second, 50*time.Millisecond)
+	assert.Equal(t, 1, observedLogs.FilterMessage("Config updated, performing partial pipelines reload").Len())
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
 // flakyStartConfig's Generation field lets two otherwise-identical configs
 // compare as different, forcing the receiver to rebuild on reload.
 type flakyStartConfig struct {
@@ -872,13 +964,13 @@ func TestCollectorPartialReceiverReloadFallsBackOnFailure(t *testing.T) {
 	require.EqualValues(t, 1, startCalls.Load())
 
 	// Change the receiver config to force a rebuild. The rebuild's Start call
-	// is the forced failure, so tryPartialReceiverReload reports a failure.
+	// is the forced failure, so tryPartialReload reports a failure.
 	generation.Store(1)
 	watcher(&confmap.ChangeEvent{})
 
 	assert.Eventually(t, func() bool {
 		for _, entry := range observedLogs.All() {
-			if entry.Message == "Partial receiver reload failed, falling back to full reload" {
+			if entry.Message == "Partial reload failed, falling back to full reload" {
 				return true
 			}
 		}
@@ -952,7 +1044,7 @@ func validatingFactories(t *testing.T) Factories {
 // TestCollectorInvalidConfigOnReload verifies that when the config fetched during
 // a partial-reload attempt fails validation, the reload reports the error rather
 // than proceeding. The config served on reload sets the receiver endpoint to the
-// sentinel "invalid", so confmap.Validate fails in tryPartialReceiverReload.
+// sentinel "invalid", so confmap.Validate fails in tryPartialReload.
 func TestCollectorInvalidConfigOnReload(t *testing.T) {
 	require.NoError(t, featuregate.GlobalRegistry().Set("service.partialReload", true))
 	defer func() {
@@ -1214,7 +1306,7 @@ func TestCollectorStartInvalidConfig(t *testing.T) {
 		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid.yaml")}),
 	})
 	require.NoError(t, err)
-	assert.EqualError(t, col.Run(context.Background()), "invalid configuration: service::pipelines::traces: references processor \"invalid\" which is not configured")
+	assert.EqualError(t, col.Run(context.Background()), "invalid configuration: service::pipelines::traces: references processor \"invalid\" which is not configured (service::pipelines::traces set in file:"+filepath.Join("testdata", "otelcol-invalid.yaml")+")")
 }
 
 func TestNewCollectorInvalidConfigProviderSettings(t *testing.T) {
@@ -1390,7 +1482,8 @@ func TestCollectorRun_Errors(t *testing.T) {
 				Factories:              nopFactories,
 				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid.yaml")}),
 			},
-			expectedErr: `invalid configuration: service::pipelines::traces: references processor "invalid" which is not configured`,
+			expectedErr: `invalid configuration: service::pipelines::traces: references processor "invalid" which is not configured` +
+				" (service::pipelines::traces set in file:" + filepath.Join("testdata", "otelcol-invalid.yaml") + ")",
 		},
 		"invalid_telemetry_config": {
 			settings: CollectorSettings{
@@ -1398,7 +1491,7 @@ func TestCollectorRun_Errors(t *testing.T) {
 				Factories:              nopFactories,
 				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid-telemetry.yaml")}),
 			},
-			expectedErr: "failed to get config: cannot unmarshal the configuration: decoding failed due to the following error(s):\n\n'service.telemetry' has invalid keys: unknown",
+			expectedErr: "failed to get config: cannot unmarshal the configuration: decoding failed due to the following error(s):\n\n'service.telemetry' has invalid keys: unknown (service::telemetry set in file:" + filepath.Join("testdata", "otelcol-invalid-telemetry.yaml") + ")",
 		},
 		"missing_telemetry_factory": {
 			settings: CollectorSettings{
@@ -1449,7 +1542,8 @@ func TestCollectorDryRun(t *testing.T) {
 				Factories:              nopFactories,
 				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid.yaml")}),
 			},
-			expectedErr: `service::pipelines::traces: references processor "invalid" which is not configured`,
+			expectedErr: `service::pipelines::traces: references processor "invalid" which is not configured` +
+				" (service::pipelines::traces set in file:" + filepath.Join("testdata", "otelcol-invalid.yaml") + ")",
 		},
 		"invalid_connector_use_unused_exp": {
 			settings: CollectorSettings{
@@ -1481,7 +1575,7 @@ func TestCollectorDryRun(t *testing.T) {
 				Factories:              nopFactories,
 				ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid-telemetry.yaml")}),
 			},
-			expectedErr: "failed to get config: cannot unmarshal the configuration: decoding failed due to the following error(s):\n\n'service.telemetry' has invalid keys: unknown",
+			expectedErr: "failed to get config: cannot unmarshal the configuration: decoding failed due to the following error(s):\n\n'service.telemetry' has invalid keys: unknown (service::telemetry set in file:" + filepath.Join("testdata", "otelcol-invalid-telemetry.yaml") + ")",
 		},
 		"missing_telemetry_factory": {
 			settings: CollectorSettings{
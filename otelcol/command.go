@@ -45,6 +45,9 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
 	rootCmd.AddCommand(newConfigPrintSubCommand(set, flagSet))
+	rootCmd.AddCommand(newConfigDiffSubCommand(set, flagSet))
+	rootCmd.AddCommand(newGraphSubCommand(set, flagSet))
+	rootCmd.AddCommand(newQueueSubCommand(set, flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -4,6 +4,7 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -43,10 +44,11 @@ type componentsOutput struct {
 
 // newComponentsCommand constructs a new components command using the given CollectorSettings.
 func newComponentsCommand(set CollectorSettings) *cobra.Command {
-	return &cobra.Command{
+	var schema bool
+	cmd := &cobra.Command{
 		Use:   "components",
 		Short: "Outputs available components in this collector distribution",
-		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.",
+		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases. With --schema, outputs the JSON schema of the configuration instead, e.g. for the autocompletion of an editor.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			factories, err := set.Factories()
@@ -54,6 +56,15 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 				return fmt.Errorf("failed to initialize factories: %w", err)
 			}
 
+			if schema {
+				jsonData, err := json.MarshalIndent(configSchema(factories), "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+				return nil
+			}
+
 			components := componentsOutput{}
 			for _, con := range sortFactoriesByType[connector.Factory](factories.Connectors) {
 				components.Connectors = append(components.Connectors, componentWithStability{
@@ -133,6 +144,8 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&schema, "schema", false, "Output the JSON schema of the configuration: true, false (default)")
+	return cmd
 }
 
 func canonicalFactoryKeys[T component.Factory](factories map[component.Type]T) []component.Type {
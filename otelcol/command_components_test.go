@@ -6,8 +6,11 @@ package otelcol
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -333,3 +336,26 @@ func TestSortFactoriesByType(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentsSchemaOutput(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-nop.yaml")}),
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"components", "--schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	properties := schema["properties"].(map[string]any)
+	assert.ElementsMatch(t, []string{"receivers", "processors", "exporters", "connectors", "extensions", "service", "templates"}, slices.Collect(maps.Keys(properties)))
+	receivers := properties["receivers"].(map[string]any)
+	assert.Contains(t, receivers["patternProperties"], `^nop(?:/.+)?$`)
+	assert.Equal(t, map[string]any{"description": "unknown component type", "not": map[string]any{}}, receivers["additionalProperties"])
+}
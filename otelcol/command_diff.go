@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/service/configdiff"
+)
+
+// newConfigDiffSubCommand constructs a new diff-config command using the given CollectorSettings.
+func newConfigDiffSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var candidates []string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "diff-config",
+		Short: "Compares a candidate configuration to the Collector's configuration",
+		Long: `Compares a candidate configuration, set with --candidate, to the Collector's
+configuration, set with --config, to preview the impact of applying it.
+
+Both configurations are resolved with the same providers and validated. The output
+lists the components added, removed or changed by section, the pipelines added,
+removed or rebuilt, whether the enabled extensions or the internal telemetry change,
+and the reload the change requires: none, receivers when only the receivers change
+and can be restarted alone with the service.partialReload and
+service.partialReloadReceivers feature gates enabled, pipelines when only the
+components of the pipelines and the pipelines change and the affected components can
+be restarted alone with the service.partialReload and service.partialReloadPipelines
+feature gates enabled, or full.
+
+The configurations are compared as they are resolved, before they are unmarshalled,
+e.g. setting the default value of an option is a change.
+
+A running Collector compares the candidate configurations posted as YAML to its
+configuration on the /debug/configdiffz page of the zpages extension.
+
+The output prints in YAML by default. To print JSON use --format=json.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			if len(candidates) == 0 {
+				return errors.New("at least one candidate flag must be provided")
+			}
+			factories, err := set.Factories()
+			if err != nil {
+				return fmt.Errorf("failed to initialize factories: %w", err)
+			}
+
+			running, err := resolveValidConfig(cmd.Context(), set.ConfigProviderSettings, factories)
+			if err != nil {
+				return fmt.Errorf("invalid configuration: %w", err)
+			}
+			candidateSet := set.ConfigProviderSettings
+			candidateSet.ResolverSettings.URIs = candidates
+			candidate, err := resolveValidConfig(cmd.Context(), candidateSet, factories)
+			if err != nil {
+				return fmt.Errorf("invalid candidate configuration: %w", err)
+			}
+
+			diff, err := configdiff.Compare(running, candidate)
+			if err != nil {
+				return err
+			}
+			return printConfigDiff(cmd.OutOrStdout(), diff, outputFormat)
+		},
+	}
+
+	candidateHelp := "Locations to the candidate config file(s), resolved like the --config locations"
+	cmd.Flags().StringArrayVar(&candidates, "candidate", nil, candidateHelp)
+
+	formatHelp := "Output format: yaml (default), json"
+	cmd.Flags().StringVar(&outputFormat, "format", "yaml", formatHelp)
+
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
+
+func printConfigDiff(w io.Writer, diff *configdiff.Diff, format string) error {
+	switch {
+	case strings.EqualFold(format, "yaml"):
+		b, err := yaml.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case strings.EqualFold(format, "json"):
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+	return fmt.Errorf("unrecognized output format: %s", format)
+}
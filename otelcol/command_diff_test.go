@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/configdiff"
+)
+
+func TestConfigDiffSubCommand(t *testing.T) {
+	configFile := filepath.Join("testdata", "otelcol-nop.yaml")
+	candidateFile := filepath.Join("testdata", "otelcol-nop-candidate.yaml")
+	newCommand := func() (*bytes.Buffer, func(args ...string) error) {
+		set := CollectorSettings{
+			Factories:              nopFactories,
+			ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{configFile}),
+		}
+		cmd := newConfigDiffSubCommand(set, flags(featuregate.GlobalRegistry()))
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		return out, func(args ...string) error {
+			cmd.SetArgs(args)
+			return cmd.Execute()
+		}
+	}
+
+	_, execute := newCommand()
+	require.EqualError(t, execute(), "at least one candidate flag must be provided")
+
+	out, execute := newCommand()
+	require.NoError(t, execute("--candidate", candidateFile))
+	assert.Equal(t, `receivers:
+    added:
+        - nop/2
+processors: {}
+exporters: {}
+connectors: {}
+extensions: {}
+pipelines:
+    rebuilt:
+        - metrics
+service_extensions_changed: false
+telemetry_changed: false
+reload: receivers
+`, out.String())
+
+	out, execute = newCommand()
+	require.NoError(t, execute("--candidate", configFile, "--format", "json"))
+	var diff configdiff.Diff
+	require.NoError(t, json.Unmarshal(out.Bytes(), &diff))
+	assert.Equal(t, configdiff.Diff{Reload: configdiff.ReloadNone}, diff)
+
+	_, execute = newCommand()
+	require.EqualError(t, execute("--candidate", candidateFile, "--format", "toml"), "unrecognized output format: toml")
+
+	_, execute = newCommand()
+	require.ErrorContains(t, execute("--candidate", filepath.Join("testdata", "otelcol-invalid-components.yaml")),
+		"invalid candidate configuration: cannot unmarshal the configuration")
+}
+
+func TestCollectorCompareConfig(t *testing.T) {
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-nop.yaml")}),
+	})
+	require.NoError(t, err)
+
+	candidate, err := os.ReadFile(filepath.Join("testdata", "otelcol-nop-candidate.yaml"))
+	require.NoError(t, err)
+	_, err = col.compareConfig(context.Background(), candidate)
+	require.EqualError(t, err, "the collector is not running")
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	diff, err := col.compareConfig(context.Background(), candidate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nop/2"}, diff.Receivers.Added)
+	assert.Equal(t, []string{"metrics"}, diff.Pipelines.Rebuilt)
+	assert.Equal(t, configdiff.ReloadReceivers, diff.Reload)
+
+	_, err = col.compareConfig(context.Background(), []byte("receivers: [nop]"))
+	require.ErrorContains(t, err, "invalid candidate configuration")
+
+	// The candidate is not resolved with the providers of the collector.
+	t.Setenv("CANDIDATE_ENDPOINT", "localhost:4317")
+	_, err = col.compareConfig(context.Background(), []byte("receivers:\n  nop:\n    endpoint: ${env:CANDIDATE_ENDPOINT}"))
+	require.ErrorContains(t, err, `scheme "env" is not supported`)
+	_, err = col.compareConfig(context.Background(), []byte("receivers:\n  nop:\n    endpoint: ${file:testdata/otelcol-nop.yaml}"))
+	require.ErrorContains(t, err, `scheme "file" is not supported`)
+
+	col.Shutdown()
+	wg.Wait()
+}
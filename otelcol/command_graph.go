@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+)
+
+// newGraphSubCommand constructs a new graph command using the given CollectorSettings.
+func newGraphSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var (
+		outputFormat string
+		schema       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Prints the topology of the pipelines without running the collector",
+		Long: `Prints the topology of the pipelines of the configuration without running the collector.
+
+The configuration is validated and the pipelines are built as the collector builds
+them, without starting them. The configuration is first validated against the schema
+of the components as with the validate command, unless --schema=false. The topology lists the nodes of the graph, i.e. the
+receivers and exporters by signal, the processors by pipeline, the connectors by pair
+of signals, and the capabilities and fanout nodes of each pipeline, and the edges the
+data flows through. Each node lists its pipelines and whether it mutates the data.
+
+A running Collector serves its topology on the /debug/graphz page of the zpages
+extension.
+
+The output prints in JSON by default. To print a Graphviz DOT graph use --format=dot,
+e.g. to render it with: dot -Tsvg -o pipelines.svg`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			topo, err := col.topology(cmd.Context(), schema)
+			if err == nil {
+				err = topo.Write(cmd.OutOrStdout(), strings.ToLower(outputFormat))
+			}
+			return multierr.Append(err, col.configProvider.Shutdown(cmd.Context()))
+		},
+	}
+
+	formatHelp := "Output format: json (default), dot"
+	cmd.Flags().StringVar(&outputFormat, "format", "json", formatHelp)
+	schemaHelp := "Validate the configuration against the schema of the components: true (default), false"
+	cmd.Flags().BoolVar(&schema, "schema", true, schemaHelp)
+
+	cmd.Flags().AddGoFlagSet(flagSet)
+	return cmd
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/topology"
+)
+
+func TestGraphSubCommand(t *testing.T) {
+	newCommand := func(configFile string) (*bytes.Buffer, func(args ...string) error) {
+		set := CollectorSettings{
+			Factories:              nopFactories,
+			ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", configFile)}),
+		}
+		cmd := newGraphSubCommand(set, flags(featuregate.GlobalRegistry()))
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		return out, func(args ...string) error {
+			cmd.SetArgs(args)
+			return cmd.Execute()
+		}
+	}
+
+	out, execute := newCommand("otelcol-valid-connector-use.yaml")
+	require.NoError(t, execute())
+	topo := &topology.Topology{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), topo))
+	var ids []string
+	for _, n := range topo.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{
+		"capabilities:logs/in1",
+		"capabilities:logs/in2",
+		"capabilities:logs/out",
+		"connector:logs:logs:nop/connector1",
+		"exporter:logs:nop",
+		"fanout:logs/in1",
+		"fanout:logs/in2",
+		"fanout:logs/out",
+		"receiver:logs:nop",
+	}, ids)
+	assert.Contains(t, topo.Edges, topology.Edge{From: "fanout:logs/in2", To: "connector:logs:logs:nop/connector1"})
+	assert.Contains(t, topo.Edges, topology.Edge{From: "connector:logs:logs:nop/connector1", To: "capabilities:logs/out"})
+
+	out, execute = newCommand("otelcol-valid-connector-use.yaml")
+	require.NoError(t, execute("--format=dot"))
+	assert.True(t, strings.HasPrefix(out.String(), "digraph pipelines {"))
+	assert.Contains(t, out.String(), `"fanout:logs/in2" -> "connector:logs:logs:nop/connector1";`)
+
+	_, execute = newCommand("otelcol-valid-connector-use.yaml")
+	require.ErrorContains(t, execute("--format=svg"), `unknown topology format "svg"`)
+
+	_, execute = newCommand("otelcol-invalid-connector-unused-exp.yaml")
+	require.ErrorContains(t, execute(), "failed to build pipelines")
+
+	// The configuration is validated against the schema as with the validate command.
+	_, execute = newCommand("otelcol-invalid-schema.yaml")
+	require.ErrorContains(t, execute(), "the configuration does not match the schema of the components")
+	_, execute = newCommand("otelcol-invalid-schema.yaml")
+	err := execute("--schema=false")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "does not match the schema")
+}
@@ -22,6 +22,7 @@ func newConfigPrintSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cob
 	var outputFormat string
 	var mode string
 	var validate bool
+	var provenance bool
 
 	cmd := &cobra.Command{
 		Use:     "print-config",
@@ -37,6 +38,11 @@ however this is considered unstable.
 
 Validation is enabled by default, as a safety measure.
 
+With --provenance, every value is annotated with its source: the configuration
+URI and line it was set at, the ${} reference it was expanded from, or "default"
+when it is not set by the configuration. The YAML output annotates the values with
+comments, the JSON output adds a "provenance" object keyed by path.
+
 All modes are experimental, requiring the otelcol.printInitialConfig feature gate.`,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -46,6 +52,7 @@ All modes are experimental, requiring the otelcol.printInitialConfig feature gat
 				set:          set,
 				outputFormat: outputFormat,
 				validate:     validate,
+				provenance:   provenance,
 			}
 			return pc.configPrintSubCommand(flagSet, mode)
 		},
@@ -60,6 +67,9 @@ All modes are experimental, requiring the otelcol.printInitialConfig feature gat
 	validateHelp := "Validation mode: true (default), false"
 	cmd.Flags().BoolVar(&validate, "validate", true, validateHelp)
 
+	provenanceHelp := "Annotate every value with its source: true, false (default)"
+	cmd.Flags().BoolVar(&provenance, "provenance", false, provenanceHelp)
+
 	cmd.Flags().AddGoFlagSet(flagSet)
 	return cmd
 }
@@ -70,6 +80,10 @@ type printContext struct {
 	set          CollectorSettings
 	outputFormat string
 	validate     bool
+	provenance   bool
+
+	// sources is the provenance of the configuration keys, recorded by getPrintableConfig.
+	sources map[string]confmap.Provenance
 }
 
 func (pctx *printContext) configPrintSubCommand(flagSet *flag.FlagSet, mode string) error {
@@ -97,7 +111,15 @@ func (pctx *printContext) printConfigData(data map[string]any) error {
 
 	switch {
 	case strings.EqualFold(format, "yaml"):
-		b, err := yaml.Marshal(data)
+		var out any = data
+		if pctx.provenance {
+			node, err := provenanceNode(data, pctx.sources)
+			if err != nil {
+				return err
+			}
+			out = node
+		}
+		b, err := yaml.Marshal(out)
 		if err != nil {
 			return err
 		}
@@ -107,6 +129,12 @@ func (pctx *printContext) printConfigData(data map[string]any) error {
 	case strings.EqualFold(format, "json"):
 		encoder := json.NewEncoder(pctx.stdout)
 		encoder.SetIndent("", "  ")
+		if pctx.provenance {
+			return encoder.Encode(map[string]any{
+				"config":     data,
+				"provenance": provenanceOf(data, pctx.sources),
+			})
+		}
 		return encoder.Encode(data)
 	}
 
@@ -127,11 +155,14 @@ func (pctx *printContext) getPrintableConfig() (any, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config provider: %w", err)
 	}
+	// The configuration is only printed once, stop watching it.
+	defer func() { _ = configProvider.Shutdown(pctx.cmd.Context()) }()
 
 	cfg, err := configProvider.Get(pctx.cmd.Context(), factories)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config: %w", err)
 	}
+	pctx.sources = configProvider.mapResolver.Provenance()
 	return cfg, nil
 }
 
@@ -146,7 +177,7 @@ func (pctx *printContext) printUnredactedConfig() error {
 
 	if pctx.validate {
 		// Validation serves prevent revealing invalid data.
-		if err = confmap.Validate(cfg); err != nil {
+		if err = withProvenance(confmap.Validate(cfg), pctx.sources); err != nil {
 			return fmt.Errorf("invalid configuration: %w", err)
 		}
 		// Note: we discard the validated configuration.
@@ -167,7 +198,7 @@ func (pctx *printContext) printRedactedConfig() error {
 	}
 
 	if pctx.validate {
-		if err = confmap.Validate(cfg); err != nil {
+		if err = withProvenance(confmap.Validate(cfg), pctx.sources); err != nil {
 			return fmt.Errorf("invalid configuration: %w", err)
 		}
 	}
@@ -50,6 +50,8 @@ func TestPrintCommand(t *testing.T) {
 	invalidConfig1 := fmt.Sprint("file:", filepath.Join("testdata", "print_invalid.yaml"))
 	invalidConfig2 := fmt.Sprint("file:", filepath.Join("testdata", "print_negative.yaml"))
 	defaultConfig := fmt.Sprint("file:", filepath.Join("testdata", "print_default.yaml"))
+	provenanceConfig := fmt.Sprint("file:", filepath.Join("testdata", "print_provenance.yaml"))
+	templatesConfig := fmt.Sprint("file:", filepath.Join("testdata", "print_templates.yaml"))
 
 	tests := []struct {
 		name            string
@@ -58,6 +60,7 @@ func TestPrintCommand(t *testing.T) {
 		errString       string
 		outString       map[string]string
 		validate        bool // add validation (even redacted)
+		provenance      bool // annotate the values with their source
 		errOnlyRedacted bool // error applies only in redacted mode
 	}{
 		{
@@ -81,6 +84,53 @@ func TestPrintCommand(t *testing.T) {
 			validate:  true,
 			errString: "timeout cannot be negative",
 		},
+		{
+			name:      "invalid syntax provenance",
+			path:      invalidConfig1,
+			errString: "'timeout' time: invalid duration (exporters::e::timeout set in " + invalidConfig1 + ":5)",
+		},
+		{
+			name:      "validation fail provenance",
+			path:      invalidConfig2,
+			validate:  true,
+			errString: "exporters::e: timeout cannot be negative (exporters::e set in " + invalidConfig2 + ":4)",
+		},
+		{
+			name:       "provenance yaml",
+			path:       provenanceConfig,
+			provenance: true,
+			outString: map[string]string{
+				"redacted":   "timeout: 5s # " + provenanceConfig + ":5 (expanded from ${file:testdata/print_provenance_timeout.yaml})",
+				"unredacted": "receivers: # " + provenanceConfig + ":9",
+			},
+		},
+		{
+			name:       "provenance default",
+			path:       provenanceConfig,
+			provenance: true,
+			outString: map[string]string{
+				"redacted":   "opaque: '[REDACTED]' # default",
+				"unredacted": `opaque: "1234" # default`,
+			},
+		},
+		{
+			name:       "provenance json",
+			ofmt:       "json",
+			path:       provenanceConfig,
+			provenance: true,
+			outString: map[string]string{
+				"redacted":   `"receivers::r::opaque": "default"`,
+				"unredacted": `:5 (expanded from ${file:testdata/print_provenance_timeout.yaml})"`,
+			},
+		},
+		{
+			name: "templates",
+			path: templatesConfig,
+			outString: map[string]string{
+				"redacted":   "e/globex:\n        timeout: 7s",
+				"unredacted": "r/acme:\n        opaque: acme-secret",
+			},
+		},
 		{
 			name: "field is set yaml",
 			path: validConfig,
@@ -189,6 +239,9 @@ func TestPrintCommand(t *testing.T) {
 				} else {
 					args = append(args, "--validate=false")
 				}
+				if test.provenance {
+					args = append(args, "--provenance")
+				}
 				cmd.SetArgs(args)
 				err := cmd.Execute()
 
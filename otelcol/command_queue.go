@@ -0,0 +1,488 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	pdatareq "go.opentelemetry.io/collector/pdata/xpdata/request"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// newQueueSubCommand constructs a new queue sub command using the given CollectorSettings.
+func newQueueSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	queueCmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspects or replays the content of an exporter persistent queue or dead letter storage",
+		Long: `Inspects or replays the content of an exporter persistent queue or dead letter storage without running the collector.
+
+The storage extension configured for the exporter is started offline, the collector instance owning it must be stopped.
+The output format is not stable and can change between releases.`,
+		Args: cobra.ExactArgs(0),
+	}
+
+	var qf queueFlags
+	inspectCmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Lists the queue metadata and the stored items",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			qc, err := newQueueContext(cmd.Context(), set, flagSet, qf)
+			if err != nil {
+				return err
+			}
+			return qc.inspect(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	inspectCmd.Flags().BoolVar(&qf.includeData, "include-data", false, "Include the stored telemetry, rendered as OTLP JSON")
+
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Sends the stored items through the exporter and removes them from the storage",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			qc, err := newQueueContext(cmd.Context(), set, flagSet, qf)
+			if err != nil {
+				return err
+			}
+			return qc.replay(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	for _, c := range []*cobra.Command{inspectCmd, replayCmd} {
+		c.Flags().StringVar(&qf.exporter, "exporter", "", "ID of the exporter owning the queue (required)")
+		c.Flags().StringVar(&qf.signal, "signal", pipeline.SignalTraces.String(), "Signal of the queue: traces, metrics or logs")
+		c.Flags().BoolVar(&qf.deadLetter, "dead-letter", false, "Use the dead letter storage instead of the sending queue")
+		c.Flags().AddGoFlagSet(flagSet)
+		queueCmd.AddCommand(c)
+	}
+	return queueCmd
+}
+
+type queueFlags struct {
+	exporter    string
+	signal      string
+	deadLetter  bool
+	includeData bool
+}
+
+type queueContext struct {
+	flags      queueFlags
+	set        CollectorSettings
+	factories  Factories
+	cfg        *Config
+	exporterID component.ID
+	signal     pipeline.Signal
+	storageID  component.ID
+	telemetry  component.TelemetrySettings
+}
+
+func newQueueContext(ctx context.Context, set CollectorSettings, flagSet *flag.FlagSet, qf queueFlags) (*queueContext, error) {
+	if qf.exporter == "" {
+		return nil, errors.New("the --exporter flag is required")
+	}
+	qc := &queueContext{flags: qf, set: set}
+	if err := qc.exporterID.UnmarshalText([]byte(qf.exporter)); err != nil {
+		return nil, fmt.Errorf("invalid exporter ID %q: %w", qf.exporter, err)
+	}
+	switch qf.signal {
+	case pipeline.SignalTraces.String():
+		qc.signal = pipeline.SignalTraces
+	case pipeline.SignalMetrics.String():
+		qc.signal = pipeline.SignalMetrics
+	case pipeline.SignalLogs.String():
+		qc.signal = pipeline.SignalLogs
+	default:
+		return nil, fmt.Errorf("unsupported signal %q: signals are: traces, metrics, logs", qf.signal)
+	}
+
+	if err := updateSettingsUsingFlags(&qc.set, flagSet); err != nil {
+		return nil, err
+	}
+	var err error
+	if qc.factories, err = qc.set.Factories(); err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	configProvider, err := NewConfigProvider(qc.set.ConfigProviderSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config provider: %w", err)
+	}
+	defer func() { _ = configProvider.Shutdown(ctx) }()
+	if qc.cfg, err = configProvider.Get(ctx, qc.factories); err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	expCfg, ok := qc.cfg.Exporters[qc.exporterID]
+	if !ok {
+		return nil, fmt.Errorf("exporter %q is not configured", qc.exporterID)
+	}
+	if qc.storageID, err = queueStorageID(expCfg, qf.deadLetter); err != nil {
+		return nil, fmt.Errorf("exporter %q: %w", qc.exporterID, err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return nil, err
+	}
+	qc.telemetry = component.TelemetrySettings{
+		Logger:         logger,
+		TracerProvider: nooptrace.NewTracerProvider(),
+		MeterProvider:  noopmetric.NewMeterProvider(),
+		Resource:       pcommon.NewResource(),
+	}
+	return qc, nil
+}
+
+// queueStorageID returns the storage extension configured for the exporter sending queue or dead letter storage.
+func queueStorageID(expCfg component.Config, deadLetter bool) (component.ID, error) {
+	conf := confmap.New()
+	if err := conf.Marshal(expCfg, xconfmap.WithUnredacted()); err != nil {
+		return component.ID{}, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	key := "sending_queue::storage"
+	if deadLetter {
+		key = "dead_letter::storage"
+	}
+	var storageID component.ID
+	if str, ok := conf.Get(key).(string); ok && str != "" {
+		if err := storageID.UnmarshalText([]byte(str)); err != nil {
+			return component.ID{}, err
+		}
+		return storageID, nil
+	}
+	if deadLetter {
+		return component.ID{}, errors.New("no dead letter storage configured")
+	}
+	return component.ID{}, errors.New("no persistent sending queue configured")
+}
+
+// startExtensions creates and starts the storage extension and, if all is true, the other extensions enabled in the service.
+func (qc *queueContext) startExtensions(ctx context.Context, all bool) (*queueHost, error) {
+	ids := []component.ID{qc.storageID}
+	if all {
+		for _, id := range qc.cfg.Service.Extensions {
+			if id != qc.storageID {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	host := &queueHost{extensions: map[component.ID]component.Component{}}
+	for _, id := range ids {
+		extCfg, ok := qc.cfg.Extensions[id]
+		if !ok {
+			return host, fmt.Errorf("extension %q is not configured", id)
+		}
+		factory, ok := qc.factories.Extensions[id.Type()]
+		if !ok {
+			return host, fmt.Errorf("extension factory for type %q is not available", id.Type())
+		}
+		ext, err := factory.Create(ctx, extension.Settings{ID: id, TelemetrySettings: qc.telemetry, BuildInfo: qc.set.BuildInfo}, extCfg)
+		if err != nil {
+			return host, fmt.Errorf("failed to create extension %q: %w", id, err)
+		}
+		if err = ext.Start(ctx, host); err != nil {
+			return host, fmt.Errorf("failed to start extension %q: %w", id, err)
+		}
+		host.extensions[id] = ext
+		host.order = append(host.order, id)
+	}
+	return host, nil
+}
+
+func (qc *queueContext) storageClient(ctx context.Context, host *queueHost) (storage.Client, error) {
+	ext, ok := host.extensions[qc.storageID].(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", qc.storageID)
+	}
+	return ext.GetClient(ctx, component.KindExporter, qc.exporterID, exporterhelper.QueueStorageName(qc.signal, qc.flags.deadLetter))
+}
+
+type queueOutput struct {
+	Exporter   string              `yaml:"exporter"`
+	Signal     string              `yaml:"signal"`
+	Storage    string              `yaml:"storage"`
+	DeadLetter bool                `yaml:"dead_letter"`
+	Metadata   queueMetadataOutput `yaml:"metadata"`
+	Items      []queueItemOutput   `yaml:"items,omitempty"`
+	Replay     *queueReplayOutput  `yaml:"replay,omitempty"`
+}
+
+type queueMetadataOutput struct {
+	ReadIndex       uint64   `yaml:"read_index"`
+	WriteIndex      uint64   `yaml:"write_index"`
+	DispatchedItems []uint64 `yaml:"dispatched_items,omitempty"`
+	ItemsSize       int64    `yaml:"items_size"`
+	BytesSize       int64    `yaml:"bytes_size"`
+}
+
+type queueItemOutput struct {
+	Index      uint64 `yaml:"index"`
+	Dispatched bool   `yaml:"dispatched,omitempty"`
+	Items      int    `yaml:"items"`
+	Bytes      int    `yaml:"bytes"`
+	Error      string `yaml:"error,omitempty"`
+	Data       string `yaml:"data,omitempty"`
+}
+
+type queueReplayOutput struct {
+	Sent      int    `yaml:"sent"`
+	Remaining int    `yaml:"remaining"`
+	Error     string `yaml:"error,omitempty"`
+}
+
+func (qc *queueContext) newOutput(md exporterhelper.QueueStorageMetadata) queueOutput {
+	return queueOutput{
+		Exporter:   qc.exporterID.String(),
+		Signal:     qc.signal.String(),
+		Storage:    qc.storageID.String(),
+		DeadLetter: qc.flags.deadLetter,
+		Metadata: queueMetadataOutput{
+			ReadIndex:       md.ReadIndex,
+			WriteIndex:      md.WriteIndex,
+			DispatchedItems: md.DispatchedItems,
+			ItemsSize:       md.ItemsSize,
+			BytesSize:       md.BytesSize,
+		},
+	}
+}
+
+func (qc *queueContext) inspect(ctx context.Context, out io.Writer) (err error) {
+	host, err := qc.startExtensions(ctx, false)
+	defer func() { err = multierr.Append(err, host.shutdown(ctx)) }()
+	if err != nil {
+		return err
+	}
+	client, err := qc.storageClient(ctx, host)
+	if err != nil {
+		return err
+	}
+	defer func() { err = multierr.Append(err, client.Close(ctx)) }()
+
+	reader, err := exporterhelper.NewQueueStorageReader(ctx, client)
+	if err != nil {
+		return err
+	}
+	md := reader.Metadata()
+	output := qc.newOutput(md)
+	dispatched := len(md.DispatchedItems)
+	for i, index := range reader.Indexes() {
+		itemOutput := queueItemOutput{Index: index, Dispatched: i < dispatched}
+		item, itemErr := qc.readItem(ctx, reader, index)
+		if itemErr != nil {
+			itemOutput.Error = itemErr.Error()
+		} else {
+			itemOutput.Items = item.items
+			itemOutput.Bytes = item.bytes
+			if qc.flags.includeData {
+				var data []byte
+				if data, itemErr = item.marshalJSON(); itemErr != nil {
+					itemOutput.Error = itemErr.Error()
+				}
+				itemOutput.Data = string(data)
+			}
+		}
+		output.Items = append(output.Items, itemOutput)
+	}
+	return printQueueOutput(out, output)
+}
+
+func (qc *queueContext) replay(ctx context.Context, out io.Writer) (err error) {
+	host, err := qc.startExtensions(ctx, true)
+	defer func() { err = multierr.Append(err, host.shutdown(ctx)) }()
+	if err != nil {
+		return err
+	}
+	client, err := qc.storageClient(ctx, host)
+	if err != nil {
+		return err
+	}
+	defer func() { err = multierr.Append(err, client.Close(ctx)) }()
+
+	reader, err := exporterhelper.NewQueueStorageReader(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	exp, err := qc.createReplayExporter(ctx)
+	if err != nil {
+		return err
+	}
+	if err = exp.Start(ctx, host); err != nil {
+		return fmt.Errorf("failed to start exporter %q: %w", qc.exporterID, err)
+	}
+
+	output := qc.newOutput(reader.Metadata())
+	output.Replay = &queueReplayOutput{}
+	for _, index := range reader.Indexes() {
+		item, itemErr := qc.readItem(ctx, reader, index)
+		if itemErr == nil {
+			itemErr = item.consume(exp)
+		}
+		if itemErr == nil {
+			itemErr = reader.Remove(ctx, index, int64(item.items), int64(item.bytes))
+		}
+		if itemErr != nil {
+			// Stop at the first failure, so the remaining items keep their order in the queue.
+			output.Replay.Remaining = len(reader.Indexes())
+			output.Replay.Error = fmt.Sprintf("item %d: %v", index, itemErr)
+			break
+		}
+		output.Replay.Sent++
+	}
+	// Shutdown the exporter before printing, so any data still buffered by the exporter is flushed.
+	err = exp.Shutdown(ctx)
+	output.Metadata = qc.newOutput(reader.Metadata()).Metadata
+	return multierr.Append(err, printQueueOutput(out, output))
+}
+
+// createReplayExporter creates the exporter with the sending queue and the dead letter storage disabled,
+// so the replayed items are sent synchronously and the storage is not used concurrently.
+func (qc *queueContext) createReplayExporter(ctx context.Context) (component.Component, error) {
+	factory, ok := qc.factories.Exporters[qc.exporterID.Type()]
+	if !ok {
+		return nil, fmt.Errorf("exporter factory for type %q is not available", qc.exporterID.Type())
+	}
+	conf := confmap.New()
+	if err := conf.Marshal(qc.cfg.Exporters[qc.exporterID], xconfmap.WithUnredacted()); err != nil {
+		return nil, fmt.Errorf("failed to marshal exporter config: %w", err)
+	}
+	overrides := map[string]any{}
+	for _, key := range []string{"sending_queue", "dead_letter"} {
+		if conf.IsSet(key) {
+			overrides[key] = map[string]any{"enabled": false}
+		}
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(overrides)); err != nil {
+		return nil, err
+	}
+	expCfg := factory.CreateDefaultConfig()
+	if err := conf.Unmarshal(expCfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exporter config: %w", err)
+	}
+
+	set := exporter.Settings{ID: qc.exporterID, TelemetrySettings: qc.telemetry, BuildInfo: qc.set.BuildInfo}
+	switch qc.signal {
+	case pipeline.SignalTraces:
+		return factory.CreateTraces(ctx, set, expCfg)
+	case pipeline.SignalMetrics:
+		return factory.CreateMetrics(ctx, set, expCfg)
+	default:
+		return factory.CreateLogs(ctx, set, expCfg)
+	}
+}
+
+// queueItem is a decoded item of a persistent queue.
+type queueItem struct {
+	items       int
+	bytes       int
+	consume     func(component.Component) error
+	marshalJSON func() ([]byte, error)
+}
+
+func (qc *queueContext) readItem(ctx context.Context, reader *exporterhelper.QueueStorageReader, index uint64) (queueItem, error) {
+	buf, err := reader.Get(ctx, index)
+	if err != nil {
+		return queueItem{}, err
+	}
+	if buf == nil {
+		return queueItem{}, errors.New("item not found in the storage")
+	}
+
+	switch qc.signal {
+	case pipeline.SignalTraces:
+		reqCtx, td, err := pdatareq.UnmarshalTraces(buf)
+		if errors.Is(err, pdatareq.ErrInvalidFormat) {
+			td, err = (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+		}
+		if err != nil {
+			return queueItem{}, err
+		}
+		return queueItem{
+			items:       td.SpanCount(),
+			bytes:       (&ptrace.ProtoMarshaler{}).TracesSize(td),
+			consume:     func(exp component.Component) error { return exp.(consumer.Traces).ConsumeTraces(reqCtx, td) },
+			marshalJSON: func() ([]byte, error) { return (&ptrace.JSONMarshaler{}).MarshalTraces(td) },
+		}, nil
+	case pipeline.SignalMetrics:
+		reqCtx, md, err := pdatareq.UnmarshalMetrics(buf)
+		if errors.Is(err, pdatareq.ErrInvalidFormat) {
+			md, err = (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+		}
+		if err != nil {
+			return queueItem{}, err
+		}
+		return queueItem{
+			items:       md.DataPointCount(),
+			bytes:       (&pmetric.ProtoMarshaler{}).MetricsSize(md),
+			consume:     func(exp component.Component) error { return exp.(consumer.Metrics).ConsumeMetrics(reqCtx, md) },
+			marshalJSON: func() ([]byte, error) { return (&pmetric.JSONMarshaler{}).MarshalMetrics(md) },
+		}, nil
+	default:
+		reqCtx, ld, err := pdatareq.UnmarshalLogs(buf)
+		if errors.Is(err, pdatareq.ErrInvalidFormat) {
+			ld, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(buf)
+		}
+		if err != nil {
+			return queueItem{}, err
+		}
+		return queueItem{
+			items:       ld.LogRecordCount(),
+			bytes:       (&plog.ProtoMarshaler{}).LogsSize(ld),
+			consume:     func(exp component.Component) error { return exp.(consumer.Logs).ConsumeLogs(reqCtx, ld) },
+			marshalJSON: func() ([]byte, error) { return (&plog.JSONMarshaler{}).MarshalLogs(ld) },
+		}, nil
+	}
+}
+
+func printQueueOutput(out io.Writer, output queueOutput) error {
+	yamlData, err := yaml.Marshal(output)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, string(yamlData))
+	return nil
+}
+
+// queueHost is the component.Host used to run extensions offline.
+type queueHost struct {
+	extensions map[component.ID]component.Component
+	order      []component.ID
+}
+
+func (h *queueHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// shutdown stops the started extensions in reverse order.
+func (h *queueHost) shutdown(ctx context.Context) error {
+	var errs error
+	for i := len(h.order) - 1; i >= 0; i-- {
+		errs = multierr.Append(errs, h.extensions[h.order[i]].Shutdown(ctx))
+	}
+	return errs
+}
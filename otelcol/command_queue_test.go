@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	memStorageType = component.MustNewType("memstorage")
+	queuedType     = component.MustNewType("queued")
+)
+
+type queuedExporterConfig struct {
+	QueueConfig      configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	DeadLetterConfig configoptional.Optional[exporterhelper.DeadLetterConfig] `mapstructure:"dead_letter"`
+}
+
+// queueTestEnv holds the state shared between the components created by queueTestFactories.
+type queueTestEnv struct {
+	storage *memStorage
+	sink    *consumertest.TracesSink
+	pushErr error
+}
+
+func (env *queueTestEnv) factories() (Factories, error) {
+	factories, err := nopFactories()
+	if err != nil {
+		return Factories{}, err
+	}
+	factories.Extensions[memStorageType] = extension.NewFactory(memStorageType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return env.storage, nil
+		}, component.StabilityLevelDevelopment)
+	factories.Exporters[queuedType] = exporter.NewFactory(queuedType,
+		func() component.Config {
+			return &queuedExporterConfig{
+				QueueConfig:      configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+				DeadLetterConfig: configoptional.Default(exporterhelper.NewDefaultDeadLetterConfig()),
+			}
+		},
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			qCfg := cfg.(*queuedExporterConfig)
+			return exporterhelper.NewTraces(ctx, set, cfg,
+				func(ctx context.Context, td ptrace.Traces) error {
+					if env.pushErr != nil {
+						return env.pushErr
+					}
+					return env.sink.ConsumeTraces(ctx, td)
+				},
+				exporterhelper.WithQueue(qCfg.QueueConfig),
+				exporterhelper.WithDeadLetter(qCfg.DeadLetterConfig))
+		}, component.StabilityLevelDevelopment))
+	return factories, nil
+}
+
+func (env *queueTestEnv) runQueueCommand(t *testing.T, args ...string) (map[string]any, error) {
+	filePath := filepath.Join("testdata", "otelcol-queue.yaml")
+	set := CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              env.factories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{"file:" + filePath}),
+	}
+	cmd := newQueueSubCommand(set, flags(featuregate.GlobalRegistry()))
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		return nil, err
+	}
+	var output map[string]any
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &output))
+	return output, nil
+}
+
+// storeDeadLetter stores two requests of one span in the dead letter storage of the queued exporter.
+func (env *queueTestEnv) storeDeadLetter(t *testing.T) {
+	factories, err := env.factories()
+	require.NoError(t, err)
+	cfg := factories.Exporters[queuedType].CreateDefaultConfig().(*queuedExporterConfig)
+	cfg.QueueConfig = configoptional.None[exporterhelper.QueueBatchConfig]()
+	dlCfg := exporterhelper.NewDefaultDeadLetterConfig()
+	dlCfg.StorageID = component.NewID(memStorageType)
+	cfg.DeadLetterConfig = configoptional.Some(dlCfg)
+	env.pushErr = consumererror.NewPermanent(errors.New("bad backend"))
+	set := exportertest.NewNopSettings(queuedType)
+	set.ID = component.NewID(queuedType)
+	exp, err := factories.Exporters[queuedType].CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), &queueHost{extensions: map[component.ID]component.Component{dlCfg.StorageID: env.storage}}))
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.Shutdown(context.Background()))
+	env.pushErr = nil
+}
+
+func TestQueueSubCommandDeadLetter(t *testing.T) {
+	env := &queueTestEnv{storage: newMemStorage(), sink: new(consumertest.TracesSink)}
+	env.storeDeadLetter(t)
+	env.pushErr = consumererror.NewPermanent(errors.New("bad backend"))
+
+	output, err := env.runQueueCommand(t, "inspect", "--exporter", "queued", "--dead-letter", "--include-data")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", output["exporter"])
+	assert.Equal(t, "memstorage", output["storage"])
+	assert.Equal(t, true, output["dead_letter"])
+	assert.Equal(t, 2, output["metadata"].(map[string]any)["write_index"])
+	items := output["items"].([]any)
+	require.Len(t, items, 2)
+	assert.Equal(t, 1, items[0].(map[string]any)["items"])
+	assert.Contains(t, items[0].(map[string]any)["data"], `"name":"span"`)
+
+	// The backend is still failing, nothing is removed.
+	output, err = env.runQueueCommand(t, "replay", "--exporter", "queued", "--dead-letter")
+	require.NoError(t, err)
+	assert.Equal(t, 0, output["replay"].(map[string]any)["sent"])
+	assert.Equal(t, 2, output["replay"].(map[string]any)["remaining"])
+	assert.Contains(t, output["replay"].(map[string]any)["error"], "bad backend")
+
+	env.pushErr = nil
+	output, err = env.runQueueCommand(t, "replay", "--exporter", "queued", "--dead-letter")
+	require.NoError(t, err)
+	assert.Equal(t, 2, output["replay"].(map[string]any)["sent"])
+	assert.Equal(t, 2, output["metadata"].(map[string]any)["read_index"])
+	assert.Equal(t, 2, env.sink.SpanCount())
+
+	output, err = env.runQueueCommand(t, "inspect", "--exporter", "queued", "--dead-letter")
+	require.NoError(t, err)
+	assert.Nil(t, output["items"])
+}
+
+func TestQueueSubCommandCorruptedItem(t *testing.T) {
+	env := &queueTestEnv{storage: newMemStorage(), sink: new(consumertest.TracesSink)}
+	env.storeDeadLetter(t)
+	env.storage.st["Exporter/queued/traces_dead_letter/0"] = []byte("corrupted")
+
+	output, err := env.runQueueCommand(t, "inspect", "--exporter", "queued", "--dead-letter", "--include-data")
+	require.NoError(t, err)
+	items := output["items"].([]any)
+	require.Len(t, items, 2)
+	assert.NotEmpty(t, items[0].(map[string]any)["error"])
+	assert.Equal(t, 0, items[0].(map[string]any)["items"])
+	assert.Equal(t, 1, items[1].(map[string]any)["items"])
+
+	// The replay stops at the corrupted item, so the items keep their order.
+	output, err = env.runQueueCommand(t, "replay", "--exporter", "queued", "--dead-letter")
+	require.NoError(t, err)
+	assert.Equal(t, 0, output["replay"].(map[string]any)["sent"])
+	assert.Equal(t, 2, output["replay"].(map[string]any)["remaining"])
+	assert.Contains(t, output["replay"].(map[string]any)["error"], "item 0: ")
+	assert.Equal(t, 0, env.sink.SpanCount())
+}
+
+func TestQueueSubCommandSendingQueue(t *testing.T) {
+	env := &queueTestEnv{storage: newMemStorage(), sink: new(consumertest.TracesSink)}
+	output, err := env.runQueueCommand(t, "inspect", "--exporter", "queued", "--signal", "traces")
+	require.NoError(t, err)
+	assert.Equal(t, false, output["dead_letter"])
+	assert.Equal(t, 0, output["metadata"].(map[string]any)["write_index"])
+}
+
+func TestQueueSubCommandErrors(t *testing.T) {
+	env := &queueTestEnv{storage: newMemStorage(), sink: new(consumertest.TracesSink)}
+	_, err := env.runQueueCommand(t, "inspect")
+	require.EqualError(t, err, "the --exporter flag is required")
+	_, err = env.runQueueCommand(t, "inspect", "--exporter", "queued", "--signal", "profiles")
+	require.EqualError(t, err, `unsupported signal "profiles": signals are: traces, metrics, logs`)
+	_, err = env.runQueueCommand(t, "inspect", "--exporter", "unknown")
+	require.EqualError(t, err, `exporter "unknown" is not configured`)
+	_, err = env.runQueueCommand(t, "inspect", "--exporter", "nop")
+	require.EqualError(t, err, `exporter "nop": no persistent sending queue configured`)
+	_, err = env.runQueueCommand(t, "replay", "--exporter", "nop", "--dead-letter")
+	require.EqualError(t, err, `exporter "nop": no dead letter storage configured`)
+}
+
+// memStorage is an in-memory storage extension, the data survives the extension restarts.
+type memStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu sync.Mutex
+	st map[string][]byte
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{st: map[string][]byte{}}
+}
+
+func (m *memStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	return &memStorageClient{storage: m, prefix: kind.String() + "/" + id.String() + "/" + name + "/"}, nil
+}
+
+type memStorageClient struct {
+	storage *memStorage
+	prefix  string
+}
+
+func (c *memStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *memStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *memStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *memStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.storage.mu.Lock()
+	defer c.storage.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.storage.st[c.prefix+op.Key]
+		case storage.Set:
+			c.storage.st[c.prefix+op.Key] = op.Value
+		case storage.Delete:
+			delete(c.storage.st, c.prefix+op.Key)
+		}
+	}
+	return nil
+}
+
+func (*memStorageClient) Close(context.Context) error {
+	return nil
+}
+
+var _ storage.Extension = (*memStorage)(nil)
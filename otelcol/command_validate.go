@@ -7,14 +7,22 @@ import (
 	"flag"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
 )
 
 // newValidateSubCommand constructs a new validate sub command using the given CollectorSettings.
 func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var schema bool
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates the config without running the collector",
-		Args:  cobra.ExactArgs(0),
+		Long: `Validates the config without running the collector.
+
+The resolved configuration is first validated against the schema of the components,
+derived from their default configuration, and all the violations are reported with the
+path of their key, e.g. the unknown keys and the values of a wrong type. Use
+--schema=false to skip this check.`,
+		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
 				return err
@@ -23,9 +31,12 @@ func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.
 			if err != nil {
 				return err
 			}
-			return col.DryRun(cmd.Context())
+			err = col.dryRun(cmd.Context(), schema)
+			return multierr.Append(err, col.configProvider.Shutdown(cmd.Context()))
 		},
 	}
+	schemaHelp := "Validate the configuration against the schema of the components: true (default), false"
+	validateCmd.Flags().BoolVar(&schema, "schema", true, schemaHelp)
 	validateCmd.Flags().AddGoFlagSet(flagSet)
 	return validateCmd
 }
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -33,5 +35,32 @@ func TestValidateSubCommandInvalidComponents(t *testing.T) {
 		},
 	}}, flags(featuregate.GlobalRegistry()))
 	err := cmd.Execute()
+	require.ErrorContains(t, err, "processors::nosuchprocessor: unknown component type")
+
+	// Without the schema validation, the error is reported by the unmarshalling of the configuration.
+	cmd.SetArgs([]string{"--schema=false"})
+	err = cmd.Execute()
 	require.ErrorContains(t, err, "unknown type: \"nosuchprocessor\"")
 }
+
+func TestValidateSubCommandSchema(t *testing.T) {
+	filePath := filepath.Join("testdata", "otelcol-invalid-schema.yaml")
+	cmd := newValidateSubCommand(CollectorSettings{Factories: nopFactories, ConfigProviderSettings: ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:              []string{filePath},
+			ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+			DefaultScheme:     "file",
+		},
+	}}, flags(featuregate.GlobalRegistry()))
+	err := cmd.Execute()
+	require.Error(t, err)
+	// All the violations are reported, with the source of their key.
+	for _, violation := range []string{
+		"processors::nosuchprocessor: unknown component type (processors::nosuchprocessor set in file:" + filePath + ":7)",
+		"receivers::nop::endpoint: unknown key (receivers::nop::endpoint set in file:" + filePath + ":3)",
+		"service::extensions: invalid type object, expected array or string",
+		`service::pipelines::traces::receivers[1]: invalid value "nop/": in "nop/" id: the part after / should not be empty`,
+	} {
+		assert.ErrorContains(t, err, violation)
+	}
+}
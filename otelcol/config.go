@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
@@ -67,14 +68,14 @@ func (cfg *Config) Validate() error {
 	// Validate the connector configuration.
 	for connID := range cfg.Connectors {
 		if _, ok := cfg.Exporters[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q exporter and %q connector. "+
+			return confmap.NewKeyError("connectors::"+connID.String(), fmt.Errorf("connectors::%s: ambiguous ID: Found both %q exporter and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, connID, connID, connID, connID.String()+"/connector"))
 		}
 		if _, ok := cfg.Receivers[connID]; ok {
-			return fmt.Errorf("connectors::%s: ambiguous ID: Found both %q receiver and %q connector. "+
+			return confmap.NewKeyError("connectors::"+connID.String(), fmt.Errorf("connectors::%s: ambiguous ID: Found both %q receiver and %q connector. "+
 				"Change one of the components' IDs to eliminate ambiguity (e.g. rename %q connector to %q)",
-				connID, connID, connID, connID, connID.String()+"/connector")
+				connID, connID, connID, connID, connID.String()+"/connector"))
 		}
 	}
 
@@ -82,7 +83,7 @@ func (cfg *Config) Validate() error {
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
 		if cfg.Extensions[ref] == nil {
-			return fmt.Errorf("service::extensions: references extension %q which is not configured", ref)
+			return confmap.NewKeyError("service::extensions", fmt.Errorf("service::extensions: references extension %q which is not configured", ref))
 		}
 	}
 
@@ -98,14 +99,16 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references receiver %q which is not configured", pipelineID.String(), ref)
+			return confmap.NewKeyError("service::pipelines::"+pipelineID.String(),
+				fmt.Errorf("service::pipelines::%s: references receiver %q which is not configured", pipelineID.String(), ref))
 		}
 
 		// Validate pipeline processor name references.
 		for _, ref := range pipeline.Processors {
 			// Check that the name referenced in the pipeline's processors exists in the top-level processors.
 			if cfg.Processors[ref] == nil {
-				return fmt.Errorf("service::pipelines::%s: references processor %q which is not configured", pipelineID.String(), ref)
+				return confmap.NewKeyError("service::pipelines::"+pipelineID.String(),
+					fmt.Errorf("service::pipelines::%s: references processor %q which is not configured", pipelineID.String(), ref))
 			}
 		}
 
@@ -118,7 +121,8 @@ func (cfg *Config) Validate() error {
 			if _, ok := cfg.Connectors[ref]; ok {
 				continue
 			}
-			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID.String(), ref)
+			return confmap.NewKeyError("service::pipelines::"+pipelineID.String(),
+				fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID.String(), ref))
 		}
 	}
 	return nil
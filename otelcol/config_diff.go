@@ -4,7 +4,9 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"slices"
@@ -12,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/configdiff"
 )
 
 // configFingerprint is hash-based snapshot of a configuration, used
@@ -19,8 +22,9 @@ import (
 // from the raw, pre-decode configuration map rather than decoded component.Config
 // values, since those are handed to live components and may be mutated in place.
 //
-// This at the moment is scoped to Phase 1 of the partial reload design, which only
-// supports receivers-only changes.
+// It covers Phase 1 of the partial reload design, the receivers-only changes,
+// and Phase 2, the changes of the processors, exporters, connectors and
+// pipelines.
 type configFingerprint struct {
 	// nonReceiverHash hashes every section a running component (other than a
 	// plain receiver) could mutate: service telemetry, extensions,
@@ -31,6 +35,15 @@ type configFingerprint struct {
 	// receiverHashes hashes each receiver's raw configuration, keyed by ID.
 	receiverHashes map[component.ID]uint64
 
+	// serviceHash hashes the sections the pipelines cannot be reloaded
+	// without: service telemetry and extensions. It must be identical
+	// between reloads for a change to qualify as a pipelines change.
+	serviceHash uint64
+
+	// componentHashes hashes each processor's, exporter's and connector's
+	// raw configuration, keyed by kind and ID.
+	componentHashes map[component.Kind]map[component.ID]uint64
+
 	// extensions is the ordered list of enabled extensions. It is plain
 	// plumbing, not a component.Config value handed to any component, so it
 	// is safe to compare directly rather than hash.
@@ -63,6 +76,22 @@ func fingerprintForPartialReload(conf *confmap.Conf, cfg *Config) (configFingerp
 		return configFingerprint{}, fmt.Errorf("could not fingerprint receiver configuration: %w", err)
 	}
 
+	serviceHash, err := hashSections(conf, "service::telemetry", "extensions")
+	if err != nil {
+		return configFingerprint{}, fmt.Errorf("could not fingerprint service configuration: %w", err)
+	}
+
+	componentHashes := make(map[component.Kind]map[component.ID]uint64, 3)
+	for kind, key := range map[component.Kind]string{
+		component.KindProcessor: "processors",
+		component.KindExporter:  "exporters",
+		component.KindConnector: "connectors",
+	} {
+		if componentHashes[kind], err = hashEntriesByID(conf, key); err != nil {
+			return configFingerprint{}, fmt.Errorf("could not fingerprint %s configuration: %w", key, err)
+		}
+	}
+
 	pipelineFingerprints := make(map[pipeline.ID]pipelineFingerprint, len(cfg.Service.Pipelines))
 	for pid, pipe := range cfg.Service.Pipelines {
 		pipelineFingerprints[pid] = pipelineFingerprint{
@@ -75,6 +104,8 @@ func fingerprintForPartialReload(conf *confmap.Conf, cfg *Config) (configFingerp
 	return configFingerprint{
 		nonReceiverHash: nonReceiverHash,
 		receiverHashes:  receiverHashes,
+		serviceHash:     serviceHash,
+		componentHashes: componentHashes,
 		extensions:      slices.Clone(cfg.Service.Extensions),
 		pipelines:       pipelineFingerprints,
 	}, nil
@@ -94,7 +125,7 @@ func hashSections(conf *confmap.Conf, keys ...string) (uint64, error) {
 
 // hashEntriesByID returns a stable hash of each entry under the given
 // top-level confmap.Conf key, keyed by parsed component.ID. Used for the
-// "receivers" section so each receiver can be compared individually.
+// component sections so each component can be compared individually.
 func hashEntriesByID(conf *confmap.Conf, key string) (map[component.ID]uint64, error) {
 	raw := conf.Get(key)
 	if raw == nil {
@@ -224,3 +255,104 @@ func changedReceivers(old, cur configFingerprint) map[component.ID]bool {
 	}
 	return changed
 }
+
+// serviceUnchanged returns true when the service telemetry, the extensions
+// and the list of enabled extensions are identical between old and cur, i.e.
+// the change only concerns the components of the pipelines and the pipelines
+// themselves, which can be reloaded without restarting the service.
+func serviceUnchanged(old, cur configFingerprint) bool {
+	return old.serviceHash == cur.serviceHash && slices.Equal(old.extensions, cur.extensions)
+}
+
+// changedComponents returns the set of receiver, processor, exporter and
+// connector IDs, by kind, present in both old and cur whose raw
+// configuration hash differs. Like changedReceivers, the added and removed
+// components are omitted: graph.UpdatePipelines derives those from the
+// pipelines on its own.
+func changedComponents(old, cur configFingerprint) map[component.Kind]map[component.ID]bool {
+	changed := map[component.Kind]map[component.ID]bool{
+		component.KindReceiver: changedReceivers(old, cur),
+	}
+	for kind, curHashes := range cur.componentHashes {
+		changed[kind] = make(map[component.ID]bool)
+		for id, curHash := range curHashes {
+			if oldHash, ok := old.componentHashes[kind][id]; ok && oldHash != curHash {
+				changed[kind][id] = true
+			}
+		}
+	}
+	return changed
+}
+
+// candidateScheme is the scheme of the candidate configurations posted to a running collector, see
+// candidateProvider.
+const candidateScheme = "candidate"
+
+// candidateProvider retrieves a candidate configuration posted to a running collector.
+type candidateProvider struct {
+	content []byte
+}
+
+func (p *candidateProvider) Retrieve(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return confmap.NewRetrievedFromYAML(p.content)
+}
+
+func (*candidateProvider) Scheme() string {
+	return candidateScheme
+}
+
+func (*candidateProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+// resolveValidConfig returns the raw configuration resolved from the URIs of the settings, after validating it.
+// The configuration is only resolved once, it is not watched.
+func resolveValidConfig(ctx context.Context, set ConfigProviderSettings, factories Factories) (_ *confmap.Conf, err error) {
+	cm, err := NewConfigProvider(set)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, cm.Shutdown(ctx)) }()
+
+	conf, err := cm.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := cm.unmarshal(conf, factories)
+	if err != nil {
+		return nil, err
+	}
+	if err = cm.withProvenance(confmap.Validate(cfg)); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// compareConfig compares the candidate configuration, as YAML, to the running configuration. The candidate is
+// posted to a running collector, so it is not resolved with the providers of the collector: they would let the
+// poster read the files and the environment of the collector, or send requests from it. The references to a
+// provider, e.g. ${env:NAME}, are rejected, the references without a scheme are kept as is.
+func (col *Collector) compareConfig(ctx context.Context, candidate []byte) (*configdiff.Diff, error) {
+	running := col.runningConf.Load()
+	if running == nil {
+		return nil, errors.New("the collector is not running")
+	}
+	factories, err := col.set.Factories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize factories: %w", err)
+	}
+
+	set := col.set.ConfigProviderSettings
+	set.ResolverSettings.URIs = []string{candidateScheme + ":"}
+	set.ResolverSettings.ProviderFactories = []confmap.ProviderFactory{
+		confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+			return &candidateProvider{content: candidate}
+		}),
+	}
+	set.ResolverSettings.DefaultScheme = ""
+	conf, err := resolveValidConfig(ctx, set, factories)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate configuration: %w", err)
+	}
+	return configdiff.Compare(running, conf)
+}
@@ -481,3 +481,47 @@ func TestChangedReceivers(t *testing.T) {
 	changed := changedReceivers(old, cur)
 	assert.Equal(t, map[component.ID]bool{component.MustNewID("otlp"): true}, changed)
 }
+
+func TestServiceUnchanged(t *testing.T) {
+	old := fingerprintFor(t, baseRawConf(), baseServiceConfig())
+
+	// Changing the components and the pipelines leaves the service unchanged.
+	newRaw := baseRawConf()
+	newRaw["processors"] = map[string]any{"batch": map[string]any{"timeout": "1s"}}
+	newRaw["exporters"] = map[string]any{"otlp": map[string]any{}, "debug": map[string]any{}}
+	newCfg := baseServiceConfig()
+	newCfg.Service.Pipelines[pipeline.NewID(pipeline.SignalLogs)] = &pipelines.PipelineConfig{
+		Receivers: []component.ID{component.MustNewID("otlp")},
+		Exporters: []component.ID{component.MustNewID("debug")},
+	}
+	assert.True(t, serviceUnchanged(old, fingerprintFor(t, newRaw, newCfg)))
+
+	newRaw = baseRawConf()
+	newRaw["service"] = map[string]any{"telemetry": map[string]any{"logs": map[string]any{"level": "debug"}}}
+	assert.False(t, serviceUnchanged(old, fingerprintFor(t, newRaw, baseServiceConfig())))
+
+	newRaw = baseRawConf()
+	newRaw["extensions"] = map[string]any{"zpages": map[string]any{}}
+	assert.False(t, serviceUnchanged(old, fingerprintFor(t, newRaw, baseServiceConfig())))
+
+	newCfg = baseServiceConfig()
+	newCfg.Service.Extensions = []component.ID{component.MustNewID("zpages")}
+	assert.False(t, serviceUnchanged(old, fingerprintFor(t, baseRawConf(), newCfg)))
+}
+
+func TestChangedComponents(t *testing.T) {
+	old := fingerprintFor(t, baseRawConf(), baseServiceConfig())
+
+	newRaw := baseRawConf()
+	newRaw["receivers"] = map[string]any{"otlp": map[string]any{"endpoint": "changed"}}
+	newRaw["processors"] = map[string]any{"batch": map[string]any{}, "memory_limiter": map[string]any{}}
+	newRaw["exporters"] = map[string]any{"otlp": map[string]any{"endpoint": "changed"}}
+	cur := fingerprintFor(t, newRaw, baseServiceConfig())
+
+	assert.Equal(t, map[component.Kind]map[component.ID]bool{
+		component.KindReceiver:  {component.MustNewID("otlp"): true},
+		component.KindProcessor: {},
+		component.KindExporter:  {component.MustNewID("otlp"): true},
+		component.KindConnector: {},
+	}, changedComponents(old, cur))
+}
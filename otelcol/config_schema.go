@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/service"
+)
+
+// configSchema returns the JSON schema of the configuration of a collector built with the factories. The schema
+// of every component is derived from its default configuration.
+func configSchema(factories Factories) *configschema.Schema {
+	svc := service.Config{}
+	if factories.Telemetry != nil {
+		svc.Telemetry = factories.Telemetry.CreateDefaultConfig()
+	}
+	return &configschema.Schema{
+		Schema: configschema.Version,
+		Title:  "OpenTelemetry Collector configuration",
+		Type:   []string{configschema.TypeObject},
+		Properties: map[string]*configschema.Schema{
+			"receivers":  componentsSchema(factories.Receivers),
+			"processors": componentsSchema(factories.Processors),
+			"exporters":  componentsSchema(factories.Exporters),
+			"connectors": componentsSchema(factories.Connectors),
+			"extensions": componentsSchema(factories.Extensions),
+			"service":    configschema.FromConfig(svc),
+			// The templates are expanded before the configuration is validated.
+			templatesKey: {Type: []string{configschema.TypeObject, configschema.TypeNull}},
+		},
+		AdditionalProperties: configschema.False(),
+	}
+}
+
+// componentsSchema returns the schema of a components section, the IDs of the components are made of the type
+// of their factory and an optional name.
+func componentsSchema[F component.Factory](factories map[component.Type]F) *configschema.Schema {
+	s := &configschema.Schema{
+		Type:                 []string{configschema.TypeObject, configschema.TypeNull},
+		PatternProperties:    map[string]*configschema.Schema{},
+		AdditionalProperties: configschema.False(),
+	}
+	s.AdditionalProperties.Description = "unknown component type"
+	for typ, factory := range factories {
+		s.PatternProperties["^"+regexp.QuoteMeta(typ.String())+"(?:/.+)?$"] = configschema.FromConfig(factory.CreateDefaultConfig())
+	}
+	return s
+}
+
+// validateConfigSchema validates the configuration against the schema of the factories, the violations
+// are reported with the path of their key.
+func validateConfigSchema(conf *confmap.Conf, factories Factories) error {
+	return configschema.Validate(configSchema(factories), xconfmap.ToStringMapRaw(conf))
+}
@@ -60,14 +60,37 @@ func (cm *ConfigProvider) Get(ctx context.Context, factories Factories) (*Config
 // getWithConf returns the service configuration along with the raw,
 // pre-decode confmap.Conf it was unmarshalled from.
 func (cm *ConfigProvider) getWithConf(ctx context.Context, factories Factories) (*Config, *confmap.Conf, error) {
+	conf, err := cm.resolve(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := cm.unmarshal(conf, factories)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, conf, nil
+}
+
+// resolve returns the raw configuration, with its templates instantiated.
+func (cm *ConfigProvider) resolve(ctx context.Context) (*confmap.Conf, error) {
 	conf, err := cm.mapResolver.Resolve(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
 	}
 
-	var cfg *configSettings
-	if cfg, err = unmarshal(conf, factories); err != nil {
-		return nil, nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
+	// The raw configuration is returned with its templates instantiated, as it is unmarshalled.
+	// The templates are only expanded here, unmarshal expects them to be instantiated.
+	if conf, err = expandTemplates(conf); err != nil {
+		return nil, fmt.Errorf("cannot expand the templates: %w", err)
+	}
+	return conf, nil
+}
+
+// unmarshal returns the service configuration unmarshalled from the raw configuration.
+func (cm *ConfigProvider) unmarshal(conf *confmap.Conf, factories Factories) (*Config, error) {
+	cfg, err := unmarshal(conf, factories)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", cm.withProvenance(err))
 	}
 
 	return &Config{
@@ -77,7 +100,13 @@ func (cm *ConfigProvider) getWithConf(ctx context.Context, factories Factories)
 		Connectors: cfg.Connectors.Configs(),
 		Extensions: cfg.Extensions.Configs(),
 		Service:    cfg.Service,
-	}, conf, nil
+	}, nil
+}
+
+// withProvenance annotates the configuration error with the source of the keys it references,
+// as recorded by the last Get call.
+func (cm *ConfigProvider) withProvenance(err error) error {
+	return withProvenance(err, cm.mapResolver.Provenance())
 }
 
 // Watch blocks until any configuration change was detected or an unrecoverable error
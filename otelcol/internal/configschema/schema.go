@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema derives the JSON schema of the configurations from their Go types, and validates the
+// configurations against it.
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Version is the JSON schema draft of the schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Schema is a JSON schema, draft 2020-12. A Schema without type accepts any value.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 []string           `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+
+	// textType is the type the string values are unmarshalled to, their value is valid if the type can be
+	// unmarshalled from it.
+	textType reflect.Type
+}
+
+// False returns the schema accepting no value, e.g. the additional properties of a closed object.
+func False() *Schema {
+	return &Schema{Not: &Schema{}}
+}
+
+func (s *Schema) isFalse() bool {
+	return s != nil && s.Not != nil && s.Not.Type == nil && s.Not.Enum == nil && s.Not.Properties == nil &&
+		s.Not.PatternProperties == nil && s.Not.AdditionalProperties == nil && s.Not.Items == nil && s.Not.Not == nil
+}
+
+var (
+	durationType          = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType   = reflect.TypeFor[encoding.TextUnmarshaler]()
+	unmarshalerType       = reflect.TypeFor[confmap.Unmarshaler]()
+	scalarUnmarshalerType = reflect.TypeFor[confmap.ScalarUnmarshaler]()
+	optionalPkgPath       = reflect.TypeFor[configoptional.Optional[any]]().PkgPath()
+)
+
+// FromConfig returns the schema of the configuration, derived from its type: the keys are the mapstructure
+// names of the fields, and the values have the types they are decoded from. The fields of an interface type
+// have the schema of their value in the configuration, e.g. the default configuration of a component.
+//
+// The schema is permissive where the decoding is customized, e.g. the types implementing confmap.Unmarshaler
+// accept any value, and every value can be null. The configoptional.Optional values have the schema of the
+// value they wrap, with the enabled key for the sections.
+func FromConfig(cfg any) *Schema {
+	return fromValue(reflect.ValueOf(cfg), map[reflect.Type]bool{})
+}
+
+func fromValue(v reflect.Value, visiting map[reflect.Type]bool) *Schema {
+	if !v.IsValid() {
+		return &Schema{}
+	}
+	t := v.Type()
+	if visiting[t] {
+		// Recursive types accept any value.
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return fromValue(reflect.New(t.Elem()).Elem(), visiting)
+		}
+		return fromValue(v.Elem(), visiting)
+	case reflect.Interface:
+		if v.IsNil() {
+			return &Schema{}
+		}
+		return fromValue(v.Elem(), visiting)
+	}
+
+	switch {
+	case isOptional(t):
+		s := fromValue(v.FieldByName("value"), visiting)
+		if s.Properties != nil {
+			// The sections can be disabled, see configoptional.Optional.Unmarshal.
+			s.Properties["enabled"] = newSchema(TypeBoolean)
+		}
+		return s
+	case implements(t, unmarshalerType) || implements(t, scalarUnmarshalerType):
+		return &Schema{}
+	case t == durationType:
+		return newSchema(TypeString, TypeInteger)
+	case implements(t, textUnmarshalerType):
+		s := newSchema(TypeString)
+		if kind := kindType(t.Kind()); kind != "" && kind != TypeString {
+			s.Type = append(s.Type, kind)
+		}
+		s.textType = t
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		visiting[t] = true
+		defer delete(visiting, t)
+		s := newSchema(TypeObject)
+		s.Properties = map[string]*Schema{}
+		s.AdditionalProperties = False()
+		if !addFields(s, v, visiting) {
+			return &Schema{}
+		}
+		return s
+	case reflect.Map:
+		s := newSchema(TypeObject)
+		s.AdditionalProperties = fromValue(reflect.New(t.Elem()).Elem(), visiting)
+		return s
+	case reflect.Slice, reflect.Array:
+		// The slices can also be decoded from a comma-separated string.
+		s := newSchema(TypeArray, TypeString)
+		s.Items = fromValue(reflect.New(t.Elem()).Elem(), visiting)
+		return s
+	}
+	if kind := kindType(t.Kind()); kind != "" {
+		return newSchema(kind)
+	}
+	return &Schema{}
+}
+
+// addFields adds the fields of the struct to the properties of the schema, it returns false if the struct
+// accepts any key.
+func addFields(s *Schema, v reflect.Value, visiting map[reflect.Type]bool) bool {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup(confmap.MapstructureTag)
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		switch {
+		case hasOption(opts, "remain"):
+			s.AdditionalProperties = &Schema{}
+			continue
+		case hasOption(opts, "squash"):
+			embedded := fromValue(v.Field(i), visiting)
+			if embedded.Type == nil {
+				return false
+			}
+			for key, prop := range embedded.Properties {
+				s.Properties[key] = prop
+			}
+			if !embedded.AdditionalProperties.isFalse() {
+				s.AdditionalProperties = &Schema{}
+			}
+			continue
+		}
+		if !hasTag || name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = fromValue(v.Field(i), visiting)
+	}
+	return true
+}
+
+func newSchema(types ...string) *Schema {
+	// Every value can be null, e.g. a component without configuration.
+	return &Schema{Type: append(types, TypeNull)}
+}
+
+// isOptional reports whether the type is an instance of configoptional.Optional.
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == optionalPkgPath && strings.HasPrefix(t.Name(), "Optional[")
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func hasOption(opts, option string) bool {
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func kindType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return TypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return TypeNumber
+	case reflect.String:
+		return TypeString
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "basic":
+		*l = 1
+	case "detailed":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type customConfig struct {
+	Value string `mapstructure:"value"`
+}
+
+func (*customConfig) Unmarshal(*confmap.Conf) error {
+	return nil
+}
+
+type embeddedConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type recursiveConfig struct {
+	Next *recursiveConfig `mapstructure:"next"`
+}
+
+type optionalNestedConfig struct {
+	Inner configoptional.Optional[embeddedConfig] `mapstructure:"inner"`
+}
+
+type testConfig struct {
+	embeddedConfig `mapstructure:",squash"`
+
+	Enabled   bool              `mapstructure:"enabled"`
+	Count     int               `mapstructure:"count"`
+	Ratio     float64           `mapstructure:"ratio"`
+	Timeout   time.Duration     `mapstructure:"timeout"`
+	Level     level             `mapstructure:"level"`
+	ID        *component.ID     `mapstructure:"id"`
+	Headers   map[string]string `mapstructure:"headers"`
+	Tags      []string          `mapstructure:"tags"`
+	Custom    customConfig      `mapstructure:"custom"`
+	Any       any               `mapstructure:"any"`
+	Recursive recursiveConfig   `mapstructure:"recursive"`
+	Ignored   string            `mapstructure:"-"`
+	Untagged  string
+	unexposed string
+}
+
+func TestFromConfig(t *testing.T) {
+	s := FromConfig(&testConfig{Any: &embeddedConfig{}})
+	assert.Equal(t, []string{TypeObject, TypeNull}, s.Type)
+	assert.True(t, s.AdditionalProperties.isFalse())
+	assert.ElementsMatch(t, []string{
+		"endpoint", "enabled", "count", "ratio", "timeout", "level", "id", "headers", "tags", "custom", "any", "recursive", "Untagged",
+	}, keys(s.Properties))
+
+	assert.Equal(t, []string{TypeString, TypeNull}, s.Properties["endpoint"].Type)
+	assert.Equal(t, []string{TypeBoolean, TypeNull}, s.Properties["enabled"].Type)
+	assert.Equal(t, []string{TypeInteger, TypeNull}, s.Properties["count"].Type)
+	assert.Equal(t, []string{TypeNumber, TypeNull}, s.Properties["ratio"].Type)
+	assert.Equal(t, []string{TypeString, TypeInteger, TypeNull}, s.Properties["timeout"].Type)
+	assert.Equal(t, []string{TypeString, TypeNull, TypeInteger}, s.Properties["level"].Type)
+	assert.Equal(t, []string{TypeString, TypeNull}, s.Properties["id"].Type)
+	assert.Equal(t, []string{TypeObject, TypeNull}, s.Properties["headers"].Type)
+	assert.Equal(t, []string{TypeString, TypeNull}, s.Properties["headers"].AdditionalProperties.Type)
+	assert.Equal(t, []string{TypeArray, TypeString, TypeNull}, s.Properties["tags"].Type)
+	assert.Equal(t, []string{TypeString, TypeNull}, s.Properties["tags"].Items.Type)
+	// The configurations with a custom unmarshaler accept any value.
+	assert.Equal(t, &Schema{}, s.Properties["custom"])
+	// The interfaces have the schema of their value.
+	assert.Contains(t, s.Properties["any"].Properties, "endpoint")
+	// The recursive types accept any value.
+	assert.Equal(t, &Schema{}, s.Properties["recursive"].Properties["next"])
+}
+
+func TestFromConfigRemain(t *testing.T) {
+	type remainConfig struct {
+		Name  string         `mapstructure:"name"`
+		Other map[string]any `mapstructure:",remain"`
+	}
+	s := FromConfig(remainConfig{})
+	assert.Contains(t, s.Properties, "name")
+	assert.Equal(t, &Schema{}, s.AdditionalProperties)
+}
+
+func TestFromConfigOptional(t *testing.T) {
+	type optionalConfig struct {
+		Section configoptional.Optional[embeddedConfig]       `mapstructure:"section"`
+		Default configoptional.Optional[embeddedConfig]       `mapstructure:"default"`
+		Pointer configoptional.Optional[*embeddedConfig]      `mapstructure:"pointer"`
+		Scalar  configoptional.Optional[int]                  `mapstructure:"scalar"`
+		Nested  configoptional.Optional[optionalNestedConfig] `mapstructure:"nested"`
+	}
+	s := FromConfig(optionalConfig{Default: configoptional.Default(embeddedConfig{Endpoint: "localhost:4317"})})
+	// The sections have the schema of their value, and can be disabled.
+	for _, key := range []string{"section", "default", "pointer"} {
+		assert.ElementsMatch(t, []string{"endpoint", "enabled"}, keys(s.Properties[key].Properties), key)
+		assert.True(t, s.Properties[key].AdditionalProperties.isFalse(), key)
+		assert.Equal(t, []string{TypeBoolean, TypeNull}, s.Properties[key].Properties["enabled"].Type, key)
+	}
+	assert.Equal(t, []string{TypeInteger, TypeNull}, s.Properties["scalar"].Type)
+	assert.Contains(t, s.Properties["nested"].Properties["inner"].Properties, "endpoint")
+}
+
+func TestSchemaJSON(t *testing.T) {
+	s := FromConfig(struct {
+		Endpoint string `mapstructure:"endpoint"`
+	}{})
+	s.Schema = Version
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": ["object", "null"],
+		"properties": {"endpoint": {"type": ["string", "null"]}},
+		"additionalProperties": {"not": {}}
+	}`, string(data))
+}
+
+func keys(m map[string]*Schema) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+// Validate validates the configuration against the schema, and returns all the violations joined.
+// Every violation is prefixed by the path of its key, e.g. "receivers::otlp::protocols: unknown key", and
+// carries it, see confmap.ErrorKeys.
+// The configuration is a raw map, see xconfmap.ToStringMapRaw, the expanded values are valid if either
+// their value or their original representation is valid.
+func Validate(s *Schema, data map[string]any) error {
+	v := &validator{}
+	v.validate("", "", s, data)
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+// errorf reports a violation, path is the path of its key as displayed, e.g. "exporters[0]", and key the
+// path of its key in the configuration, e.g. "exporters::0".
+func (v *validator) errorf(path, key, format string, args ...any) {
+	if path == "" {
+		v.errs = append(v.errs, fmt.Errorf(format, args...))
+		return
+	}
+	v.errs = append(v.errs, confmap.NewKeyError(key, fmt.Errorf("%s: "+format, append([]any{path}, args...)...)))
+}
+
+func (v *validator) validate(path, key string, s *Schema, value any) {
+	if s == nil {
+		return
+	}
+	if expanded, ok := value.(xconfmap.ExpandedValue); ok {
+		// The original representation is used for the string values.
+		probe := &validator{}
+		if probe.validate(path, key, s, expanded.Value); len(probe.errs) > 0 {
+			v.validate(path, key, s, expanded.Original)
+		}
+		return
+	}
+	if s.isFalse() {
+		v.errorf(path, key, "not allowed")
+		return
+	}
+	if len(s.Type) > 0 && !hasType(s, typeOf(value)) {
+		v.errorf(path, key, "invalid type %s, expected %s", describe(value), joinTypes(s.Type))
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		v.errorf(path, key, "invalid value %v, expected one of %v", value, s.Enum)
+		return
+	}
+
+	switch val := value.(type) {
+	case string:
+		if s.textType != nil {
+			target := reflect.New(s.textType).Interface().(encoding.TextUnmarshaler)
+			if err := target.UnmarshalText([]byte(val)); err != nil {
+				v.errorf(path, key, "invalid value %q: %v", val, err)
+			}
+		}
+	case map[string]any:
+		v.validateObject(path, key, s, val)
+	case []any:
+		for i, item := range val {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), key+confmap.KeyDelimiter+strconv.Itoa(i), s.Items, item)
+		}
+	}
+}
+
+func (v *validator) validateObject(path, key string, s *Schema, data map[string]any) {
+	// The keys are validated in order, for the violations to be reported deterministically.
+	for _, name := range slices.Sorted(maps.Keys(data)) {
+		namePath, nameKey := name, name
+		if path != "" {
+			namePath = path + confmap.KeyDelimiter + name
+		}
+		if key != "" {
+			nameKey = key + confmap.KeyDelimiter + name
+		}
+		if prop, ok := s.Properties[name]; ok {
+			v.validate(namePath, nameKey, prop, data[name])
+			continue
+		}
+		if pattern, ok := matchPattern(s.PatternProperties, name); ok {
+			v.validate(namePath, nameKey, pattern, data[name])
+			continue
+		}
+		if s.AdditionalProperties.isFalse() {
+			// The description of the schema of the unknown keys describes them, e.g. "unknown component type".
+			if s.AdditionalProperties.Description != "" {
+				v.errorf(namePath, nameKey, "%s", s.AdditionalProperties.Description)
+			} else {
+				v.errorf(namePath, nameKey, "unknown key")
+			}
+			continue
+		}
+		v.validate(namePath, nameKey, s.AdditionalProperties, data[name])
+	}
+}
+
+func matchPattern(patterns map[string]*Schema, key string) (*Schema, bool) {
+	for _, pattern := range slices.Sorted(maps.Keys(patterns)) {
+		if regexp.MustCompile(pattern).MatchString(key) {
+			return patterns[pattern], true
+		}
+	}
+	return nil, false
+}
+
+// hasType returns whether the schema accepts the type, the integers are numbers.
+func hasType(s *Schema, typ string) bool {
+	return slices.Contains(s.Type, typ) || (typ == TypeInteger && slices.Contains(s.Type, TypeNumber))
+}
+
+// typeOf returns the JSON type of a value of a configuration.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return TypeNull
+	case map[string]any:
+		return TypeObject
+	case []any:
+		return TypeArray
+	case string, time.Time:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return TypeInteger
+	case float32, float64:
+		return TypeNumber
+	}
+	return ""
+}
+
+func describe(value any) string {
+	switch typ := typeOf(value); typ {
+	case TypeObject, TypeArray, TypeNull:
+		return typ
+	default:
+		return fmt.Sprintf("%s %v", typ, value)
+	}
+}
+
+// joinTypes returns the expected types, the null type is omitted as it is accepted by every schema.
+func joinTypes(types []string) string {
+	var expected string
+	for _, typ := range types {
+		if typ == TypeNull {
+			continue
+		}
+		if expected != "" {
+			expected += " or "
+		}
+		expected += typ
+	}
+	return expected
+}
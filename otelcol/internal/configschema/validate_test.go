@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+func TestValidate(t *testing.T) {
+	s := FromConfig(&testConfig{})
+	tests := []struct {
+		name        string
+		data        map[string]any
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			data: map[string]any{
+				"endpoint": "localhost:4317",
+				"enabled":  true,
+				"count":    3,
+				"ratio":    1,
+				"timeout":  "5s",
+				"level":    "detailed",
+				"id":       "otlp/2",
+				"headers":  map[string]any{"key": "value"},
+				"tags":     []any{"a", "b"},
+				"custom":   []any{"anything"},
+			},
+		},
+		{
+			name: "null_values",
+			data: map[string]any{"endpoint": nil, "headers": nil, "tags": nil},
+		},
+		{
+			name: "comma_separated_slice",
+			data: map[string]any{"tags": "a,b"},
+		},
+		{
+			name: "timestamp_string",
+			data: map[string]any{"endpoint": time.Now()},
+		},
+		{
+			name: "expanded_value",
+			// The original representation of the expanded values is used for the string fields.
+			data: map[string]any{"endpoint": xconfmap.ExpandedValue{Value: 4317, Original: "4317"}},
+		},
+		{
+			name:        "expanded_value_invalid",
+			data:        map[string]any{"count": xconfmap.ExpandedValue{Value: "three", Original: "three"}},
+			expectedErr: "count: invalid type string three, expected integer",
+		},
+		{
+			name:        "unknown_key",
+			data:        map[string]any{"endpoint": "localhost:4317", "endpont": "localhost:4317"},
+			expectedErr: "endpont: unknown key",
+		},
+		{
+			name:        "wrong_type",
+			data:        map[string]any{"count": "3"},
+			expectedErr: "count: invalid type string 3, expected integer",
+		},
+		{
+			name:        "wrong_type_object",
+			data:        map[string]any{"headers": []any{"key"}},
+			expectedErr: "headers: invalid type array, expected object",
+		},
+		{
+			name:        "invalid_text",
+			data:        map[string]any{"level": "verbose"},
+			expectedErr: `level: invalid value "verbose": unknown level`,
+		},
+		{
+			name:        "nested",
+			data:        map[string]any{"headers": map[string]any{"key": 1}, "tags": []any{"a", map[string]any{}}},
+			expectedErr: "headers::key: invalid type integer 1, expected string\ntags[1]: invalid type object, expected string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(s, tt.data)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidateAllViolations(t *testing.T) {
+	err := Validate(FromConfig(&testConfig{}), map[string]any{
+		"count":   true,
+		"enabled": "yes",
+		"unknown": 1,
+	})
+	assert.EqualError(t, err, "count: invalid type boolean true, expected integer\n"+
+		"enabled: invalid type string yes, expected boolean\n"+
+		"unknown: unknown key")
+}
+
+func TestValidateOptional(t *testing.T) {
+	s := FromConfig(&optionalNestedConfig{})
+	require.NoError(t, Validate(s, map[string]any{"inner": map[string]any{"endpoint": "localhost:4317"}}))
+	require.NoError(t, Validate(s, map[string]any{"inner": map[string]any{"enabled": false}}))
+	require.NoError(t, Validate(s, map[string]any{"inner": nil}))
+	// The keys of the optional sections are validated.
+	assert.EqualError(t, Validate(s, map[string]any{"inner": map[string]any{"endpointt": "localhost:4317"}}),
+		"inner::endpointt: unknown key")
+	assert.EqualError(t, Validate(s, map[string]any{"inner": map[string]any{"enabled": "no"}}),
+		"inner::enabled: invalid type string no, expected boolean")
+}
+
+func TestValidateErrorKeys(t *testing.T) {
+	err := Validate(FromConfig(&testConfig{}), map[string]any{
+		"headers": map[string]any{"key": 1},
+		"tags":    []any{"a", map[string]any{}},
+	})
+	// The violations carry the path of their key.
+	assert.Equal(t, []string{"headers::key", "tags::1"}, confmap.ErrorKeys(err))
+}
+
+func TestValidatePatternsAndEnum(t *testing.T) {
+	s := &Schema{
+		Type: []string{TypeObject},
+		PatternProperties: map[string]*Schema{
+			"^mode(/.+)?$": {Enum: []any{"append", "replace"}},
+		},
+		AdditionalProperties: &Schema{Not: &Schema{}, Description: "unknown component type"},
+	}
+	require.NoError(t, Validate(s, map[string]any{"mode": "append", "mode/2": "replace"}))
+	assert.EqualError(t, Validate(s, map[string]any{"mode": "merge", "other": nil}),
+		"mode: invalid value merge, expected one of [append replace]\nother: unknown component type")
+}
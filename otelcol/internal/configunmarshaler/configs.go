@@ -66,11 +66,11 @@ func (c *Configs[F]) Configs() map[component.ID]component.Config {
 
 func errorUnknownType(id component.ID, factories []component.Type) error {
 	if id.Type().String() == "logging" {
-		return errors.New("the logging exporter has been deprecated, use the debug exporter instead")
+		return confmap.NewKeyError(id.String(), errors.New("the logging exporter has been deprecated, use the debug exporter instead"))
 	}
-	return fmt.Errorf("unknown type: %q for id: %q (valid values: %v)", id.Type(), id, factories)
+	return confmap.NewKeyError(id.String(), fmt.Errorf("unknown type: %q for id: %q (valid values: %v)", id.Type(), id, factories))
 }
 
 func errorUnmarshalError(id component.ID, err error) error {
-	return fmt.Errorf("error reading configuration for %q: %w", id, err)
+	return confmap.NewKeyError(id.String(), fmt.Errorf("error reading configuration for %q: %w", id, err))
 }
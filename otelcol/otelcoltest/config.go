@@ -5,6 +5,7 @@ package otelcoltest // import "go.opentelemetry.io/collector/otelcol/otelcoltest
 
 import (
 	"context"
+	"errors"
 
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
@@ -37,7 +38,8 @@ func LoadConfig(fileName string, factories otelcol.Factories) (*otelcol.Config,
 	if err != nil {
 		return nil, err
 	}
-	return provider.Get(context.Background(), factories)
+	cfg, err := provider.Get(context.Background(), factories)
+	return cfg, errors.Join(err, provider.Shutdown(context.Background()))
 }
 
 // LoadConfigAndValidate loads a config from the file, and validates the configuration.
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// defaultProvenance is the provenance of the values which are not set by the configuration.
+const defaultProvenance = "default"
+
+// withProvenance annotates the configuration error with the source of the keys it references,
+// the joined errors are annotated individually.
+func withProvenance(err error, provenance map[string]confmap.Provenance) error {
+	if err == nil || len(provenance) == 0 {
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		annotated := make([]error, len(errs))
+		for i, e := range errs {
+			annotated[i] = withProvenance(e, provenance)
+		}
+		return errors.Join(annotated...)
+	}
+
+	keys := errorKeys(err, provenance)
+	if len(keys) == 0 {
+		return err
+	}
+	sources := make([]string, len(keys))
+	for i, key := range keys {
+		sources[i] = key + " set in " + provenance[key].String()
+	}
+	return &provenanceError{err: err, sources: sources}
+}
+
+// provenanceError is a configuration error annotated with the source of the keys it references.
+type provenanceError struct {
+	err     error
+	sources []string
+}
+
+func (pe *provenanceError) Error() string {
+	return pe.err.Error() + " (" + strings.Join(pe.sources, ", ") + ")"
+}
+
+func (pe *provenanceError) Unwrap() error {
+	return pe.err
+}
+
+// errorKeys returns the keys referenced by the error, see confmap.ErrorKeys. A referenced key without a
+// provenance, e.g. a list element, is replaced by its deepest parent with one, and the keys whose children
+// are referenced are dropped.
+func errorKeys(err error, provenance map[string]confmap.Provenance) []string {
+	var keys []string
+	for _, key := range confmap.ErrorKeys(err) {
+		if key, ok := knownKey(key, provenance); ok && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	referenced := slices.Clone(keys)
+	return slices.DeleteFunc(keys, func(key string) bool {
+		return slices.ContainsFunc(referenced, func(other string) bool {
+			return strings.HasPrefix(other, key+confmap.KeyDelimiter)
+		})
+	})
+}
+
+// knownKey returns the key, or its deepest parent, with a provenance.
+func knownKey(key string, provenance map[string]confmap.Provenance) (string, bool) {
+	for {
+		if _, ok := provenance[key]; ok {
+			return key, true
+		}
+		i := strings.LastIndex(key, confmap.KeyDelimiter)
+		if i < 0 {
+			return "", false
+		}
+		key = key[:i]
+	}
+}
+
+// provenanceOf returns the provenance of every value of the configuration, keyed by path.
+func provenanceOf(data map[string]any, provenance map[string]confmap.Provenance) map[string]string {
+	sources := map[string]string{}
+	walkValues("", data, func(key string) {
+		sources[key] = sourceOf(key, provenance)
+	})
+	return sources
+}
+
+// provenanceNode returns the YAML node of the configuration, every value is commented with its provenance.
+func provenanceNode(data map[string]any, provenance map[string]confmap.Provenance) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(data); err != nil {
+		return nil, err
+	}
+	commentValues("", node, provenance)
+	return node, nil
+}
+
+func commentValues(prefix string, node *yaml.Node, provenance map[string]confmap.Provenance) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + confmap.KeyDelimiter + key
+		}
+		switch {
+		case valueNode.Kind == yaml.MappingNode && len(valueNode.Content) > 0:
+			commentValues(key, valueNode, provenance)
+		case valueNode.Kind == yaml.ScalarNode || len(valueNode.Content) == 0:
+			// The scalars and the empty collections are printed on the line of their key.
+			valueNode.LineComment = sourceOf(key, provenance)
+		default:
+			// The comment of a block collection goes after its key.
+			keyNode.LineComment = sourceOf(key, provenance)
+		}
+	}
+}
+
+// walkValues calls f with the path of every value of the configuration, the non-empty maps are walked.
+func walkValues(prefix string, data map[string]any, f func(key string)) {
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = prefix + confmap.KeyDelimiter + k
+		}
+		if sub, ok := v.(map[string]any); ok && len(sub) > 0 {
+			walkValues(key, sub, f)
+			continue
+		}
+		f(key)
+	}
+}
+
+func sourceOf(key string, provenance map[string]confmap.Provenance) string {
+	if p, ok := provenance[key]; ok {
+		return p.String()
+	}
+	return defaultProvenance
+}
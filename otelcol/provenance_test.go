@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestWithProvenance(t *testing.T) {
+	provenance := map[string]confmap.Provenance{
+		"receivers":                            {URI: "file:main.yaml", Line: 1},
+		"receivers::nop":                       {URI: "file:main.yaml", Line: 2},
+		"receivers::nop::foo":                  {URI: "file:main.yaml", Line: 3},
+		"receivers::bad":                       {URI: "file:extra.yaml", Line: 1},
+		"service":                              {URI: "file:main.yaml", Line: 4},
+		"service::extensions":                  {URI: "file:extra.yaml", Line: 2},
+		"service::telemetry":                   {URI: "env:TELEMETRY", Expansion: "${env:TELEMETRY}"},
+		"service::pipelines::traces":           {URI: "file:main.yaml", Line: 7},
+		"service::pipelines::traces::receiver": {URI: "file:main.yaml", Line: 8},
+	}
+	unmarshalErr := func(conf map[string]any) error {
+		var cfg struct {
+			Service struct {
+				Extensions []int          `mapstructure:"extensions"`
+				Telemetry  map[string]int `mapstructure:"telemetry"`
+			} `mapstructure:"service"`
+		}
+		err := confmap.NewFromStringMap(conf).Unmarshal(&cfg)
+		require.Error(t, err)
+		return err
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "component field",
+			err:      confmap.NewKeyError("receivers", confmap.NewKeyError("nop", confmap.NewKeyError("foo", errors.New("invalid")))),
+			expected: " (receivers::nop::foo set in file:main.yaml:3)",
+		},
+		{
+			name:     "unknown type",
+			err:      confmap.NewKeyError("receivers::bad", errors.New(`unknown type: "bad"`)),
+			expected: " (receivers::bad set in file:extra.yaml:1)",
+		},
+		{
+			name:     "expanded key",
+			err:      unmarshalErr(map[string]any{"service": map[string]any{"telemetry": map[string]any{"level": "x"}}}),
+			expected: " (service::telemetry set in env:TELEMETRY (expanded from ${env:TELEMETRY}))",
+		},
+		{
+			name:     "list element",
+			err:      unmarshalErr(map[string]any{"service": map[string]any{"extensions": []any{"a"}}}),
+			expected: " (service::extensions set in file:extra.yaml:2)",
+		},
+		{
+			name:     "validation path",
+			err:      confmap.NewKeyError("service::pipelines::traces", errors.New(`references processor "x" which is not configured`)),
+			expected: " (service::pipelines::traces set in file:main.yaml:7)",
+		},
+		{
+			name: "several keys",
+			err: fmt.Errorf("invalid: %w", errors.Join(
+				confmap.NewKeyError("receivers::nop", errors.New("invalid")),
+				confmap.NewKeyError("service::telemetry", errors.New("invalid")),
+			)),
+			expected: " (receivers::nop set in file:main.yaml:2, service::telemetry set in env:TELEMETRY (expanded from ${env:TELEMETRY}))",
+		},
+		{
+			name: "unknown key",
+			err:  confmap.NewKeyError("exporters::debug", errors.New("invalid verbosity")),
+		},
+		{
+			// The keys are never found in the error messages.
+			name: "message only",
+			err:  errors.New(`service::pipelines::traces: references processor "x" which is not configured`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotated := withProvenance(tt.err, provenance)
+			require.ErrorIs(t, annotated, tt.err)
+			assert.EqualError(t, annotated, tt.err.Error()+tt.expected)
+		})
+	}
+}
+
+func TestWithProvenanceJoinedErrors(t *testing.T) {
+	provenance := map[string]confmap.Provenance{
+		"exporters::a": {URI: "file:main.yaml", Line: 2},
+		"exporters::b": {URI: "file:main.yaml", Line: 4},
+	}
+	errA := confmap.NewKeyError("exporters::a", errors.New("exporters::a: invalid endpoint"))
+	errB := confmap.NewKeyError("exporters::b", errors.New("exporters::b: invalid timeout"))
+	err := withProvenance(errors.Join(errA, errB), provenance)
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+	assert.EqualError(t, err, "exporters::a: invalid endpoint (exporters::a set in file:main.yaml:2)\n"+
+		"exporters::b: invalid timeout (exporters::b set in file:main.yaml:4)")
+
+	assert.NoError(t, withProvenance(nil, provenance))
+	assert.Equal(t, errA, withProvenance(errA, nil))
+}
+
+func TestProvenanceOf(t *testing.T) {
+	data := map[string]any{
+		"exporters": map[string]any{
+			"debug": map[string]any{"verbosity": "basic"},
+		},
+		"extensions": map[string]any{},
+	}
+	provenance := map[string]confmap.Provenance{
+		"exporters::debug":            {URI: "file:main.yaml", Line: 2},
+		"exporters::debug::verbosity": {URI: "file:main.yaml", Line: 3},
+	}
+	assert.Equal(t, map[string]string{
+		"exporters::debug::verbosity": "file:main.yaml:3",
+		"extensions":                  "default",
+	}, provenanceOf(data, provenance))
+}
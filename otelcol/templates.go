@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+const (
+	templatesKey = "templates"
+
+	// templateKey and argumentsKey are the keys of a pipeline instantiating a template.
+	templateKey  = "template"
+	argumentsKey = "arguments"
+)
+
+// templateSections are the component sections a template can define.
+var templateSections = []string{"receivers", "processors", "exporters", "connectors"}
+
+// pipelineLists are the component lists of a pipeline a template can set.
+var pipelineLists = []string{"receivers", "processors", "exporters"}
+
+// placeholderRegexp matches the parameter placeholders of a template, e.g. {{tenant}} or {{ tenant }}.
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// template is a parameterized group of components, instantiated by the pipelines.
+type template struct {
+	// Parameters are the names of the parameters mapped to their default value, the parameters
+	// without default value are required.
+	Parameters map[string]any `mapstructure:"parameters"`
+	// Components are the component sections of the template, e.g. receivers.
+	Components map[string]map[string]any `mapstructure:",remain"`
+	// Pipeline are the component lists of the pipelines instantiating the template.
+	Pipeline map[string]any `mapstructure:"pipeline"`
+}
+
+// expandTemplates returns the configuration with the templates instantiated by the pipelines: the
+// components of the template are added to the configuration, and the pipelines get the component lists
+// of the template, unless they set them. The placeholders of the template are replaced by the arguments
+// of the pipeline, e.g. with the following configuration the traces/acme pipeline gets the otlp/acme
+// receiver and the otlp_grpc exporter:
+//
+//	templates:
+//	  tenant:
+//	    parameters:
+//	      name:
+//	      endpoint: localhost:4317
+//	    receivers:
+//	      otlp/{{name}}:
+//	    exporters:
+//	      otlp_grpc:
+//	        endpoint: "{{endpoint}}"
+//	    pipeline:
+//	      receivers: ["otlp/{{name}}"]
+//	      exporters: [otlp_grpc]
+//	service:
+//	  pipelines:
+//	    traces/acme:
+//	      template: tenant
+//	      arguments:
+//	        name: acme
+func expandTemplates(conf *confmap.Conf) (*confmap.Conf, error) {
+	if !conf.IsSet(templatesKey) {
+		return conf, nil
+	}
+	var templates map[string]template
+	sub, err := conf.Sub(templatesKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = sub.Unmarshal(&templates); err != nil {
+		return nil, fmt.Errorf("invalid templates: %w", err)
+	}
+	for name, tmpl := range templates {
+		for section := range tmpl.Components {
+			if !slices.Contains(templateSections, section) {
+				return nil, fmt.Errorf("template %q: unsupported section %q, must be one of %v", name, section, templateSections)
+			}
+		}
+		for list := range tmpl.Pipeline {
+			if !slices.Contains(pipelineLists, list) {
+				return nil, fmt.Errorf("template %q: unsupported pipeline list %q, must be one of %v", name, list, pipelineLists)
+			}
+		}
+	}
+
+	// The raw map keeps the original representation of the expanded values, e.g. "${env:PORT}" for a string field.
+	cfg := xconfmap.ToStringMapRaw(conf)
+	delete(cfg, templatesKey)
+	service, _ := cfg["service"].(map[string]any)
+	pipelines, _ := service["pipelines"].(map[string]any)
+	var errs error
+	// The pipelines are expanded in order, for the conflicts to be reported deterministically.
+	for _, pipelineID := range slices.Sorted(maps.Keys(pipelines)) {
+		pipe, ok := pipelines[pipelineID].(map[string]any)
+		if !ok || pipe[templateKey] == nil {
+			continue
+		}
+		if err = instantiate(cfg, pipe, templates); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("pipeline %q: %w", pipelineID, err))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return confmap.NewFromStringMap(cfg), nil
+}
+
+// instantiate adds the components of the template instantiated by the pipeline to the configuration, and
+// sets the component lists of the pipeline.
+func instantiate(cfg, pipe map[string]any, templates map[string]template) error {
+	name, ok := pipe[templateKey].(string)
+	if !ok {
+		return fmt.Errorf("the template must be a name, got %v", pipe[templateKey])
+	}
+	tmpl, ok := templates[name]
+	if !ok {
+		return fmt.Errorf("template %q is not defined", name)
+	}
+	arguments, ok := pipe[argumentsKey].(map[string]any)
+	if !ok && pipe[argumentsKey] != nil {
+		return fmt.Errorf("template %q: the arguments must be a map, got %v", name, pipe[argumentsKey])
+	}
+	values, err := tmpl.values(arguments)
+	if err != nil {
+		return fmt.Errorf("template %q: %w", name, err)
+	}
+	delete(pipe, templateKey)
+	delete(pipe, argumentsKey)
+
+	for _, section := range templateSections {
+		if tmpl.Components[section] == nil {
+			continue
+		}
+		components, err := substitute(tmpl.Components[section], values)
+		if err != nil {
+			return fmt.Errorf("template %q: %w", name, err)
+		}
+		for id, componentCfg := range components.(map[string]any) {
+			existing, _ := cfg[section].(map[string]any)
+			if existing == nil {
+				existing = map[string]any{}
+				cfg[section] = existing
+			}
+			if current, ok := existing[id]; ok && !reflect.DeepEqual(current, componentCfg) {
+				return fmt.Errorf("template %q: %s%s%s is already defined with a different configuration", name, section, confmap.KeyDelimiter, id)
+			}
+			existing[id] = componentCfg
+		}
+	}
+	for _, list := range pipelineLists {
+		if _, ok := pipe[list]; ok || tmpl.Pipeline[list] == nil {
+			// The lists set by the pipeline take precedence over the lists of the template.
+			continue
+		}
+		if pipe[list], err = substitute(tmpl.Pipeline[list], values); err != nil {
+			return fmt.Errorf("template %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// values returns the value of every parameter of the template, set by the arguments or by default.
+func (t template) values(arguments map[string]any) (map[string]any, error) {
+	var errs error
+	for _, arg := range slices.Sorted(maps.Keys(arguments)) {
+		if _, ok := t.Parameters[arg]; !ok {
+			errs = errors.Join(errs, fmt.Errorf("unknown parameter %q", arg))
+		}
+	}
+	values := make(map[string]any, len(t.Parameters))
+	for _, param := range slices.Sorted(maps.Keys(t.Parameters)) {
+		value, ok := arguments[param]
+		if !ok {
+			value = t.Parameters[param]
+		}
+		if value == nil {
+			errs = errors.Join(errs, fmt.Errorf("missing argument for the required parameter %q", param))
+			continue
+		}
+		values[param] = value
+	}
+	return values, errs
+}
+
+// substitute returns a copy of the value with the placeholders of the keys and strings replaced by the
+// parameter values. A string made of a single placeholder is replaced by the value of the parameter.
+func substitute(value any, values map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if match := placeholderRegexp.FindStringSubmatch(v); match != nil && match[0] == v {
+			param, ok := values[match[1]]
+			if !ok {
+				return nil, fmt.Errorf("unknown parameter %q", match[1])
+			}
+			return param, nil
+		}
+		var err error
+		replaced := placeholderRegexp.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+			param, ok := values[name]
+			if !ok {
+				err = fmt.Errorf("unknown parameter %q", name)
+				return placeholder
+			}
+			return fmt.Sprint(param)
+		})
+		return replaced, err
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			var err error
+			if out[i], err = substitute(elem, values); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, elem := range v {
+			newKey, err := substitute(key, values)
+			if err != nil {
+				return nil, err
+			}
+			keyStr, ok := newKey.(string)
+			if !ok {
+				keyStr = fmt.Sprint(newKey)
+			}
+			if out[keyStr], err = substitute(elem, values); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return value, nil
+}
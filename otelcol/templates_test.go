@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func tenantTemplate() map[string]any {
+	return map[string]any{
+		"parameters": map[string]any{
+			"name":     nil,
+			"endpoint": "localhost:4317",
+			"port":     4317,
+		},
+		"receivers": map[string]any{
+			"otlp/{{name}}": map[string]any{"port": "{{ port }}"},
+		},
+		"processors": map[string]any{
+			"attributes/{{name}}": map[string]any{
+				"actions": []any{map[string]any{"key": "tenant", "value": "{{name}}"}},
+			},
+		},
+		"exporters": map[string]any{
+			"otlp_grpc": map[string]any{"endpoint": "http://{{endpoint}}"},
+		},
+		"pipeline": map[string]any{
+			"receivers":  []any{"otlp/{{name}}"},
+			"processors": []any{"attributes/{{name}}"},
+			"exporters":  []any{"otlp_grpc"},
+		},
+	}
+}
+
+func TestExpandTemplates(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"templates": map[string]any{"tenant": tenantTemplate()},
+		"receivers": map[string]any{"nop": nil},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces/acme": map[string]any{
+					"template":  "tenant",
+					"arguments": map[string]any{"name": "acme", "port": 4318},
+				},
+				"traces/globex": map[string]any{
+					"template":  "tenant",
+					"arguments": map[string]any{"name": "globex"},
+					// The lists of the pipeline take precedence over the lists of the template.
+					"receivers": []any{"nop"},
+				},
+			},
+		},
+	})
+
+	expanded, err := expandTemplates(conf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"receivers": map[string]any{
+			"nop":         nil,
+			"otlp/acme":   map[string]any{"port": 4318},
+			"otlp/globex": map[string]any{"port": 4317},
+		},
+		"processors": map[string]any{
+			"attributes/acme":   map[string]any{"actions": []any{map[string]any{"key": "tenant", "value": "acme"}}},
+			"attributes/globex": map[string]any{"actions": []any{map[string]any{"key": "tenant", "value": "globex"}}},
+		},
+		"exporters": map[string]any{
+			// The components without parameters are shared by the instances.
+			"otlp_grpc": map[string]any{"endpoint": "http://localhost:4317"},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces/acme": map[string]any{
+					"receivers":  []any{"otlp/acme"},
+					"processors": []any{"attributes/acme"},
+					"exporters":  []any{"otlp_grpc"},
+				},
+				"traces/globex": map[string]any{
+					"receivers":  []any{"nop"},
+					"processors": []any{"attributes/globex"},
+					"exporters":  []any{"otlp_grpc"},
+				},
+			},
+		},
+	}, expanded.ToStringMap())
+}
+
+func TestExpandTemplatesWithoutTemplates(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"nop": nil}})
+	expanded, err := expandTemplates(conf)
+	require.NoError(t, err)
+	assert.Same(t, conf, expanded)
+}
+
+func TestExpandTemplatesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    func(map[string]any)
+		pipeline    map[string]any
+		receivers   map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "undefined_template",
+			pipeline:    map[string]any{"template": "unknown"},
+			expectedErr: `pipeline "traces/acme": template "unknown" is not defined`,
+		},
+		{
+			name:        "invalid_template_name",
+			pipeline:    map[string]any{"template": []any{"tenant"}},
+			expectedErr: `pipeline "traces/acme": the template must be a name`,
+		},
+		{
+			name:        "invalid_arguments",
+			pipeline:    map[string]any{"template": "tenant", "arguments": []any{"acme"}},
+			expectedErr: `template "tenant": the arguments must be a map`,
+		},
+		{
+			name:        "missing_argument",
+			pipeline:    map[string]any{"template": "tenant"},
+			expectedErr: `template "tenant": missing argument for the required parameter "name"`,
+		},
+		{
+			name:        "unknown_argument",
+			pipeline:    map[string]any{"template": "tenant", "arguments": map[string]any{"name": "acme", "region": "eu"}},
+			expectedErr: `template "tenant": unknown parameter "region"`,
+		},
+		{
+			name: "unknown_placeholder",
+			template: func(tmpl map[string]any) {
+				tmpl["exporters"] = map[string]any{"otlp_grpc": map[string]any{"endpoint": "{{region}}.example.com"}}
+			},
+			pipeline:    map[string]any{"template": "tenant", "arguments": map[string]any{"name": "acme"}},
+			expectedErr: `template "tenant": unknown parameter "region"`,
+		},
+		{
+			name: "unsupported_section",
+			template: func(tmpl map[string]any) {
+				tmpl["extensions"] = map[string]any{"zpages": nil}
+			},
+			pipeline:    map[string]any{"template": "tenant", "arguments": map[string]any{"name": "acme"}},
+			expectedErr: `template "tenant": unsupported section "extensions"`,
+		},
+		{
+			name: "unsupported_pipeline_list",
+			template: func(tmpl map[string]any) {
+				tmpl["pipeline"] = map[string]any{"connectors": []any{"forward"}}
+			},
+			pipeline:    map[string]any{"template": "tenant", "arguments": map[string]any{"name": "acme"}},
+			expectedErr: `template "tenant": unsupported pipeline list "connectors"`,
+		},
+		{
+			name:        "conflicting_component",
+			pipeline:    map[string]any{"template": "tenant", "arguments": map[string]any{"name": "acme"}},
+			receivers:   map[string]any{"otlp/acme": map[string]any{"port": 1234}},
+			expectedErr: `template "tenant": receivers::otlp/acme is already defined with a different configuration`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := tenantTemplate()
+			if tt.template != nil {
+				tt.template(tmpl)
+			}
+			conf := confmap.NewFromStringMap(map[string]any{
+				"templates": map[string]any{"tenant": tmpl},
+				"receivers": tt.receivers,
+				"service": map[string]any{
+					"pipelines": map[string]any{"traces/acme": tt.pipeline},
+				},
+			})
+			_, err := expandTemplates(conf)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestUnmarshalTemplates(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"templates": map[string]any{
+			"nop": map[string]any{
+				"parameters": map[string]any{"name": nil},
+				"receivers":  map[string]any{"nop/{{name}}": nil},
+				"exporters":  map[string]any{"nop/{{name}}": nil},
+				"pipeline": map[string]any{
+					"receivers": []any{"nop/{{name}}"},
+					"exporters": []any{"nop/{{name}}"},
+				},
+			},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces/acme": map[string]any{"template": "nop", "arguments": map[string]any{"name": "acme"}},
+			},
+		},
+	})
+	conf, err = expandTemplates(conf)
+	require.NoError(t, err)
+	cfg, err := unmarshal(conf, factories)
+	require.NoError(t, err)
+
+	id := component.MustNewIDWithName("nop", "acme")
+	assert.Contains(t, cfg.Receivers.Configs(), id)
+	assert.Contains(t, cfg.Exporters.Configs(), id)
+	pipe := cfg.Service.Pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "acme")]
+	require.NotNil(t, pipe)
+	assert.Equal(t, []component.ID{id}, pipe.Receivers)
+	assert.Equal(t, []component.ID{id}, pipe.Exporters)
+}
+
+func TestConfigProviderTemplateError(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	cm, err := NewConfigProvider(newDefaultConfigProviderSettings(t, []string{"file:" + filepath.Join("testdata", "otelcol-undefined-template.yaml")}))
+	require.NoError(t, err)
+	_, err = cm.Get(context.Background(), factories)
+	require.EqualError(t, err, `cannot expand the templates: pipeline "traces/acme": template "unknown" is not defined`)
+}
+
+func TestExpandTemplatesKeepsExpandedValues(t *testing.T) {
+	port := xconfmap.ExpandedValue{Value: 4317, Original: "4317"}
+	conf := confmap.NewFromStringMap(map[string]any{
+		"templates": map[string]any{"tenant": tenantTemplate()},
+		"receivers": map[string]any{"nop": map[string]any{"port": port}},
+	})
+	expanded, err := expandTemplates(conf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"nop": map[string]any{"port": port}}, xconfmap.ToStringMapRaw(expanded)["receivers"])
+}
@@ -27,7 +27,7 @@ type configSettings struct {
 	Service    service.Config                                `mapstructure:"service"`
 }
 
-// unmarshal the configSettings from a confmap.Conf.
+// unmarshal the configSettings from a confmap.Conf, the templates must already be instantiated, see expandTemplates.
 // After the config is unmarshalled, `Validate()` must be called to validate.
 func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 	if factories.Telemetry == nil {
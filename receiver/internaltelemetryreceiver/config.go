@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internaltelemetryreceiver // import "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
+
+// Config has the configuration of the internal telemetry receiver. The internal telemetry it receives
+// is configured in service::telemetry::receiver.
+type Config struct{}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package internaltelemetryreceiver implements a receiver that receives the
+// internal telemetry of the Collector, i.e. its own logs, metrics and traces,
+// so that it flows through the pipelines.
+package internaltelemetryreceiver // import "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
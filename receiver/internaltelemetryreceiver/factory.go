@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internaltelemetryreceiver // import "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/internaltelemetryreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// NewFactory returns a receiver.Factory that constructs internal telemetry receivers.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+func createTraces(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Traces) (receiver.Traces, error) {
+	return newReceiver(set, "traces", func(host hostcapabilities.InternalTelemetry) (func(), error) {
+		return host.ConsumeInternalTraces(next)
+	}), nil
+}
+
+func createMetrics(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	return newReceiver(set, "metrics", func(host hostcapabilities.InternalTelemetry) (func(), error) {
+		return host.ConsumeInternalMetrics(next)
+	}), nil
+}
+
+func createLogs(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Logs) (receiver.Logs, error) {
+	return newReceiver(set, "logs", func(host hostcapabilities.InternalTelemetry) (func(), error) {
+		return host.ConsumeInternalLogs(next)
+	}), nil
+}
@@ -0,0 +1,20 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+// Package metadata contains the autogenerated telemetry and
+// build information for the receiver/internaltelemetry component.
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("internaltelemetry")
+	ScopeName = "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
+)
+
+const (
+	TracesStability  = component.StabilityLevelDevelopment
+	MetricsStability = component.StabilityLevelDevelopment
+	LogsStability    = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internaltelemetryreceiver // import "go.opentelemetry.io/collector/receiver/internaltelemetryreceiver"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// internalTelemetryReceiver registers the next consumer of a signal to the host while it is started, so that
+// the host passes it the internal telemetry of the signal.
+type internalTelemetryReceiver struct {
+	logger     *zap.Logger
+	signal     string
+	register   func(hostcapabilities.InternalTelemetry) (func(), error)
+	unregister func()
+}
+
+func newReceiver(set receiver.Settings, signal string, register func(hostcapabilities.InternalTelemetry) (func(), error)) *internalTelemetryReceiver {
+	return &internalTelemetryReceiver{
+		logger:   set.Logger,
+		signal:   signal,
+		register: register,
+	}
+}
+
+func (r *internalTelemetryReceiver) Start(_ context.Context, host component.Host) error {
+	internalTelemetry, ok := host.(hostcapabilities.InternalTelemetry)
+	if !ok {
+		r.logger.Warn("The host does not inject the internal telemetry into the pipelines, nothing will be received",
+			zap.String("signal", r.signal))
+		return nil
+	}
+	unregister, err := r.register(internalTelemetry)
+	if err != nil {
+		return err
+	}
+	r.unregister = unregister
+	return nil
+}
+
+func (r *internalTelemetryReceiver) Shutdown(context.Context) error {
+	if r.unregister != nil {
+		r.unregister()
+		r.unregister = nil
+	}
+	return nil
+}
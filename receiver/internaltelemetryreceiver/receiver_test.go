@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internaltelemetryreceiver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/internaltelemetryreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+var _ hostcapabilities.InternalTelemetry = (*internalTelemetryHost)(nil)
+
+// internalTelemetryHost is a host that passes its internal telemetry to the registered consumers.
+type internalTelemetryHost struct {
+	component.Host
+	err     error
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+}
+
+func (h *internalTelemetryHost) ConsumeInternalTraces(next consumer.Traces) (func(), error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.traces = next
+	return func() { h.traces = nil }, nil
+}
+
+func (h *internalTelemetryHost) ConsumeInternalMetrics(next consumer.Metrics) (func(), error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.metrics = next
+	return func() { h.metrics = nil }, nil
+}
+
+func (h *internalTelemetryHost) ConsumeInternalLogs(next consumer.Logs) (func(), error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.logs = next
+	return func() { h.logs = nil }, nil
+}
+
+func TestReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	host := &internalTelemetryHost{Host: componenttest.NewNopHost()}
+
+	tracesSink := new(consumertest.TracesSink)
+	traces, err := factory.CreateTraces(t.Context(), set, cfg, tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(t.Context(), host))
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, host.traces.ConsumeTraces(t.Context(), td))
+	assert.Equal(t, []ptrace.Traces{td}, tracesSink.AllTraces())
+	require.NoError(t, traces.Shutdown(t.Context()))
+	assert.Nil(t, host.traces)
+
+	metricsSink := new(consumertest.MetricsSink)
+	metrics, err := factory.CreateMetrics(t.Context(), set, cfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, metrics.Start(t.Context(), host))
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, host.metrics.ConsumeMetrics(t.Context(), md))
+	assert.Equal(t, []pmetric.Metrics{md}, metricsSink.AllMetrics())
+	require.NoError(t, metrics.Shutdown(t.Context()))
+	assert.Nil(t, host.metrics)
+
+	logsSink := new(consumertest.LogsSink)
+	logs, err := factory.CreateLogs(t.Context(), set, cfg, logsSink)
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(t.Context(), host))
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	require.NoError(t, host.logs.ConsumeLogs(t.Context(), ld))
+	assert.Equal(t, []plog.Logs{ld}, logsSink.AllLogs())
+	require.NoError(t, logs.Shutdown(t.Context()))
+	assert.Nil(t, host.logs)
+}
+
+func TestReceiverRegisterError(t *testing.T) {
+	factory := NewFactory()
+	host := &internalTelemetryHost{Host: componenttest.NewNopHost(), err: errors.New("already consumed")}
+
+	traces, err := factory.CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	require.EqualError(t, traces.Start(t.Context(), host), "already consumed")
+	assert.NoError(t, traces.Shutdown(t.Context()))
+}
+
+func TestReceiverHostNotSupported(t *testing.T) {
+	factory := NewFactory()
+	core, observed := observer.New(zapcore.WarnLevel)
+	set := receivertest.NewNopSettings(metadata.Type)
+	set.Logger = zap.New(core)
+
+	logs, err := factory.CreateLogs(t.Context(), set, factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, logs.Shutdown(t.Context()))
+
+	entries := observed.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "logs", entries[0].ContextMap()["signal"])
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	factory := NewFactory()
+	metrics, err := factory.CreateMetrics(t.Context(), receivertest.NewNopSettings(metadata.Type), factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, metrics.Shutdown(t.Context()))
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configdiff computes the structured difference between two collector configurations: the
+// components added, removed or changed, and the pipelines that a reload would rebuild.
+package configdiff // import "go.opentelemetry.io/collector/service/configdiff"
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Reload is the kind of reload applying a configuration change requires.
+type Reload string
+
+const (
+	// ReloadNone is the reload of an unchanged configuration, there is nothing to apply.
+	ReloadNone Reload = "none"
+	// ReloadReceivers is the reload of a configuration where only the receivers change. Only the changed
+	// receivers are restarted when the service.partialReload and service.partialReloadReceivers feature
+	// gates are enabled, the whole service is restarted otherwise.
+	ReloadReceivers Reload = "receivers"
+	// ReloadPipelines is the reload of a configuration where only the components of the pipelines and the
+	// pipelines change. Only the affected components are restarted when the service.partialReload and
+	// service.partialReloadPipelines feature gates are enabled, the whole service is restarted otherwise.
+	ReloadPipelines Reload = "pipelines"
+	// ReloadFull is the reload restarting the whole service.
+	ReloadFull Reload = "full"
+)
+
+// Components are the changes of a component section, as sorted component IDs.
+type Components struct {
+	// Added are the components of the candidate configuration only.
+	Added []string `json:"added,omitempty" yaml:"added,omitempty"`
+	// Removed are the components of the running configuration only.
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// Changed are the components of both configurations with a different configuration.
+	Changed []string `json:"changed,omitempty" yaml:"changed,omitempty"`
+}
+
+func (c Components) empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// Pipelines are the changes of the pipelines, as sorted pipeline IDs.
+type Pipelines struct {
+	// Added are the pipelines of the candidate configuration only.
+	Added []string `json:"added,omitempty" yaml:"added,omitempty"`
+	// Removed are the pipelines of the running configuration only.
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// Rebuilt are the pipelines of both configurations whose component lists change, or with a component
+	// whose configuration changes.
+	Rebuilt []string `json:"rebuilt,omitempty" yaml:"rebuilt,omitempty"`
+}
+
+func (p Pipelines) empty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0 && len(p.Rebuilt) == 0
+}
+
+// Diff is the difference between the running configuration and a candidate configuration.
+type Diff struct {
+	Receivers  Components `json:"receivers" yaml:"receivers"`
+	Processors Components `json:"processors" yaml:"processors"`
+	Exporters  Components `json:"exporters" yaml:"exporters"`
+	Connectors Components `json:"connectors" yaml:"connectors"`
+	Extensions Components `json:"extensions" yaml:"extensions"`
+	Pipelines  Pipelines  `json:"pipelines" yaml:"pipelines"`
+
+	// ServiceExtensionsChanged is whether the list of the enabled extensions changes.
+	ServiceExtensionsChanged bool `json:"service_extensions_changed" yaml:"service_extensions_changed"`
+	// TelemetryChanged is whether the configuration of the internal telemetry changes.
+	TelemetryChanged bool `json:"telemetry_changed" yaml:"telemetry_changed"`
+
+	// Reload is the kind of reload applying the candidate configuration requires.
+	Reload Reload `json:"reload" yaml:"reload"`
+}
+
+// Empty returns whether the configurations are the same.
+func (d *Diff) Empty() bool {
+	return d.Receivers.empty() && d.Processors.empty() && d.Exporters.empty() && d.Connectors.empty() &&
+		d.Extensions.empty() && d.Pipelines.empty() && !d.ServiceExtensionsChanged && !d.TelemetryChanged
+}
+
+// Compare returns the difference between the running configuration and the candidate configuration. Both
+// are resolved configurations, i.e. their ${} references are expanded and their templates instantiated.
+// The configurations are compared as they are written, their values are not unmarshalled.
+func Compare(running, candidate *confmap.Conf) (*Diff, error) {
+	old, err := newSnapshot(running)
+	if err != nil {
+		return nil, fmt.Errorf("invalid running configuration: %w", err)
+	}
+	cur, err := newSnapshot(candidate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate configuration: %w", err)
+	}
+
+	d := &Diff{
+		Receivers:                compareComponents(old.components["receivers"], cur.components["receivers"]),
+		Processors:               compareComponents(old.components["processors"], cur.components["processors"]),
+		Exporters:                compareComponents(old.components["exporters"], cur.components["exporters"]),
+		Connectors:               compareComponents(old.components["connectors"], cur.components["connectors"]),
+		Extensions:               compareComponents(old.components["extensions"], cur.components["extensions"]),
+		ServiceExtensionsChanged: !slices.Equal(old.extensions, cur.extensions),
+		TelemetryChanged:         !reflect.DeepEqual(old.telemetry, cur.telemetry),
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(cur.pipelines)) {
+		oldPipe, ok := old.pipelines[id]
+		switch {
+		case !ok:
+			d.Pipelines.Added = append(d.Pipelines.Added, id)
+		case !oldPipe.equal(cur.pipelines[id]) || d.changesComponentOf(cur.pipelines[id]):
+			d.Pipelines.Rebuilt = append(d.Pipelines.Rebuilt, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(old.pipelines)) {
+		if _, ok := cur.pipelines[id]; !ok {
+			d.Pipelines.Removed = append(d.Pipelines.Removed, id)
+		}
+	}
+
+	switch {
+	case d.Empty():
+		d.Reload = ReloadNone
+	case d.receiversOnly(old, cur):
+		d.Reload = ReloadReceivers
+	case d.Extensions.empty() && !d.ServiceExtensionsChanged && !d.TelemetryChanged:
+		d.Reload = ReloadPipelines
+	default:
+		d.Reload = ReloadFull
+	}
+	return d, nil
+}
+
+// changesComponentOf returns whether the configuration of a component of the pipeline changes. The
+// receivers and exporters of a pipeline can be connectors.
+func (d *Diff) changesComponentOf(pipe pipelineSnapshot) bool {
+	changed := func(ids []string, sections ...Components) bool {
+		return slices.ContainsFunc(ids, func(id string) bool {
+			return slices.ContainsFunc(sections, func(c Components) bool { return slices.Contains(c.Changed, id) })
+		})
+	}
+	return changed(pipe.Receivers, d.Receivers, d.Connectors) ||
+		changed(pipe.Processors, d.Processors) ||
+		changed(pipe.Exporters, d.Exporters, d.Connectors)
+}
+
+// receiversOnly returns whether only the receivers change: the other sections and the service are the
+// same, and the pipelines only differ by the receivers that are not connectors.
+func (d *Diff) receiversOnly(old, cur *snapshot) bool {
+	if !d.Processors.empty() || !d.Exporters.empty() || !d.Connectors.empty() || !d.Extensions.empty() ||
+		d.ServiceExtensionsChanged || d.TelemetryChanged || len(d.Pipelines.Added) > 0 || len(d.Pipelines.Removed) > 0 {
+		return false
+	}
+	isConnector := func(id string) bool {
+		_, ok := cur.components["connectors"][id]
+		return ok
+	}
+	for id, oldPipe := range old.pipelines {
+		curPipe := cur.pipelines[id]
+		if !slices.Equal(oldPipe.Processors, curPipe.Processors) || !slices.Equal(oldPipe.Exporters, curPipe.Exporters) {
+			return false
+		}
+		if !slices.Equal(filter(oldPipe.Receivers, isConnector), filter(curPipe.Receivers, isConnector)) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalConfig returns whether the component configurations are the same, a component without configuration
+// has the same configuration as a component with an empty one.
+func equalConfig(old, cur any) bool {
+	if isEmpty(old) && isEmpty(cur) {
+		return true
+	}
+	return reflect.DeepEqual(old, cur)
+}
+
+func isEmpty(cfg any) bool {
+	m, ok := cfg.(map[string]any)
+	return cfg == nil || (ok && len(m) == 0)
+}
+
+func compareComponents(old, cur map[string]any) Components {
+	var c Components
+	for _, id := range slices.Sorted(maps.Keys(cur)) {
+		oldCfg, ok := old[id]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, id)
+		case !equalConfig(oldCfg, cur[id]):
+			c.Changed = append(c.Changed, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(old)) {
+		if _, ok := cur[id]; !ok {
+			c.Removed = append(c.Removed, id)
+		}
+	}
+	return c
+}
+
+// snapshot is the part of a configuration the diff compares.
+type snapshot struct {
+	// components are the configurations of the components by section and ID.
+	components map[string]map[string]any
+	extensions []string
+	telemetry  any
+	pipelines  map[string]pipelineSnapshot
+}
+
+// pipelineSnapshot are the component lists of a pipeline, as written in the configuration.
+type pipelineSnapshot struct {
+	Receivers  []string `mapstructure:"receivers"`
+	Processors []string `mapstructure:"processors"`
+	Exporters  []string `mapstructure:"exporters"`
+}
+
+func (p pipelineSnapshot) equal(other pipelineSnapshot) bool {
+	return slices.Equal(p.Receivers, other.Receivers) && slices.Equal(p.Processors, other.Processors) &&
+		slices.Equal(p.Exporters, other.Exporters)
+}
+
+func newSnapshot(conf *confmap.Conf) (*snapshot, error) {
+	s := &snapshot{components: map[string]map[string]any{}}
+	for _, section := range []string{"receivers", "processors", "exporters", "connectors", "extensions"} {
+		raw := conf.Get(section)
+		if raw == nil {
+			continue
+		}
+		components, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected a map under %q, got %T", section, raw)
+		}
+		s.components[section] = components
+	}
+
+	var service struct {
+		Extensions []string                    `mapstructure:"extensions"`
+		Pipelines  map[string]pipelineSnapshot `mapstructure:"pipelines"`
+	}
+	sub, err := conf.Sub("service")
+	if err != nil {
+		return nil, err
+	}
+	// The other keys of the service, e.g. the telemetry, are compared as they are written.
+	if err = sub.Unmarshal(&service, confmap.WithIgnoreUnused()); err != nil {
+		return nil, fmt.Errorf("invalid service: %w", err)
+	}
+	s.extensions = service.Extensions
+	s.pipelines = service.Pipelines
+	s.telemetry = conf.Get("service::telemetry")
+	return s, nil
+}
+
+// filter returns the IDs for which the predicate returns true, preserving order.
+func filter(ids []string, pred func(string) bool) []string {
+	var out []string
+	for _, id := range ids {
+		if pred(id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
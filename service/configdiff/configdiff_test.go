@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configdiff
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate string
+		expected  *Diff
+	}{
+		{
+			name:      "unchanged",
+			candidate: "running.yaml",
+			expected:  &Diff{Reload: ReloadNone},
+		},
+		{
+			name:      "receivers",
+			candidate: "receivers.yaml",
+			expected: &Diff{
+				Receivers: Components{
+					Added:   []string{"hostmetrics"},
+					Removed: []string{"prometheus"},
+					Changed: []string{"otlp"},
+				},
+				Pipelines: Pipelines{
+					Rebuilt: []string{"metrics", "traces"},
+				},
+				Reload: ReloadReceivers,
+			},
+		},
+		{
+			name:      "pipelines",
+			candidate: "pipelines.yaml",
+			expected: &Diff{
+				Processors: Components{Added: []string{"memory_limiter"}},
+				Exporters:  Components{Changed: []string{"otlp_grpc"}},
+				Pipelines: Pipelines{
+					Added:   []string{"logs"},
+					Removed: []string{"metrics"},
+					Rebuilt: []string{"traces", "traces/backend"},
+				},
+				Reload: ReloadPipelines,
+			},
+		},
+		{
+			name:      "full",
+			candidate: "full.yaml",
+			expected: &Diff{
+				Processors: Components{Added: []string{"memory_limiter"}},
+				Exporters:  Components{Changed: []string{"otlp_grpc"}},
+				Extensions: Components{Added: []string{"health_check"}},
+				Pipelines: Pipelines{
+					Added:   []string{"logs"},
+					Removed: []string{"metrics"},
+					Rebuilt: []string{"traces", "traces/backend"},
+				},
+				ServiceExtensionsChanged: true,
+				TelemetryChanged:         true,
+				Reload:                   ReloadFull,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			running, err := confmaptest.LoadConf(filepath.Join("testdata", "running.yaml"))
+			require.NoError(t, err)
+			candidate, err := confmaptest.LoadConf(filepath.Join("testdata", tt.candidate))
+			require.NoError(t, err)
+
+			diff, err := Compare(running, candidate)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff)
+			assert.Equal(t, tt.expected.Reload == ReloadNone, diff.Empty())
+		})
+	}
+}
+
+func TestCompareConnectorReceivers(t *testing.T) {
+	running, err := confmaptest.LoadConf(filepath.Join("testdata", "running.yaml"))
+	require.NoError(t, err)
+	candidate, err := confmaptest.LoadConf(filepath.Join("testdata", "running.yaml"))
+	require.NoError(t, err)
+	// Adding a receiver to a pipeline consuming a connector only changes the receivers.
+	require.NoError(t, candidate.Merge(confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces/backend": map[string]any{
+					"receivers": []any{"forward", "otlp"},
+				},
+			},
+		},
+	})))
+
+	diff, err := Compare(running, candidate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traces/backend"}, diff.Pipelines.Rebuilt)
+	assert.Equal(t, ReloadReceivers, diff.Reload)
+
+	require.NoError(t, candidate.Merge(confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"metrics": map[string]any{
+					"receivers": []any{"otlp", "prometheus", "forward"},
+				},
+			},
+		},
+	})))
+	// Changing the connectors consumed by a pipeline changes the pipeline graph.
+	diff, err = Compare(running, candidate)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metrics", "traces/backend"}, diff.Pipelines.Rebuilt)
+	assert.Equal(t, ReloadPipelines, diff.Reload)
+}
+
+func TestCompareInvalid(t *testing.T) {
+	running, err := confmaptest.LoadConf(filepath.Join("testdata", "running.yaml"))
+	require.NoError(t, err)
+
+	_, err = Compare(running, confmap.NewFromStringMap(map[string]any{"receivers": []any{"otlp"}}))
+	require.EqualError(t, err, `invalid candidate configuration: expected a map under "receivers", got []interface {}`)
+
+	_, err = Compare(confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{"pipelines": "traces"},
+	}), running)
+	require.ErrorContains(t, err, "invalid running configuration: invalid service")
+}
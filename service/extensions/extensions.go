@@ -5,10 +5,12 @@ package extensions // import "go.opentelemetry.io/collector/service/extensions"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 	"sort"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -267,6 +269,7 @@ var (
 	_ hostcapabilities.ModuleInfo       = (*hostWrapper)(nil)
 	_ hostcapabilities.ExposeExporters  = (*hostWrapper)(nil) //nolint:staticcheck // SA1019
 	_ hostcapabilities.ComponentFactory = (*hostWrapper)(nil)
+	_ hostcapabilities.Tapper           = (*hostWrapper)(nil)
 )
 
 type hostWrapper struct {
@@ -308,3 +311,10 @@ func (host *hostWrapper) GetFactory(kind component.Kind, componentType component
 	}
 	return nil
 }
+
+func (host *hostWrapper) Tap(pipelineID pipeline.ID, kind component.Kind, id component.ID, interval time.Duration) (<-chan []byte, func(), error) {
+	if t, ok := host.Host.(hostcapabilities.Tapper); ok {
+		return t.Tap(pipelineID, kind, id, interval)
+	}
+	return nil, nil, errors.New("the host does not support taps")
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -561,6 +562,7 @@ func TestExtensionHostForwardsHostCapabilities(t *testing.T) {
 			pipeline.SignalTraces: {},
 		},
 		factory: factory,
+		samples: make(chan []byte),
 	}
 	require.NoError(t, exts.Start(t.Context(), host))
 	require.NoError(t, exts.Shutdown(t.Context()))
@@ -576,6 +578,13 @@ func TestExtensionHostForwardsHostCapabilities(t *testing.T) {
 	cf, ok := extHost.(hostcapabilities.ComponentFactory)
 	require.True(t, ok, "host passed to extensions must implement hostcapabilities.ComponentFactory")
 	assert.Equal(t, factory, cf.GetFactory(component.KindExtension, factory.Type()))
+
+	tapper, ok := extHost.(hostcapabilities.Tapper)
+	require.True(t, ok, "host passed to extensions must implement hostcapabilities.Tapper")
+	samples, cancel, err := tapper.Tap(pipeline.NewID(pipeline.SignalTraces), component.KindProcessor, component.MustNewID("batch"), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, (<-chan []byte)(host.samples), samples)
+	cancel()
 }
 
 func TestExtensionHostCapabilitiesZeroValues(t *testing.T) {
@@ -585,6 +594,8 @@ func TestExtensionHostCapabilitiesZeroValues(t *testing.T) {
 	assert.Equal(t, moduleinfo.ModuleInfos{}, wrapper.GetModuleInfos())
 	assert.Nil(t, wrapper.GetExporters())
 	assert.Nil(t, wrapper.GetFactory(component.KindExtension, component.MustNewType("recording")))
+	_, _, err := wrapper.Tap(pipeline.NewID(pipeline.SignalTraces), component.KindProcessor, component.MustNewID("batch"), time.Second)
+	assert.EqualError(t, err, "the host does not support taps")
 }
 
 // capabilitiesHost is a component.Host that also implements the optional
@@ -594,6 +605,7 @@ type capabilitiesHost struct {
 	moduleInfos moduleinfo.ModuleInfos
 	exporters   map[pipeline.Signal]map[component.ID]component.Component
 	factory     component.Factory
+	samples     chan []byte
 }
 
 func (h *capabilitiesHost) GetModuleInfos() moduleinfo.ModuleInfos {
@@ -608,6 +620,10 @@ func (h *capabilitiesHost) GetFactory(component.Kind, component.Type) component.
 	return h.factory
 }
 
+func (h *capabilitiesHost) Tap(pipeline.ID, component.Kind, component.ID, time.Duration) (<-chan []byte, func(), error) {
+	return h.samples, func() {}, nil
+}
+
 // statusReporterHost is a component.Host that also implements status.Reporter,
 // mirroring the host provided to extensions by the running service.
 type statusReporterHost struct {
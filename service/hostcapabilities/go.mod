@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	go.opentelemetry.io/collector/component v1.65.0
+	go.opentelemetry.io/collector/consumer v1.65.0
 	go.opentelemetry.io/collector/pipeline v1.65.0
 	go.opentelemetry.io/collector/service v0.159.0
 )
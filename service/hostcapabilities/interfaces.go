@@ -6,7 +6,10 @@
 package hostcapabilities // import "go.opentelemetry.io/collector/service/hostcapabilities"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 )
@@ -35,3 +38,32 @@ type ComponentFactory interface {
 	// component type
 	GetFactory(kind component.Kind, componentType component.Type) component.Factory
 }
+
+// Tapper is an interface that may be implemented by the host to sample the data
+// flowing through its pipelines, e.g. to inspect it without reloading the configuration.
+type Tapper interface {
+	// Tap subscribes to samples of the data flowing through a component of a pipeline,
+	// rendered as OTLP JSON: the data a receiver, or a connector used as a receiver of
+	// the pipeline, emits to the pipeline, the data a processor consumes, or the data the
+	// pipeline emits to an exporter, or a connector used as an exporter of the pipeline.
+	// At most one sample is sent every interval, and the samples are dropped while the
+	// previous one is not received. The subscription ends when cancel is called, and the
+	// samples channel is closed once the component is removed from the pipeline.
+	Tap(pipelineID pipeline.ID, kind component.Kind, id component.ID, interval time.Duration) (samples <-chan []byte, cancel func(), err error)
+}
+
+// InternalTelemetry is an interface that may be implemented by the host to inject
+// the internal telemetry of the Collector, i.e. its own logs, metrics and traces,
+// into the pipelines through a receiver. The host should not inject the telemetry
+// produced by the components the internal telemetry flows through, which would loop.
+type InternalTelemetry interface {
+	// ConsumeInternalTraces registers the consumer of the internal traces until
+	// unregister is called. It fails if another consumer is registered.
+	ConsumeInternalTraces(consumer.Traces) (unregister func(), err error)
+	// ConsumeInternalMetrics registers the consumer of the internal metrics until
+	// unregister is called. It fails if another consumer is registered.
+	ConsumeInternalMetrics(consumer.Metrics) (unregister func(), err error)
+	// ConsumeInternalLogs registers the consumer of the internal logs until
+	// unregister is called. It fails if another consumer is registered.
+	ConsumeInternalLogs(consumer.Logs) (unregister func(), err error)
+}
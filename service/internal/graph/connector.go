@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 )
 
 const pipelineIDAttrKey = "otelcol.pipeline.id"
@@ -30,11 +31,14 @@ var _ consumerNode = (*connectorNode)(nil)
 
 type connectorNode struct {
 	attribute.Attributes
+	flows
 	componentID      component.ID
 	exprPipelineType pipeline.Signal
 	rcvrPipelineType pipeline.Signal
 	component.Component
 	consumer baseConsumer
+	// taps sample the data produced by the connector, by pipeline it emits to.
+	taps map[pipeline.ID]*tapconsumer.Tap
 }
 
 func newConnectorNode(exprPipelineType, rcvrPipelineType pipeline.Signal, connID component.ID) *connectorNode {
@@ -62,7 +66,6 @@ func (n *connectorNode) buildComponent(
 		TelemetrySettings: componentattribute.TelemetrySettingsWithAttributes(tel, *n.Set()),
 		BuildInfo:         info,
 	}
-
 	switch n.rcvrPipelineType {
 	case pipeline.SignalTraces:
 		return n.buildTraces(ctx, set, builder, nexts)
@@ -91,17 +94,19 @@ func (n *connectorNode) buildTraces(
 		ItemCounter: tb.ConnectorProducedItems,
 		SizeCounter: tb.ConnectorProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 	consumedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ConnectorConsumedItems,
 		SizeCounter: tb.ConnectorConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Traces, len(nexts))
 	for _, next := range nexts {
 		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewTraces(
-			next.(consumer.Traces),
+			tapconsumer.NewTraces(next.(consumer.Traces), n.taps[next.(*capabilitiesNode).pipelineID]),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
@@ -169,17 +174,19 @@ func (n *connectorNode) buildMetrics(
 		ItemCounter: tb.ConnectorProducedItems,
 		SizeCounter: tb.ConnectorProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 	consumedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ConnectorConsumedItems,
 		SizeCounter: tb.ConnectorConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Metrics, len(nexts))
 	for _, next := range nexts {
 		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewMetrics(
-			next.(consumer.Metrics),
+			tapconsumer.NewMetrics(next.(consumer.Metrics), n.taps[next.(*capabilitiesNode).pipelineID]),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
@@ -247,17 +254,19 @@ func (n *connectorNode) buildLogs(
 		ItemCounter: tb.ConnectorProducedItems,
 		SizeCounter: tb.ConnectorProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 	consumedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ConnectorConsumedItems,
 		SizeCounter: tb.ConnectorConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Logs, len(nexts))
 	for _, next := range nexts {
 		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewLogs(
-			next.(consumer.Logs),
+			tapconsumer.NewLogs(next.(consumer.Logs), n.taps[next.(*capabilitiesNode).pipelineID]),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
@@ -325,17 +334,19 @@ func (n *connectorNode) buildProfiles(
 		ItemCounter: tb.ConnectorProducedItems,
 		SizeCounter: tb.ConnectorProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 	consumedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ConnectorConsumedItems,
 		SizeCounter: tb.ConnectorConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	consumers := make(map[pipeline.ID]xconsumer.Profiles, len(nexts))
 	for _, next := range nexts {
 		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewProfiles(
-			next.(xconsumer.Profiles),
+			tapconsumer.NewProfiles(next.(xconsumer.Profiles), n.taps[next.(*capabilitiesNode).pipelineID]),
 			producedSettings,
 			obsconsumer.WithStaticDataPointAttribute(
 				otelattr.String(
@@ -27,6 +27,7 @@ var _ consumerNode = (*exporterNode)(nil)
 // Therefore, nodeID is derived from "pipeline type" and "component ID".
 type exporterNode struct {
 	attribute.Attributes
+	flows
 	componentID  component.ID
 	pipelineType pipeline.Signal
 	component.Component
@@ -66,6 +67,7 @@ func (n *exporterNode) buildComponent(
 		ItemCounter: tb.ExporterConsumedItems,
 		SizeCounter: tb.ExporterConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	switch n.pipelineType {
@@ -6,6 +6,7 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 )
 
 var _ consumerNode = (*fanOutNode)(nil)
@@ -16,6 +17,8 @@ type fanOutNode struct {
 	attribute.Attributes
 	pipelineID pipeline.ID
 	baseConsumer
+	// tap samples the data emitted to the exporters of the pipeline.
+	tap *tapconsumer.Tap
 }
 
 func newFanOutNode(pipelineID pipeline.ID) *fanOutNode {
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+// flows count the items flowing in and out of a component node since it was built and the flows are served,
+// the flow is nil if the component does not consume or produce, or if its consumers are not instrumented.
+type flows struct {
+	consumed *obsconsumer.Flow
+	produced *obsconsumer.Flow
+}
+
+func (f flows) getFlows() flows {
+	return f
+}
+
+type flowNode interface {
+	getFlows() flows
+}
+
+// newFlow returns a flow counting the items once the flows are served, or nil if the consumers are not
+// instrumented because the telemetry.newPipelineTelemetry feature gate is disabled.
+func (g *Graph) newFlow() *obsconsumer.Flow {
+	if !metadata.TelemetryNewPipelineTelemetryFeatureGate.IsEnabled() {
+		return nil
+	}
+	return obsconsumer.NewFlow(&g.flowsServed)
+}
+
+// serveFlows starts counting the items flowing through the nodes, when the flows are served.
+func (g *Graph) serveFlows() {
+	g.flowsServed.Store(true)
+}
+
+// nodeFlow are the counts of the items flowing in and out of a component node.
+type nodeFlow struct {
+	ID          string                  `json:"id"`
+	Kind        string                  `json:"kind"`
+	ComponentID string                  `json:"component_id"`
+	Pipelines   []string                `json:"pipelines"`
+	Consumed    *obsconsumer.FlowCounts `json:"consumed,omitempty"`
+	Produced    *obsconsumer.FlowCounts `json:"produced,omitempty"`
+}
+
+// flowSnapshot are the counts of the items flowing through the component nodes at a point in time.
+type flowSnapshot struct {
+	Timestamp time.Time  `json:"timestamp"`
+	Nodes     []nodeFlow `json:"nodes"`
+}
+
+// flowSnapshot returns the current counts of the component nodes, sorted by ID.
+func (g *Graph) flowSnapshot() *flowSnapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := &flowSnapshot{Timestamp: time.Now(), Nodes: []nodeFlow{}}
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		fn, ok := nodes.Node().(flowNode)
+		if !ok {
+			continue
+		}
+		n := g.topologyNode(nodes.Node())
+		nf := nodeFlow{ID: n.ID, Kind: n.Kind, ComponentID: n.ComponentID, Pipelines: n.Pipelines}
+		if f := fn.getFlows(); f.consumed != nil {
+			counts := f.consumed.Counts()
+			nf.Consumed = &counts
+		}
+		if f := fn.getFlows(); f.produced != nil {
+			counts := f.produced.Counts()
+			nf.Produced = &counts
+		}
+		s.Nodes = append(s.Nodes, nf)
+	}
+	slices.SortFunc(s.Nodes, func(a, b nodeFlow) int { return strings.Compare(a.ID, b.ID) })
+	return s
+}
+
+// HandleFlowZPages serves the items per second flowing in and out of the component nodes, refused and
+// failed, refreshed live. With the format=json query parameter, it responds with the counts of the items
+// since the nodes were built as JSON instead.
+func (g *Graph) HandleFlowZPages(w http.ResponseWriter, r *http.Request) {
+	s := g.flowSnapshot()
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipelines Flow"})
+	data := zpages.FlowTableData{}
+	for _, n := range s.Nodes {
+		data.Rows = append(data.Rows, zpages.FlowTableRowData{
+			ID:        n.ID,
+			Pipelines: n.Pipelines,
+			Consumed:  flowCountsData(n.Consumed),
+			Produced:  flowCountsData(n.Produced),
+		})
+	}
+	zpages.WriteHTMLFlowTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func flowCountsData(counts *obsconsumer.FlowCounts) *zpages.FlowCountsData {
+	if counts == nil {
+		return nil
+	}
+	return &zpages.FlowCountsData{Items: counts.Items, Refused: counts.Refused, Failed: counts.Failed}
+}
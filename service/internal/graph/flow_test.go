@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestGraphFlow(t *testing.T) {
+	setObsConsumerGateForTest(t, true)
+	pg, err := Build(context.Background(), newTopologyTestSettings())
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter(), Pipelines: pg}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter())) })
+
+	// The items are only counted once the flows are served.
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	host.RegisterZPages(http.NewServeMux(), "/debug")
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	rec := httptest.NewRecorder()
+	pg.HandleFlowZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/flowz?format=json", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	snapshot := &flowSnapshot{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), snapshot))
+
+	dataPoints := int64(testdata.GenerateMetrics(2).DataPointCount())
+	assert.Equal(t, []nodeFlow{
+		{
+			ID: "connector:traces:metrics:exampleconnector", Kind: "connector", ComponentID: "exampleconnector",
+			Pipelines: []string{"metrics/out", "traces/in"},
+			Consumed:  &obsconsumer.FlowCounts{Items: 2},
+			Produced:  &obsconsumer.FlowCounts{Items: dataPoints},
+		},
+		{
+			ID: "exporter:metrics:exampleexporter", Kind: "exporter", ComponentID: "exampleexporter",
+			Pipelines: []string{"metrics/out"},
+			Consumed:  &obsconsumer.FlowCounts{Items: dataPoints},
+		},
+		{
+			ID: "processor:traces/in:exampleprocessor/mutate", Kind: "processor", ComponentID: "exampleprocessor/mutate",
+			Pipelines: []string{"traces/in"},
+			Consumed:  &obsconsumer.FlowCounts{Items: 2},
+			Produced:  &obsconsumer.FlowCounts{Items: 2},
+		},
+		{
+			ID: "receiver:traces:examplereceiver", Kind: "receiver", ComponentID: "examplereceiver",
+			Pipelines: []string{"traces/in"},
+			Produced:  &obsconsumer.FlowCounts{Items: 2},
+		},
+	}, snapshot.Nodes)
+
+	rec = httptest.NewRecorder()
+	pg.HandleFlowZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/flowz", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `data-node="receiver:traces:examplereceiver"`)
+}
+
+func TestGraphFlowGateDisabled(t *testing.T) {
+	setObsConsumerGateForTest(t, false)
+	pg, err := Build(context.Background(), newTopologyTestSettings())
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter(), Pipelines: pg}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter())) })
+	host.RegisterZPages(http.NewServeMux(), "/debug")
+
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	// The consumers are not instrumented, no flow is counted.
+	for _, n := range pg.flowSnapshot().Nodes {
+		assert.Nil(t, n.Consumed, n.ID)
+		assert.Nil(t, n.Produced, n.ID)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -41,6 +43,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -71,6 +74,16 @@ type Graph struct {
 	instanceIDs map[int64]*componentstatus.InstanceID
 
 	telemetry component.TelemetrySettings
+
+	// flowsServed reports whether the flows of the nodes are served, they only count the items once served.
+	flowsServed atomic.Bool
+
+	// mu guards the graph, the pipelines and the taps, since the taps, the components the internal
+	// telemetry flows through, the topology and the flows are looked up while the pipelines are updated.
+	mu sync.Mutex
+	// taps sample the data flowing through the nodes. They are kept while their node is rebuilt, so their
+	// subscriptions survive the partial reloads, and closed once their node is removed.
+	taps map[tapKey]*tapconsumer.Tap
 }
 
 // Build builds a full pipeline graph.
@@ -301,79 +314,99 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	}
 
 	for _, node := range slices.Backward(nodes) {
-		switch n := node.(type) {
-		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
-		case *processorNode:
-			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
-		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
-		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
-		case *capabilitiesNode:
-			capability := consumer.Capabilities{
-				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
-				MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
-			}
-			for _, proc := range g.pipelines[n.pipelineID].processors {
-				capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
-			}
-			next := g.nextConsumers(n.ID())[0]
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
-				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
-			case pipeline.SignalMetrics:
-				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
-				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
-			case pipeline.SignalLogs:
-				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
-				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
-			case xpipeline.SignalProfiles:
-				cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
-				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
-			}
-		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				consumers := make([]consumer.Traces, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Traces))
-				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
-			case pipeline.SignalMetrics:
-				consumers := make([]consumer.Metrics, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Metrics))
-				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
-			case pipeline.SignalLogs:
-				consumers := make([]consumer.Logs, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Logs))
-				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
-			case xpipeline.SignalProfiles:
-				consumers := make([]xconsumer.Profiles, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(xconsumer.Profiles))
-				}
-				n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
-			}
-		}
-		if err != nil {
+		if err = g.buildNode(ctx, set, node); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// buildNode instantiates the component of the node, or its consumer for the capabilities and fan-out nodes.
+// The nodes it emits to must already be built.
+func (g *Graph) buildNode(ctx context.Context, set Settings, node graph.Node) error {
+	var err error
+	switch n := node.(type) {
+	case *receiverNode:
+		n.flows = flows{produced: g.newFlow()}
+		n.tap = g.tap(tapKey{nodeID: n.ID()})
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+	case *processorNode:
+		n.flows = flows{consumed: g.newFlow(), produced: g.newFlow()}
+		n.tap = g.tap(tapKey{nodeID: n.ID()})
+		// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+	case *exporterNode:
+		n.flows = flows{consumed: g.newFlow()}
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+	case *connectorNode:
+		n.flows = flows{consumed: g.newFlow(), produced: g.newFlow()}
+		nexts := g.nextConsumers(n.ID())
+		n.taps = make(map[pipeline.ID]*tapconsumer.Tap, len(nexts))
+		for _, next := range nexts {
+			pipelineID := next.(*capabilitiesNode).pipelineID
+			n.taps[pipelineID] = g.tap(tapKey{nodeID: n.ID(), pipelineID: pipelineID})
+		}
+		err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, nexts)
+	case *capabilitiesNode:
+		capability := consumer.Capabilities{
+			// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
+			MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+		}
+		for _, proc := range g.pipelines[n.pipelineID].processors {
+			capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+		}
+		next := g.nextConsumers(n.ID())[0]
+		switch n.pipelineID.Signal() {
+		case pipeline.SignalTraces:
+			cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
+			n.baseConsumer = cc
+			n.ConsumeTracesFunc = cc.ConsumeTraces
+		case pipeline.SignalMetrics:
+			cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
+			n.baseConsumer = cc
+			n.ConsumeMetricsFunc = cc.ConsumeMetrics
+		case pipeline.SignalLogs:
+			cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
+			n.baseConsumer = cc
+			n.ConsumeLogsFunc = cc.ConsumeLogs
+		case xpipeline.SignalProfiles:
+			cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
+			n.baseConsumer = cc
+			n.ConsumeProfilesFunc = cc.ConsumeProfiles
+		}
+	case *fanOutNode:
+		n.tap = g.tap(tapKey{nodeID: n.ID()})
+		nexts := g.nextConsumers(n.ID())
+		switch n.pipelineID.Signal() {
+		case pipeline.SignalTraces:
+			consumers := make([]consumer.Traces, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Traces))
+			}
+			n.baseConsumer = tapconsumer.NewTraces(fanoutconsumer.NewTraces(consumers), n.tap)
+		case pipeline.SignalMetrics:
+			consumers := make([]consumer.Metrics, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Metrics))
+			}
+			n.baseConsumer = tapconsumer.NewMetrics(fanoutconsumer.NewMetrics(consumers), n.tap)
+		case pipeline.SignalLogs:
+			consumers := make([]consumer.Logs, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(consumer.Logs))
+			}
+			n.baseConsumer = tapconsumer.NewLogs(fanoutconsumer.NewLogs(consumers), n.tap)
+		case xpipeline.SignalProfiles:
+			consumers := make([]xconsumer.Profiles, 0, len(nexts))
+			for _, next := range nexts {
+				consumers = append(consumers, next.(xconsumer.Profiles))
+			}
+			n.baseConsumer = tapconsumer.NewProfiles(fanoutconsumer.NewProfiles(consumers), n.tap)
+		}
+	}
+	return err
+}
+
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
 	nextNodes := g.componentGraph.From(nodeID)
@@ -407,7 +440,11 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 	if host == nil {
 		return errors.New("host cannot be nil")
 	}
+	return g.startNodes(ctx, host, nil)
+}
 
+// startNodes starts the components of the graph, except the ones of the running nodes.
+func (g *Graph) startNodes(ctx context.Context, host *Host, running map[int64]bool) error {
 	nodes, err := topo.SortStabilized(g.componentGraph, func(nodes []graph.Node) {
 		slices.SortFunc(nodes, func(node1, node2 graph.Node) int {
 			// Always start receivers after non-receivers, to avoid cases where a shared receiver
@@ -433,8 +470,8 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 	for _, node := range slices.Backward(nodes) {
 		comp, ok := node.(component.Component)
 
-		if !ok {
-			// Skip capabilities/fanout nodes
+		if !ok || running[node.ID()] {
+			// Skip capabilities/fanout nodes, and the components already running.
 			continue
 		}
 
@@ -470,6 +507,14 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 		return err
 	}
 
+	// End the subscriptions to the taps, the pipelines are not sampled anymore.
+	g.mu.Lock()
+	for key, tap := range g.taps {
+		tap.Close()
+		delete(g.taps, key)
+	}
+	g.mu.Unlock()
+
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
@@ -605,10 +650,14 @@ func (g *Graph) UpdateReceivers(ctx context.Context, set Settings,
 		}
 	}
 
-	// Phase 5: Remove shutdown nodes from the graph and pipeline maps.
+	// Phase 5: Remove shutdown nodes from the graph and pipeline maps. The taps of the removed receivers
+	// are closed, the rebuilt receivers keep theirs. The graph and the pipeline maps are guarded until the
+	// receivers are wired back, since the taps and the components are looked up in them.
+	g.mu.Lock()
 	for nodeID := range toRemove {
 		g.componentGraph.RemoveNode(nodeID)
 		delete(g.instanceIDs, nodeID)
+		g.closeTapLocked(tapKey{nodeID: nodeID})
 	}
 	for nodeID := range toRebuild {
 		g.componentGraph.RemoveNode(nodeID)
@@ -658,6 +707,7 @@ func (g *Graph) UpdateReceivers(ctx context.Context, set Settings,
 			g.componentGraph.SetEdge(g.componentGraph.NewEdge(node, pipe.capabilitiesNode))
 		}
 	}
+	g.mu.Unlock()
 
 	// Phase 8: Build new/rebuilt receiver components.
 	built := make(map[int64]bool)
@@ -675,7 +725,7 @@ func (g *Graph) UpdateReceivers(ctx context.Context, set Settings,
 				continue // shared receiver already built
 			}
 			built[nodeID] = true
-			if err := rn.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(nodeID)); err != nil {
+			if err := g.buildNode(ctx, set, rn); err != nil {
 				return fmt.Errorf("failed to build receiver %q: %w", rn.componentID, err)
 			}
 		}
@@ -730,6 +780,199 @@ func (g *Graph) shutdownReceiverNode(ctx context.Context, nodeID int64, rn *rece
 	return nil
 }
 
+// UpdatePipelines performs a partial reload of the graph to the pipelines of the settings. Only the nodes
+// that are added, whose component configuration changed, or that emit to a rebuilt node are rebuilt: the
+// changes are propagated up the graph, from the changed node to the receivers of its pipelines, so that
+// no in-flight data is sent to a stopped component. All other nodes keep running without interruption.
+//
+// For example, changing the configuration of an exporter rebuilds the exporter and the processors and
+// receivers of the pipelines it is used in, while a pipeline added or removed does not affect the other
+// pipelines. A connector is rebuilt when the pipelines it emits to are rebuilt, which in turn rebuilds
+// the pipelines it is used in as an exporter.
+//
+// The changed components are the IDs, by kind, of the components present before and after the update
+// whose configuration changed. The settings hold the builders with the updated configurations.
+func (g *Graph) UpdatePipelines(ctx context.Context, set Settings,
+	changed map[component.Kind]map[component.ID]bool,
+	host *Host,
+) error {
+	if host == nil {
+		return errors.New("host cannot be nil")
+	}
+
+	// Phase 1: Create the nodes and edges of the updated graph, without building its components.
+	next := &Graph{
+		componentGraph: simple.NewDirectedGraph(),
+		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		telemetry:      g.telemetry,
+	}
+	for pipelineID := range set.PipelineConfigs {
+		next.pipelines[pipelineID] = &pipelineNodes{
+			receivers: make(map[int64]graph.Node),
+			exporters: make(map[int64]graph.Node),
+		}
+	}
+	if err := next.createNodes(set); err != nil {
+		return err
+	}
+	next.createEdges()
+	nextNodes, err := topo.Sort(next.componentGraph)
+	if err != nil {
+		return cycleErr(err, topo.DirectedCyclesIn(next.componentGraph))
+	}
+	currentNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return err
+	}
+
+	// Phase 2: Determine the nodes that keep running, downstream first since a node can only keep
+	// running if the nodes it emits to do.
+	running := make(map[int64]bool)
+	for _, node := range slices.Backward(nextNodes) {
+		running[node.ID()] = g.canKeepRunning(next, node, changed, running)
+	}
+	if len(nextNodes) == len(currentNodes) && !slices.ContainsFunc(nextNodes, func(node graph.Node) bool {
+		return !running[node.ID()]
+	}) {
+		g.telemetry.Logger.Info("Partial pipelines reload: no pipeline changes detected")
+		return nil
+	}
+
+	// Install the updated builders on the host, so component.Host.GetFactory returns the factories
+	// of the new component types.
+	host.Receivers = set.ReceiverBuilder
+	host.Processors = set.ProcessorBuilder
+	host.Exporters = set.ExporterBuilder
+	host.Connectors = set.ConnectorBuilder
+
+	// Phase 3: Shutdown the components that are removed or rebuilt, upstream first so that each
+	// component has a chance to drain to its consumer before the consumer is stopped.
+	stopped := 0
+	for _, node := range currentNodes {
+		comp, ok := node.(component.Component)
+		if !ok || running[node.ID()] {
+			continue
+		}
+		if err = g.shutdownNode(ctx, node.ID(), comp, host); err != nil {
+			return err
+		}
+		stopped++
+	}
+
+	// Phase 4: Move the running nodes to the updated graph, and replace the graph. The running
+	// components keep their instance ID, their status is reported with it.
+	for _, node := range nextNodes {
+		if running[node.ID()] {
+			keepRunning(node, g.componentGraph.Node(node.ID()))
+			if instanceID, ok := g.instanceIDs[node.ID()]; ok {
+				next.instanceIDs[node.ID()] = instanceID
+			}
+		}
+	}
+	g.mu.Lock()
+	g.componentGraph = next.componentGraph
+	g.pipelines = next.pipelines
+	g.instanceIDs = next.instanceIDs
+	g.closeRemovedTapsLocked()
+	g.mu.Unlock()
+
+	// Phase 5: Build the other nodes, downstream first.
+	for _, node := range slices.Backward(nextNodes) {
+		if running[node.ID()] {
+			continue
+		}
+		if err = g.buildNode(ctx, set, node); err != nil {
+			return err
+		}
+	}
+
+	// Phase 6: Start the built components.
+	if err = g.startNodes(ctx, host, running); err != nil {
+		return err
+	}
+
+	g.telemetry.Logger.Info("Partial pipelines reload completed successfully", zap.Int("stopped_components", stopped))
+	return nil
+}
+
+// canKeepRunning returns whether the current node of the updated graph can keep running: its component
+// configuration is unchanged, and it emits to the same nodes, which all keep running. The consumers of a
+// node are set when it is built.
+func (g *Graph) canKeepRunning(next *Graph, node graph.Node, changed map[component.Kind]map[component.ID]bool, running map[int64]bool) bool {
+	if g.componentGraph.Node(node.ID()) == nil {
+		return false
+	}
+	switch n := node.(type) {
+	case *receiverNode:
+		if changed[component.KindReceiver][n.componentID] {
+			return false
+		}
+	case *processorNode:
+		if changed[component.KindProcessor][n.componentID] {
+			return false
+		}
+	case *exporterNode:
+		if changed[component.KindExporter][n.componentID] {
+			return false
+		}
+	case *connectorNode:
+		if changed[component.KindConnector][n.componentID] {
+			return false
+		}
+	}
+
+	nexts := next.componentGraph.From(node.ID())
+	if nexts.Len() != g.componentGraph.From(node.ID()).Len() {
+		return false
+	}
+	for nexts.Next() {
+		nextID := nexts.Node().ID()
+		if !running[nextID] || !g.componentGraph.HasEdgeFromTo(node.ID(), nextID) {
+			return false
+		}
+	}
+	return true
+}
+
+// keepRunning moves the running component, or consumer, of the current node to the node of the updated graph.
+func keepRunning(node, current graph.Node) {
+	switch n := node.(type) {
+	case *receiverNode:
+		*n = *current.(*receiverNode)
+	case *processorNode:
+		*n = *current.(*processorNode)
+	case *exporterNode:
+		*n = *current.(*exporterNode)
+	case *connectorNode:
+		*n = *current.(*connectorNode)
+	case *capabilitiesNode:
+		*n = *current.(*capabilitiesNode)
+	case *fanOutNode:
+		*n = *current.(*fanOutNode)
+	}
+}
+
+func (g *Graph) shutdownNode(ctx context.Context, nodeID int64, comp component.Component, host *Host) error {
+	instanceID := g.instanceIDs[nodeID]
+	host.Reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopping),
+	)
+	if err := comp.Shutdown(ctx); err != nil {
+		host.Reporter.ReportStatus(
+			instanceID,
+			componentstatus.NewPermanentErrorEvent(err),
+		)
+		return fmt.Errorf("failed to shutdown %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), err)
+	}
+	host.Reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopped),
+	)
+	return nil
+}
+
 func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
 	exporters := make(map[pipeline.Signal]map[component.ID]component.Component)
 	exporters[pipeline.SignalTraces] = make(map[component.ID]component.Component)
@@ -868,3 +1111,105 @@ type HostWrapper struct {
 func (host *HostWrapper) Report(event *componentstatus.Event) {
 	host.Reporter.ReportStatus(host.InstanceID, event)
 }
+
+// Tap returns the tap sampling the data flowing through the component of the given kind and ID in the
+// pipeline: the data a receiver, or a connector used as a receiver, emits to the pipeline, the data a
+// processor consumes, or the data the pipeline emits to an exporter, or a connector used as an exporter.
+func (g *Graph) Tap(pipelineID pipeline.ID, kind component.Kind, id component.ID) (*tapconsumer.Tap, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID.String())
+	}
+	switch kind {
+	case component.KindReceiver, component.KindConnector:
+		for _, node := range pipe.receivers {
+			switch n := node.(type) {
+			case *receiverNode:
+				if kind == component.KindReceiver && n.componentID == id {
+					return g.tapLocked(tapKey{nodeID: n.ID()}), nil
+				}
+			case *connectorNode:
+				if kind == component.KindConnector && n.componentID == id {
+					return g.tapLocked(tapKey{nodeID: n.ID(), pipelineID: pipelineID}), nil
+				}
+			}
+		}
+		if kind == component.KindReceiver {
+			break
+		}
+		fallthrough
+	case component.KindExporter:
+		for _, node := range pipe.exporters {
+			switch n := node.(type) {
+			case *exporterNode:
+				if kind == component.KindExporter && n.componentID == id {
+					return g.tapLocked(tapKey{nodeID: pipe.fanOutNode.ID()}), nil
+				}
+			case *connectorNode:
+				if kind == component.KindConnector && n.componentID == id {
+					return g.tapLocked(tapKey{nodeID: pipe.fanOutNode.ID()}), nil
+				}
+			}
+		}
+	case component.KindProcessor:
+		for _, node := range pipe.processors {
+			if n := node.(*processorNode); n.componentID == id {
+				return g.tapLocked(tapKey{nodeID: n.ID()}), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s %q not found in pipeline %q", strings.ToLower(kind.String()), id.String(), pipelineID.String())
+}
+
+// tapKey identifies the tap of a node, and for a connector the pipeline it emits to.
+type tapKey struct {
+	nodeID     int64
+	pipelineID pipeline.ID
+}
+
+// tap returns the tap of the given key, created on first use.
+func (g *Graph) tap(key tapKey) *tapconsumer.Tap {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.tapLocked(key)
+}
+
+// tapLocked is like tap, the caller must hold g.mu.
+func (g *Graph) tapLocked(key tapKey) *tapconsumer.Tap {
+	if tap, ok := g.taps[key]; ok {
+		return tap
+	}
+	if g.taps == nil {
+		g.taps = make(map[tapKey]*tapconsumer.Tap)
+	}
+	tap := &tapconsumer.Tap{}
+	g.taps[key] = tap
+	return tap
+}
+
+// closeTapLocked closes the tap of the given key, ending its subscriptions. The caller must hold g.mu.
+func (g *Graph) closeTapLocked(key tapKey) {
+	if tap, ok := g.taps[key]; ok {
+		tap.Close()
+		delete(g.taps, key)
+	}
+}
+
+// closeRemovedTapsLocked closes the taps of the nodes removed from the graph, and of the pipelines the
+// connectors no longer emit to. The caller must hold g.mu.
+func (g *Graph) closeRemovedTapsLocked() {
+	for key := range g.taps {
+		if g.componentGraph.Node(key.nodeID) == nil {
+			g.closeTapLocked(key)
+			continue
+		}
+		if key.pipelineID == (pipeline.ID{}) {
+			continue
+		}
+		if pipe, ok := g.pipelines[key.pipelineID]; !ok || !g.componentGraph.HasEdgeFromTo(key.nodeID, pipe.capabilitiesNode.ID()) {
+			g.closeTapLocked(key)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -3707,3 +3709,243 @@ func TestSharedReceiverStartOrder(t *testing.T) {
 		}))
 	}
 }
+
+func TestUpdatePipelines(t *testing.T) {
+	t.Run("with_internal_telemetry", func(t *testing.T) {
+		setObsConsumerGateForTest(t, true)
+		testUpdatePipelines(t)
+	})
+	t.Run("without_internal_telemetry", func(t *testing.T) {
+		setObsConsumerGateForTest(t, false)
+		testUpdatePipelines(t)
+	})
+}
+
+func TestUpdatePipelines_NilHost(t *testing.T) {
+	pg := &Graph{
+		componentGraph: simple.NewDirectedGraph(),
+		pipelines:      make(map[pipeline.ID]*pipelineNodes),
+		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		telemetry:      componenttest.NewNopTelemetrySettings(),
+	}
+	err := pg.UpdatePipelines(context.Background(), Settings{}, nil, nil)
+	require.EqualError(t, err, "host cannot be nil")
+}
+
+// statefulComponent is implemented by the test components, to check whether they were started or stopped.
+type statefulComponent interface {
+	Started() bool
+	Stopped() bool
+}
+
+// nodeComponents returns the component of every node of the graph, by node ID.
+func nodeComponents(pg *Graph) map[int64]component.Component {
+	components := map[int64]component.Component{}
+	nodes := pg.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			components[n.ID()] = n.Component
+		case *processorNode:
+			components[n.ID()] = n.Component
+		case *exporterNode:
+			components[n.ID()] = n.Component
+		case *connectorNode:
+			components[n.ID()] = n.Component
+		}
+	}
+	return components
+}
+
+func updatePipelinesTestSettings(pipelineCfgs pipelines.Config, changedCfgs ...component.ID) Settings {
+	cfgs := func(ids ...component.ID) map[component.ID]component.Config {
+		m := map[component.ID]component.Config{}
+		for _, id := range ids {
+			m[id] = &struct{ Changed bool }{Changed: slices.Contains(changedCfgs, id)}
+		}
+		return m
+	}
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			cfgs(component.MustNewID("examplereceiver"), component.MustNewIDWithName("examplereceiver", "2"), component.MustNewIDWithName("examplereceiver", "3")),
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			cfgs(component.MustNewID("exampleprocessor")),
+			map[component.Type]processor.Factory{testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			cfgs(component.MustNewID("exampleexporter"), component.MustNewIDWithName("exampleexporter", "2")),
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			cfgs(component.MustNewID("exampleconnector")),
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory},
+		),
+		PipelineConfigs: pipelineCfgs,
+	}
+}
+
+func testUpdatePipelines(t *testing.T) {
+	var (
+		recv       = component.MustNewID("examplereceiver")
+		recv2      = component.MustNewIDWithName("examplereceiver", "2")
+		recv3      = component.MustNewIDWithName("examplereceiver", "3")
+		proc       = component.MustNewID("exampleprocessor")
+		exp        = component.MustNewID("exampleexporter")
+		exp2       = component.MustNewIDWithName("exampleexporter", "2")
+		conn       = component.MustNewID("exampleconnector")
+		traces     = pipeline.NewID(pipeline.SignalTraces)
+		traces2    = pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+		metrics    = pipeline.NewID(pipeline.SignalMetrics)
+		metrics2   = pipeline.NewIDWithName(pipeline.SignalMetrics, "2")
+		logs       = pipeline.NewID(pipeline.SignalLogs)
+		tracesRecv = newReceiverNode(pipeline.SignalTraces, recv).ID()
+		tracesExp  = newExporterNode(pipeline.SignalTraces, exp).ID()
+		metricsExp = newExporterNode(pipeline.SignalMetrics, exp).ID()
+	)
+	// The receivers are not shared between the pipelines of different signals, the example receivers of the
+	// same configuration are the same instance.
+	initial := pipelines.Config{
+		traces:  {Receivers: []component.ID{recv}, Processors: []component.ID{proc}, Exporters: []component.ID{exp}},
+		traces2: {Receivers: []component.ID{recv2}, Processors: []component.ID{proc}, Exporters: []component.ID{exp2}},
+		metrics: {Receivers: []component.ID{recv3}, Exporters: []component.ID{exp}},
+	}
+	withPipelines := func(update func(pipelines.Config)) pipelines.Config {
+		cfgs := maps.Clone(initial)
+		update(cfgs)
+		return cfgs
+	}
+
+	tests := []struct {
+		name      string
+		pipelines pipelines.Config
+		changed   map[component.Kind]map[component.ID]bool
+		// running are the nodes whose components keep running.
+		running []int64
+		// stopped are the nodes whose components are stopped, rebuilt or removed.
+		stopped []int64
+	}{
+		{
+			name:      "no_change",
+			pipelines: initial,
+			running: []int64{
+				tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp,
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(), newExporterNode(pipeline.SignalTraces, exp2).ID(),
+				newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp,
+			},
+		},
+		{
+			name:      "exporter_changed",
+			pipelines: initial,
+			changed:   map[component.Kind]map[component.ID]bool{component.KindExporter: {exp2: true}},
+			running:   []int64{tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp, newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp},
+			stopped: []int64{
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(), newExporterNode(pipeline.SignalTraces, exp2).ID(),
+			},
+		},
+		{
+			name:      "processor_changed",
+			pipelines: initial,
+			changed:   map[component.Kind]map[component.ID]bool{component.KindProcessor: {proc: true}},
+			running:   []int64{tracesExp, newExporterNode(pipeline.SignalTraces, exp2).ID(), newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp},
+			stopped: []int64{
+				tracesRecv, newProcessorNode(traces, proc).ID(),
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(),
+			},
+		},
+		{
+			name:      "pipeline_removed",
+			pipelines: withPipelines(func(cfgs pipelines.Config) { delete(cfgs, traces2) }),
+			running:   []int64{tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp, newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp},
+			stopped: []int64{
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(), newExporterNode(pipeline.SignalTraces, exp2).ID(),
+			},
+		},
+		{
+			name: "pipeline_added",
+			pipelines: withPipelines(func(cfgs pipelines.Config) {
+				cfgs[logs] = &pipelines.PipelineConfig{Receivers: []component.ID{recv}, Exporters: []component.ID{exp}}
+			}),
+			running: []int64{
+				tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp,
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(), newExporterNode(pipeline.SignalTraces, exp2).ID(),
+				newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp,
+			},
+		},
+		{
+			name: "pipeline_modified",
+			pipelines: withPipelines(func(cfgs pipelines.Config) {
+				cfgs[metrics] = &pipelines.PipelineConfig{Receivers: []component.ID{recv3}, Processors: []component.ID{proc}, Exporters: []component.ID{exp}}
+			}),
+			running: []int64{
+				tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp,
+				newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID(), newExporterNode(pipeline.SignalTraces, exp2).ID(),
+				metricsExp,
+			},
+			stopped: []int64{newReceiverNode(pipeline.SignalMetrics, recv3).ID()},
+		},
+		{
+			name: "connector_added",
+			pipelines: withPipelines(func(cfgs pipelines.Config) {
+				cfgs[traces2] = &pipelines.PipelineConfig{Receivers: []component.ID{recv2}, Processors: []component.ID{proc}, Exporters: []component.ID{exp2, conn}}
+				cfgs[metrics2] = &pipelines.PipelineConfig{Receivers: []component.ID{conn}, Exporters: []component.ID{exp}}
+			}),
+			// The pipeline exporting to the connector is rebuilt, but its exporter keeps running.
+			running: []int64{
+				tracesRecv, newProcessorNode(traces, proc).ID(), tracesExp, newExporterNode(pipeline.SignalTraces, exp2).ID(),
+				newReceiverNode(pipeline.SignalMetrics, recv3).ID(), metricsExp,
+			},
+			stopped: []int64{newReceiverNode(pipeline.SignalTraces, recv2).ID(), newProcessorNode(traces2, proc).ID()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := updatePipelinesTestSettings(initial)
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			host := &Host{Reporter: status.NewNopStatusReporter()}
+			require.NoError(t, pg.StartAll(context.Background(), host))
+			before := nodeComponents(pg)
+
+			var changed []component.ID
+			for _, ids := range tt.changed {
+				changed = slices.AppendSeq(changed, maps.Keys(ids))
+			}
+			updated := updatePipelinesTestSettings(tt.pipelines, changed...)
+			require.NoError(t, pg.UpdatePipelines(context.Background(), updated, tt.changed, host))
+			after := nodeComponents(pg)
+
+			for _, nodeID := range tt.running {
+				require.Contains(t, after, nodeID)
+				assert.Same(t, before[nodeID], after[nodeID], "the component of node %d should keep running", nodeID)
+				assert.False(t, before[nodeID].(statefulComponent).Stopped())
+			}
+			for _, nodeID := range tt.stopped {
+				assert.True(t, before[nodeID].(statefulComponent).Stopped(), "the component of node %d should be stopped", nodeID)
+			}
+			for nodeID, comp := range after {
+				if before[nodeID] != comp {
+					assert.True(t, comp.(statefulComponent).Started(), "the component of node %d should be started", nodeID)
+				}
+			}
+			assert.Len(t, pg.pipelines, len(tt.pipelines))
+			assert.Equal(t, set.PipelineConfigs, initial, "the initial pipelines must not be modified")
+
+			// The data flows from every receiver to the exporters.
+			for _, c := range pg.getReceivers()[pipeline.SignalTraces] {
+				require.NoError(t, c.(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			}
+			for id, e := range pg.GetExporters()[pipeline.SignalTraces] {
+				assert.NotEmpty(t, e.(*testcomponents.ExampleExporter).Traces, "exporter %q should receive the traces", id)
+			}
+			if _, ok := tt.pipelines[metrics2]; ok {
+				assert.NotEmpty(t, pg.GetExporters()[pipeline.SignalMetrics][exp].(*testcomponents.ExampleExporter).Metrics)
+			}
+
+			require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+		})
+	}
+}
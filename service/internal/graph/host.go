@@ -4,6 +4,11 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"runtime"
@@ -11,23 +16,31 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/configdiff"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/zpages"
+	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/topology"
 )
 
 var (
-	_ component.Host                    = (*Host)(nil)
-	_ hostcapabilities.ModuleInfo       = (*Host)(nil)
-	_ hostcapabilities.ExposeExporters  = (*Host)(nil) //nolint:staticcheck // SA1019
-	_ hostcapabilities.ComponentFactory = (*Host)(nil)
+	_ component.Host                     = (*Host)(nil)
+	_ hostcapabilities.ModuleInfo        = (*Host)(nil)
+	_ hostcapabilities.ExposeExporters   = (*Host)(nil) //nolint:staticcheck // SA1019
+	_ hostcapabilities.ComponentFactory  = (*Host)(nil)
+	_ hostcapabilities.Tapper            = (*Host)(nil)
+	_ hostcapabilities.InternalTelemetry = (*Host)(nil)
 )
 
+var errInternalTelemetryNotSupported = errors.New("the internal telemetry is not injected into the pipelines by this host")
+
 type Host struct {
 	AsyncErrorChannel chan error
 	Receivers         *builders.ReceiverBuilder
@@ -43,6 +56,12 @@ type Host struct {
 	ServiceExtensions *extensions.Extensions
 
 	Reporter status.Reporter
+
+	// InternalTelemetry receives the internal telemetry the telemetry providers inject into the pipelines.
+	InternalTelemetry *telemetry.Sink
+
+	// CompareConfig compares a candidate configuration to the running configuration, see zConfigDiffPath.
+	CompareConfig func(ctx context.Context, candidate []byte) (*configdiff.Diff, error)
 }
 
 func (host *Host) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -79,6 +98,40 @@ func (host *Host) GetExporters() map[pipeline.Signal]map[component.ID]component.
 	return host.Pipelines.GetExporters()
 }
 
+// Tap implements hostcapabilities.Tapper.
+func (host *Host) Tap(pipelineID pipeline.ID, kind component.Kind, id component.ID, interval time.Duration) (<-chan []byte, func(), error) {
+	tap, err := host.Pipelines.Tap(pipelineID, kind, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	sub := tap.Subscribe(interval)
+	return sub.Samples(), sub.Close, nil
+}
+
+// ConsumeInternalTraces implements hostcapabilities.InternalTelemetry.
+func (host *Host) ConsumeInternalTraces(c consumer.Traces) (func(), error) {
+	if host.InternalTelemetry == nil {
+		return nil, errInternalTelemetryNotSupported
+	}
+	return host.InternalTelemetry.RegisterTraces(c)
+}
+
+// ConsumeInternalMetrics implements hostcapabilities.InternalTelemetry.
+func (host *Host) ConsumeInternalMetrics(c consumer.Metrics) (func(), error) {
+	if host.InternalTelemetry == nil {
+		return nil, errInternalTelemetryNotSupported
+	}
+	return host.InternalTelemetry.RegisterMetrics(c)
+}
+
+// ConsumeInternalLogs implements hostcapabilities.InternalTelemetry.
+func (host *Host) ConsumeInternalLogs(c consumer.Logs) (func(), error) {
+	if host.InternalTelemetry == nil {
+		return nil, errInternalTelemetryNotSupported
+	}
+	return host.InternalTelemetry.RegisterLogs(c)
+}
+
 func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
 	host.ServiceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == componentstatus.StatusFatalError {
@@ -92,6 +145,16 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	// zConfigDiffPath compares the configuration posted as YAML to the running configuration, and
+	// responds with their difference as JSON.
+	zConfigDiffPath = "configdiffz"
+	// zGraphPath responds with the topology of the pipelines, as JSON or as Graphviz DOT with ?format=dot.
+	zGraphPath = "graphz"
+	// zFlowPath shows the items flowing in and out of each component of the pipelines, refreshed live.
+	zFlowPath = "flowz"
+
+	// maxCandidateSize is the maximum size of a configuration posted to zConfigDiffPath.
+	maxCandidateSize = 4 << 20
 )
 
 // InfoVar is a singleton instance of the Info struct.
@@ -112,6 +175,14 @@ func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zGraphPath), host.handleGraphzRequest)
+	if host.Pipelines != nil {
+		host.Pipelines.serveFlows()
+	}
+	mux.HandleFunc(path.Join(pathPrefix, zFlowPath), host.Pipelines.HandleFlowZPages)
+	if host.CompareConfig != nil {
+		mux.HandleFunc(path.Join(pathPrefix, zConfigDiffPath), host.handleConfigDiffzRequest)
+	}
 }
 
 func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -124,6 +195,16 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Topology",
+		ComponentEndpoint: zGraphPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Flow",
+		ComponentEndpoint: zFlowPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
@@ -137,6 +218,42 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
+// handleConfigDiffzRequest responds with the difference between the running configuration and the
+// configuration posted as YAML, e.g. to preview the components and pipelines a rollout would restart.
+func (host *Host) handleConfigDiffzRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "the candidate configuration must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+	candidate, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCandidateSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot read the candidate configuration: %v", err), http.StatusBadRequest)
+		return
+	}
+	diff, err := host.CompareConfig(r.Context(), candidate)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(diff)
+}
+
+// handleGraphzRequest responds with the topology of the pipelines, e.g. to render it with Graphviz.
+func (host *Host) handleGraphzRequest(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = topology.FormatJSON
+	}
+	if format != topology.FormatJSON && format != topology.FormatDOT {
+		http.Error(w, fmt.Sprintf("unknown format %q, must be %q or %q", format, topology.FormatJSON, topology.FormatDOT), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", topology.ContentType(format))
+	_ = host.Pipelines.Topology().Write(w, format)
+}
+
 func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/service/configdiff"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestHostConfigDiffz(t *testing.T) {
+	host := &Host{
+		CompareConfig: func(_ context.Context, candidate []byte) (*configdiff.Diff, error) {
+			if string(candidate) == "invalid" {
+				return nil, errors.New("invalid candidate configuration")
+			}
+			return &configdiff.Diff{
+				Receivers: configdiff.Components{Added: []string{string(candidate)}},
+				Reload:    configdiff.ReloadReceivers,
+			}, nil
+		},
+	}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/configdiffz", strings.NewReader("otlp")))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var diff configdiff.Diff
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &diff))
+	assert.Equal(t, []string{"otlp"}, diff.Receivers.Added)
+	assert.Equal(t, configdiff.ReloadReceivers, diff.Reload)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/configdiffz", strings.NewReader("invalid")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid candidate configuration")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/configdiffz", http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+
+	// The page is not registered without a way to compare the configurations.
+	mux = http.NewServeMux()
+	(&Host{}).RegisterZPages(mux, "/debug")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/configdiffz", strings.NewReader("otlp")))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHostGraphz(t *testing.T) {
+	host := &Host{Pipelines: &Graph{componentGraph: simple.NewDirectedGraph()}}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/graphz", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"nodes": [], "edges": []}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/graphz?format=dot", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/vnd.graphviz", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "digraph pipelines {"))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/graphz?format=svg", http.NoBody))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/flowz?format=json", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"nodes":[]`)
+}
+
+func TestHostInternalTelemetry(t *testing.T) {
+	host := &Host{}
+	_, err := host.ConsumeInternalTraces(consumertest.NewNop())
+	require.ErrorIs(t, err, errInternalTelemetryNotSupported)
+	_, err = host.ConsumeInternalMetrics(consumertest.NewNop())
+	require.ErrorIs(t, err, errInternalTelemetryNotSupported)
+	_, err = host.ConsumeInternalLogs(consumertest.NewNop())
+	require.ErrorIs(t, err, errInternalTelemetryNotSupported)
+
+	host.InternalTelemetry = &telemetry.Sink{}
+	logs := new(consumertest.LogsSink)
+	unregister, err := host.ConsumeInternalLogs(logs)
+	require.NoError(t, err)
+	_, err = host.ConsumeInternalLogs(consumertest.NewNop())
+	require.Error(t, err)
+
+	require.NoError(t, host.InternalTelemetry.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, logs.AllLogs(), 1)
+	unregister()
+	require.NoError(t, host.InternalTelemetry.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, logs.AllLogs(), 1)
+
+	unregisterTraces, err := host.ConsumeInternalTraces(consumertest.NewNop())
+	require.NoError(t, err)
+	unregisterTraces()
+	unregisterMetrics, err := host.ConsumeInternalMetrics(consumertest.NewNop())
+	require.NoError(t, err)
+	unregisterMetrics()
+}
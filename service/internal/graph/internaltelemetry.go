@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// ConsumeInternalTraces implements hostcapabilities.InternalTelemetry. The spans of the components the
+// internal traces flow through are dropped, otherwise consuming the internal traces would produce more.
+func (host *HostWrapper) ConsumeInternalTraces(c consumer.Traces) (func(), error) {
+	return host.Host.ConsumeInternalTraces(&internalTracesFilter{
+		Traces:     c,
+		components: host.downstreamComponents(pipeline.SignalTraces),
+	})
+}
+
+// ConsumeInternalLogs implements hostcapabilities.InternalTelemetry. The logs of the components the
+// internal logs flow through are dropped, otherwise consuming the internal logs would produce more.
+func (host *HostWrapper) ConsumeInternalLogs(c consumer.Logs) (func(), error) {
+	return host.Host.ConsumeInternalLogs(&internalLogsFilter{
+		Logs:       c,
+		components: host.downstreamComponents(pipeline.SignalLogs),
+	})
+}
+
+// downstreamComponents returns a function listing the components the data of the receiver of the signal
+// flows through. They are listed on each call, since the pipelines may be updated while the receiver runs.
+func (host *HostWrapper) downstreamComponents(signal pipeline.Signal) func() []otelattr.Set {
+	return func() []otelattr.Set {
+		if host.Pipelines == nil || host.InstanceID == nil {
+			return nil
+		}
+		return host.Pipelines.downstreamComponents(signal, host.InstanceID.ComponentID())
+	}
+}
+
+// downstreamComponents returns the attributes of the receiver of the signal and of the processors,
+// exporters and connectors its data flows through, including in the pipelines the connectors emit to.
+func (g *Graph) downstreamComponents(signal pipeline.Signal, recvID component.ID) []otelattr.Set {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var components []otelattr.Set
+	visited := make(map[int64]bool)
+	var visit func(node graph.Node)
+	visit = func(node graph.Node) {
+		if node == nil || visited[node.ID()] {
+			return
+		}
+		visited[node.ID()] = true
+		switch n := node.(type) {
+		case *receiverNode:
+			components = append(components, *n.Set())
+		case *processorNode:
+			components = append(components, *n.Set())
+		case *exporterNode:
+			components = append(components, *n.Set())
+		case *connectorNode:
+			components = append(components, *n.Set())
+		}
+		nodes := g.componentGraph.From(node.ID())
+		for nodes.Next() {
+			visit(nodes.Node())
+		}
+	}
+	visit(g.componentGraph.Node(newReceiverNode(signal, recvID).ID()))
+	return components
+}
+
+// producedBy reports whether the attributes of an instrumentation scope identify one of the components.
+// The attributes a component dropped from its telemetry are ignored, except its kind and ID.
+func producedBy(scope pcommon.Map, components []otelattr.Set) bool {
+	if _, ok := scope.Get(telemetry.ComponentIDKey); !ok {
+		return false
+	}
+	for _, set := range components {
+		if matchesScope(scope, set) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesScope(scope pcommon.Map, set otelattr.Set) bool {
+	iter := set.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		v, ok := scope.Get(string(kv.Key))
+		if !ok {
+			if kv.Key == telemetry.ComponentKindKey || kv.Key == telemetry.ComponentIDKey {
+				return false
+			}
+			continue
+		}
+		if v.AsString() != kv.Value.AsString() {
+			return false
+		}
+	}
+	return true
+}
+
+// internalTracesFilter drops the spans of the components the internal traces flow through. The traces
+// are created for the receiver by the telemetry providers, so they are modified in place.
+type internalTracesFilter struct {
+	consumer.Traces
+	components func() []otelattr.Set
+}
+
+func (f *internalTracesFilter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	components := f.components()
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			return producedBy(ss.Scope().Attributes(), components)
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	return f.Traces.ConsumeTraces(ctx, td)
+}
+
+// internalLogsFilter drops the logs of the components the internal logs flow through. The logs are
+// created for the receiver by the telemetry providers, so they are modified in place.
+type internalLogsFilter struct {
+	consumer.Logs
+	components func() []otelattr.Set
+}
+
+func (f *internalLogsFilter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	components := f.components()
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			return producedBy(sl.Scope().Attributes(), components)
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return nil
+	}
+	return f.Logs.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelattr "go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	servicetelemetry "go.opentelemetry.io/collector/service/telemetry"
+)
+
+func putScopeAttributes(dest pcommon.Map, attrs otelattr.Set) {
+	for _, kv := range attrs.ToSlice() {
+		dest.PutStr(string(kv.Key), kv.Value.AsString())
+	}
+}
+
+func TestHostWrapperConsumeInternalTraces(t *testing.T) {
+	pg, err := Build(context.Background(), newTopologyTestSettings())
+	require.NoError(t, err)
+	tracesInID := pipeline.NewIDWithName(pipeline.SignalTraces, "in")
+	metricsOutID := pipeline.NewIDWithName(pipeline.SignalMetrics, "out")
+	host := &HostWrapper{
+		Host:       &Host{Pipelines: pg, InternalTelemetry: &servicetelemetry.Sink{}},
+		InstanceID: componentstatus.NewInstanceID(component.MustNewID("examplereceiver"), component.KindReceiver, tracesInID),
+	}
+	traces := new(consumertest.TracesSink)
+	unregister, err := host.ConsumeInternalTraces(traces)
+	require.NoError(t, err)
+	defer unregister()
+
+	withoutPipeline := otelattr.NewSet(
+		otelattr.String(telemetry.ComponentKindKey, "processor"),
+		otelattr.String(telemetry.ComponentIDKey, "exampleprocessor/mutate"),
+	)
+	tests := []struct {
+		name    string
+		scope   *otelattr.Set
+		dropped bool
+	}{
+		{name: "processor", scope: attribute.Processor(tracesInID, component.MustNewIDWithName("exampleprocessor", "mutate")).Set(), dropped: true},
+		{name: "processor_without_pipeline", scope: &withoutPipeline, dropped: true},
+		{name: "connector", scope: attribute.Connector(pipeline.SignalTraces, pipeline.SignalMetrics, component.MustNewID("exampleconnector")).Set(), dropped: true},
+		{name: "downstream_exporter", scope: attribute.Exporter(metricsOutID.Signal(), component.MustNewID("exampleexporter")).Set(), dropped: true},
+		{name: "other_exporter", scope: attribute.Exporter(pipeline.SignalTraces, component.MustNewID("exampleexporter")).Set()},
+		{name: "no_component", scope: &otelattr.Set{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces.Reset()
+			td := ptrace.NewTraces()
+			ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+			putScopeAttributes(ss.Scope().Attributes(), *tt.scope)
+			ss.Spans().AppendEmpty().SetName("span")
+			require.NoError(t, host.InternalTelemetry.ConsumeTraces(context.Background(), td))
+			if tt.dropped {
+				assert.Empty(t, traces.AllTraces())
+			} else {
+				require.Len(t, traces.AllTraces(), 1)
+				assert.Equal(t, 1, traces.AllTraces()[0].SpanCount())
+			}
+		})
+	}
+}
+
+func TestInternalLogsFilter(t *testing.T) {
+	processor := attribute.Processor(pipeline.NewID(pipeline.SignalLogs), component.MustNewID("exampleprocessor")).Set()
+	logs := new(consumertest.LogsSink)
+	filter := &internalLogsFilter{
+		Logs:       logs,
+		components: func() []otelattr.Set { return []otelattr.Set{*processor} },
+	}
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	sl := rl.ScopeLogs().AppendEmpty()
+	putScopeAttributes(sl.Scope().Attributes(), *processor)
+	sl.LogRecords().AppendEmpty()
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, filter.ConsumeLogs(context.Background(), ld))
+	require.Len(t, logs.AllLogs(), 1)
+	assert.Equal(t, 1, logs.AllLogs()[0].LogRecordCount())
+
+	// Nothing is consumed once all the logs are dropped.
+	logs.Reset()
+	ld = plog.NewLogs()
+	sl = ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	putScopeAttributes(sl.Scope().Attributes(), *processor)
+	sl.LogRecords().AppendEmpty()
+	require.NoError(t, filter.ConsumeLogs(context.Background(), ld))
+	assert.Empty(t, logs.AllLogs())
+}
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 )
 
 var _ consumerNode = (*processorNode)(nil)
@@ -27,10 +28,13 @@ var _ consumerNode = (*processorNode)(nil)
 // Therefore, nodeID is derived from "pipeline ID" and "component ID".
 type processorNode struct {
 	attribute.Attributes
+	flows
 	componentID component.ID
 	pipelineID  pipeline.ID
 	component.Component
 	consumer baseConsumer
+	// tap samples the data consumed by the processor.
+	tap *tapconsumer.Tap
 }
 
 func newProcessorNode(pipelineID pipeline.ID, procID component.ID) *processorNode {
@@ -66,11 +70,13 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		ItemCounter: tb.ProcessorProducedItems,
 		SizeCounter: tb.ProcessorProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 	consumedSettings := obsconsumer.Settings{
 		ItemCounter: tb.ProcessorConsumedItems,
 		SizeCounter: tb.ProcessorConsumedSize,
 		Logger:      set.Logger,
+		Flow:        n.consumed,
 	}
 
 	switch n.pipelineID.Signal() {
@@ -81,7 +87,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
-		n.consumer = obsconsumer.NewTraces(n.Component.(consumer.Traces), consumedSettings)
+		n.consumer = obsconsumer.NewTraces(tapconsumer.NewTraces(n.Component.(consumer.Traces), n.tap), consumedSettings)
 		n.consumer = refconsumer.NewTraces(n.consumer.(consumer.Traces))
 	case pipeline.SignalMetrics:
 		n.Component, err = builder.CreateMetrics(ctx, set,
@@ -89,7 +95,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
-		n.consumer = obsconsumer.NewMetrics(n.Component.(consumer.Metrics), consumedSettings)
+		n.consumer = obsconsumer.NewMetrics(tapconsumer.NewMetrics(n.Component.(consumer.Metrics), n.tap), consumedSettings)
 		n.consumer = refconsumer.NewMetrics(n.consumer.(consumer.Metrics))
 	case pipeline.SignalLogs:
 		n.Component, err = builder.CreateLogs(ctx, set,
@@ -97,7 +103,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
-		n.consumer = obsconsumer.NewLogs(n.Component.(consumer.Logs), consumedSettings)
+		n.consumer = obsconsumer.NewLogs(tapconsumer.NewLogs(n.Component.(consumer.Logs), n.tap), consumedSettings)
 		n.consumer = refconsumer.NewLogs(n.consumer.(consumer.Logs))
 	case xpipeline.SignalProfiles:
 		n.Component, err = builder.CreateProfiles(ctx, set,
@@ -105,7 +111,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
 		}
-		n.consumer = obsconsumer.NewProfiles(n.Component.(xconsumer.Profiles), consumedSettings)
+		n.consumer = obsconsumer.NewProfiles(tapconsumer.NewProfiles(n.Component.(xconsumer.Profiles), n.tap), consumedSettings)
 		n.consumer = refconsumer.NewProfiles(n.consumer.(xconsumer.Profiles))
 	default:
 		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID.String(), n.pipelineID.Signal())
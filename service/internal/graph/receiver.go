@@ -19,15 +19,19 @@ import (
 	"go.opentelemetry.io/collector/service/internal/componentattribute"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 )
 
 // A receiver instance can be shared by multiple pipelines of the same type.
 // Therefore, nodeID is derived from "pipeline type" and "component ID".
 type receiverNode struct {
 	attribute.Attributes
+	flows
 	componentID  component.ID
 	pipelineType pipeline.Signal
 	component.Component
+	// tap samples the data produced by the receiver.
+	tap *tapconsumer.Tap
 }
 
 func newReceiverNode(pipelineType pipeline.Signal, recvID component.ID) *receiverNode {
@@ -59,6 +63,7 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		ItemCounter: tb.ReceiverProducedItems,
 		SizeCounter: tb.ReceiverProducedSize,
 		Logger:      set.Logger,
+		Flow:        n.produced,
 	}
 
 	switch n.pipelineType {
@@ -68,7 +73,7 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 			consumers = append(consumers, next.(consumer.Traces))
 		}
 		n.Component, err = builder.CreateTraces(ctx, set,
-			obsconsumer.NewTraces(fanoutconsumer.NewTraces(consumers), producedSettings),
+			obsconsumer.NewTraces(tapconsumer.NewTraces(fanoutconsumer.NewTraces(consumers), n.tap), producedSettings),
 		)
 	case pipeline.SignalMetrics:
 		var consumers []consumer.Metrics
@@ -76,21 +81,21 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
 		n.Component, err = builder.CreateMetrics(ctx, set,
-			obsconsumer.NewMetrics(fanoutconsumer.NewMetrics(consumers), producedSettings))
+			obsconsumer.NewMetrics(tapconsumer.NewMetrics(fanoutconsumer.NewMetrics(consumers), n.tap), producedSettings))
 	case pipeline.SignalLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
 		n.Component, err = builder.CreateLogs(ctx, set,
-			obsconsumer.NewLogs(fanoutconsumer.NewLogs(consumers), producedSettings))
+			obsconsumer.NewLogs(tapconsumer.NewLogs(fanoutconsumer.NewLogs(consumers), n.tap), producedSettings))
 	case xpipeline.SignalProfiles:
 		var consumers []xconsumer.Profiles
 		for _, next := range nexts {
 			consumers = append(consumers, next.(xconsumer.Profiles))
 		}
 		n.Component, err = builder.CreateProfiles(ctx, set,
-			obsconsumer.NewProfiles(fanoutconsumer.NewProfiles(consumers), producedSettings))
+			obsconsumer.NewProfiles(tapconsumer.NewProfiles(fanoutconsumer.NewProfiles(consumers), n.tap), producedSettings))
 	default:
 		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
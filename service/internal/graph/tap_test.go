@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestGraphTap(t *testing.T) {
+	pg, err := Build(context.Background(), newTopologyTestSettings())
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewNopStatusReporter()}))
+	t.Cleanup(func() { assert.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter())) })
+	host := &Host{Pipelines: pg}
+
+	tracesInID := pipeline.NewIDWithName(pipeline.SignalTraces, "in")
+	metricsOutID := pipeline.NewIDWithName(pipeline.SignalMetrics, "out")
+	tests := []struct {
+		name       string
+		pipelineID pipeline.ID
+		kind       component.Kind
+		id         component.ID
+		traces     bool
+	}{
+		{name: "receiver", pipelineID: tracesInID, kind: component.KindReceiver, id: component.MustNewID("examplereceiver"), traces: true},
+		{name: "processor", pipelineID: tracesInID, kind: component.KindProcessor, id: component.MustNewIDWithName("exampleprocessor", "mutate"), traces: true},
+		{name: "connector_exporter", pipelineID: tracesInID, kind: component.KindConnector, id: component.MustNewID("exampleconnector"), traces: true},
+		{name: "connector_receiver", pipelineID: metricsOutID, kind: component.KindConnector, id: component.MustNewID("exampleconnector")},
+		{name: "exporter", pipelineID: metricsOutID, kind: component.KindExporter, id: component.MustNewID("exampleexporter")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, cancel, err := host.Tap(tt.pipelineID, tt.kind, tt.id, time.Nanosecond)
+			require.NoError(t, err)
+			defer cancel()
+
+			rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+			require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+			sample := <-samples
+			if tt.traces {
+				td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(sample)
+				require.NoError(t, err)
+				assert.Equal(t, 2, td.SpanCount())
+			} else {
+				md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(sample)
+				require.NoError(t, err)
+				assert.Equal(t, testdata.GenerateMetrics(2).DataPointCount(), md.DataPointCount())
+			}
+		})
+	}
+
+	_, _, err = host.Tap(pipeline.NewID(pipeline.SignalLogs), component.KindReceiver, component.MustNewID("examplereceiver"), time.Second)
+	require.EqualError(t, err, `pipeline "logs" not found`)
+	_, _, err = host.Tap(metricsOutID, component.KindReceiver, component.MustNewID("examplereceiver"), time.Second)
+	require.EqualError(t, err, `receiver "examplereceiver" not found in pipeline "metrics/out"`)
+	_, _, err = host.Tap(tracesInID, component.KindExporter, component.MustNewID("exampleconnector"), time.Second)
+	require.EqualError(t, err, `exporter "exampleconnector" not found in pipeline "traces/in"`)
+	_, _, err = host.Tap(tracesInID, component.KindExtension, component.MustNewID("zpages"), time.Second)
+	require.EqualError(t, err, `extension "zpages" not found in pipeline "traces/in"`)
+}
+
+func TestGraphTapUpdatePipelines(t *testing.T) {
+	var (
+		recv    = component.MustNewID("examplereceiver")
+		recv2   = component.MustNewIDWithName("examplereceiver", "2")
+		proc    = component.MustNewID("exampleprocessor")
+		exp     = component.MustNewID("exampleexporter")
+		exp2    = component.MustNewIDWithName("exampleexporter", "2")
+		traces  = pipeline.NewID(pipeline.SignalTraces)
+		traces2 = pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	)
+	initial := pipelines.Config{
+		traces:  {Receivers: []component.ID{recv}, Processors: []component.ID{proc}, Exporters: []component.ID{exp}},
+		traces2: {Receivers: []component.ID{recv2}, Processors: []component.ID{proc}, Exporters: []component.ID{exp2}},
+	}
+	pg, err := Build(context.Background(), updatePipelinesTestSettings(initial))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewNopStatusReporter(), Pipelines: pg}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	rebuilt, cancel, err := host.Tap(traces, component.KindProcessor, proc, time.Nanosecond)
+	require.NoError(t, err)
+	defer cancel()
+	removed, cancel2, err := host.Tap(traces2, component.KindProcessor, proc, time.Nanosecond)
+	require.NoError(t, err)
+	defer cancel2()
+
+	// The taps, the topology and the flows are looked up while the pipelines are updated.
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_, _ = pg.Tap(traces, component.KindReceiver, recv)
+				_ = pg.Topology()
+				_ = pg.flowSnapshot()
+			}
+		}
+	}()
+
+	// The processor is rebuilt and the second pipeline is removed.
+	updated := maps.Clone(initial)
+	delete(updated, traces2)
+	require.NoError(t, pg.UpdatePipelines(context.Background(), updatePipelinesTestSettings(updated, proc),
+		map[component.Kind]map[component.ID]bool{component.KindProcessor: {proc: true}}, host))
+	close(done)
+	wg.Wait()
+
+	// The subscription to the removed processor ends.
+	_, ok := <-removed
+	assert.False(t, ok)
+
+	// The subscription to the rebuilt processor keeps sampling its data.
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][recv].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-rebuilt)
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+
+	// The subscriptions end once the pipelines are shut down.
+	require.NoError(t, pg.ShutdownAll(context.Background(), status.NewNopStatusReporter()))
+	for range rebuilt {
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/topology"
+)
+
+// Topology returns the topology of the built graph.
+func (g *Graph) Topology() *topology.Topology {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	t := &topology.Topology{Nodes: []topology.Node{}, Edges: []topology.Edge{}}
+	ids := make(map[int64]string)
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		n := g.topologyNode(nodes.Node())
+		ids[nodes.Node().ID()] = n.ID
+		t.Nodes = append(t.Nodes, n)
+	}
+	slices.SortFunc(t.Nodes, func(a, b topology.Node) int { return strings.Compare(a.ID, b.ID) })
+
+	edges := g.componentGraph.Edges()
+	for edges.Next() {
+		t.Edges = append(t.Edges, topology.Edge{
+			From: ids[edges.Edge().From().ID()],
+			To:   ids[edges.Edge().To().ID()],
+		})
+	}
+	slices.SortFunc(t.Edges, func(a, b topology.Edge) int {
+		return cmp.Or(strings.Compare(a.From, b.From), strings.Compare(a.To, b.To))
+	})
+	return t
+}
+
+// topologyNode returns the topology node of a node of the graph. The caller must hold g.mu.
+func (g *Graph) topologyNode(node graph.Node) topology.Node {
+	n := newNodeTopology(node)
+	if instanceID, ok := g.instanceIDs[node.ID()]; ok {
+		instanceID.AllPipelineIDs(func(id pipeline.ID) bool {
+			n.Pipelines = append(n.Pipelines, id.String())
+			return true
+		})
+		slices.Sort(n.Pipelines)
+	}
+	if cn, ok := node.(consumerNode); ok && cn.getConsumer() != nil {
+		n.MutatesData = cn.getConsumer().Capabilities().MutatesData
+	}
+	return n
+}
+
+// newNodeTopology returns the topology node of a node of the graph, without its pipelines and capabilities.
+func newNodeTopology(node graph.Node) topology.Node {
+	switch n := node.(type) {
+	case *receiverNode:
+		return newTopologyNode(component.KindReceiver, n.pipelineType.String(), n.componentID)
+	case *processorNode:
+		tn := newTopologyNode(component.KindProcessor, n.pipelineID.String(), n.componentID)
+		tn.Signal = n.pipelineID.Signal().String()
+		return tn
+	case *exporterNode:
+		return newTopologyNode(component.KindExporter, n.pipelineType.String(), n.componentID)
+	case *connectorNode:
+		tn := newTopologyNode(component.KindConnector,
+			n.exprPipelineType.String()+":"+n.rcvrPipelineType.String(), n.componentID)
+		tn.Signal = n.exprPipelineType.String()
+		tn.OutputSignal = n.rcvrPipelineType.String()
+		return tn
+	case *capabilitiesNode:
+		return topology.Node{
+			ID:        topology.KindCapabilities + ":" + n.pipelineID.String(),
+			Kind:      topology.KindCapabilities,
+			Signal:    n.pipelineID.Signal().String(),
+			Pipelines: []string{n.pipelineID.String()},
+		}
+	case *fanOutNode:
+		return topology.Node{
+			ID:        topology.KindFanout + ":" + n.pipelineID.String(),
+			Kind:      topology.KindFanout,
+			Signal:    n.pipelineID.Signal().String(),
+			Pipelines: []string{n.pipelineID.String()},
+		}
+	}
+	return topology.Node{}
+}
+
+// newTopologyNode returns the topology node of a component, identified by its kind, its scope, i.e. its signal
+// or pipeline, and its ID.
+func newTopologyNode(kind component.Kind, scope string, id component.ID) topology.Node {
+	k := strings.ToLower(kind.String())
+	return topology.Node{
+		ID:          k + ":" + scope + ":" + id.String(),
+		Kind:        k,
+		ComponentID: id.String(),
+		Signal:      scope,
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/topology"
+)
+
+// newTopologyTestSettings returns the settings of a graph with a traces/in pipeline, with a mutating
+// processor, connected to a metrics/out pipeline.
+func newTopologyTestSettings() Settings {
+	tracesInID := pipeline.NewIDWithName(pipeline.SignalTraces, "in")
+	metricsOutID := pipeline.NewIDWithName(pipeline.SignalMetrics, "out")
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				component.MustNewIDWithName("exampleprocessor", "mutate"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			},
+		),
+		PipelineConfigs: pipelines.Config{
+			tracesInID: {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+				Exporters:  []component.ID{component.MustNewID("exampleconnector")},
+			},
+			metricsOutID: {
+				Receivers: []component.ID{component.MustNewID("exampleconnector")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+	}
+}
+
+func TestGraphTopology(t *testing.T) {
+	pg, err := Build(context.Background(), newTopologyTestSettings())
+	require.NoError(t, err)
+
+	assert.Equal(t, &topology.Topology{
+		Nodes: []topology.Node{
+			{ID: "capabilities:metrics/out", Kind: topology.KindCapabilities, Signal: "metrics", Pipelines: []string{"metrics/out"}},
+			{ID: "capabilities:traces/in", Kind: topology.KindCapabilities, Signal: "traces", Pipelines: []string{"traces/in"}, MutatesData: true},
+			{ID: "connector:traces:metrics:exampleconnector", Kind: "connector", ComponentID: "exampleconnector", Signal: "traces", OutputSignal: "metrics", Pipelines: []string{"metrics/out", "traces/in"}},
+			{ID: "exporter:metrics:exampleexporter", Kind: "exporter", ComponentID: "exampleexporter", Signal: "metrics", Pipelines: []string{"metrics/out"}},
+			{ID: "fanout:metrics/out", Kind: topology.KindFanout, Signal: "metrics", Pipelines: []string{"metrics/out"}},
+			{ID: "fanout:traces/in", Kind: topology.KindFanout, Signal: "traces", Pipelines: []string{"traces/in"}},
+			{ID: "processor:traces/in:exampleprocessor/mutate", Kind: "processor", ComponentID: "exampleprocessor/mutate", Signal: "traces", Pipelines: []string{"traces/in"}, MutatesData: true},
+			{ID: "receiver:traces:examplereceiver", Kind: "receiver", ComponentID: "examplereceiver", Signal: "traces", Pipelines: []string{"traces/in"}},
+		},
+		Edges: []topology.Edge{
+			{From: "capabilities:metrics/out", To: "fanout:metrics/out"},
+			{From: "capabilities:traces/in", To: "processor:traces/in:exampleprocessor/mutate"},
+			{From: "connector:traces:metrics:exampleconnector", To: "capabilities:metrics/out"},
+			{From: "fanout:metrics/out", To: "exporter:metrics:exampleexporter"},
+			{From: "fanout:traces/in", To: "connector:traces:metrics:exampleconnector"},
+			{From: "processor:traces/in:exampleprocessor/mutate", To: "fanout:traces/in"},
+			{From: "receiver:traces:examplereceiver", To: "capabilities:traces/in"},
+		},
+	}, pg.Topology())
+}
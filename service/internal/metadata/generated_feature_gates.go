@@ -22,6 +22,14 @@ var ServicePartialReloadFeatureGate = featuregate.GlobalRegistry().MustRegister(
 	featuregate.WithRegisterFromVersion("v0.157.0"),
 )
 
+var ServicePartialReloadPipelinesFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"service.partialReloadPipelines",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("Controls whether configuration changes of processors, exporters, connectors and pipelines restart only the affected components."),
+	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector/blob/main/docs/rfcs/partial-reload.md"),
+	featuregate.WithRegisterFromVersion("v0.160.0"),
+)
+
 var ServicePartialReloadReceiversFeatureGate = featuregate.GlobalRegistry().MustRegister(
 	"service.partialReloadReceivers",
 	featuregate.StageBeta,
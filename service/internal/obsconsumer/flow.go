@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsconsumer // import "go.opentelemetry.io/collector/service/internal/obsconsumer"
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Flow counts the items flowing through consumers, e.g. to compute the live throughput of a component.
+// The zero value counts all the items, and a nil Flow counts nothing.
+type Flow struct {
+	// counting, if set, reports whether the items are counted, e.g. only while the flows are served.
+	counting *atomic.Bool
+	items    atomic.Int64
+	refused  atomic.Int64
+	failed   atomic.Int64
+}
+
+// NewFlow returns a Flow only counting the items while counting is true.
+func NewFlow(counting *atomic.Bool) *Flow {
+	return &Flow{counting: counting}
+}
+
+// FlowCounts are the counts of a Flow.
+type FlowCounts struct {
+	// Items is the number of items passed to the consumers, whatever the outcome.
+	Items int64 `json:"items"`
+	// Refused is the number of items refused by a consumer further downstream.
+	Refused int64 `json:"refused"`
+	// Failed is the number of items the consumers failed to consume.
+	Failed int64 `json:"failed"`
+}
+
+// Counts returns the current counts of the flow.
+func (f *Flow) Counts() FlowCounts {
+	if f == nil {
+		return FlowCounts{}
+	}
+	return FlowCounts{
+		Items:   f.items.Load(),
+		Refused: f.refused.Load(),
+		Failed:  f.failed.Load(),
+	}
+}
+
+// record counts the items of a call to a consumer, with the error it returned, before it is marked as a
+// downstream error.
+func (f *Flow) record(items int, err error) {
+	if f == nil || (f.counting != nil && !f.counting.Load()) {
+		return
+	}
+	f.items.Add(int64(items))
+	switch {
+	case err == nil:
+	case consumererror.IsDownstream(err):
+		f.refused.Add(int64(items))
+	default:
+		f.failed.Add(int64(items))
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package obsconsumer_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+)
+
+func TestFlow(t *testing.T) {
+	setGateForTest(t, true)
+
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+	itemCounter, err := meter.Int64Counter("item_counter")
+	require.NoError(t, err)
+	sizeCounter, err := meter.Int64Counter("size_counter")
+	require.NoError(t, err)
+
+	counting := &atomic.Bool{}
+	flow := obsconsumer.NewFlow(counting)
+	mockConsumer := &mockTracesConsumer{}
+	cons := obsconsumer.NewTraces(mockConsumer, obsconsumer.Settings{
+		ItemCounter: itemCounter,
+		SizeCounter: sizeCounter,
+		Logger:      zap.NewNop(),
+		Flow:        flow,
+	})
+
+	// The items are not counted until counting is enabled.
+	require.NoError(t, cons.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	assert.Equal(t, obsconsumer.FlowCounts{}, flow.Counts())
+
+	counting.Store(true)
+	require.NoError(t, cons.ConsumeTraces(ctx, testdata.GenerateTraces(2)))
+	mockConsumer.err = consumererror.NewDownstream(errors.New("refused"))
+	require.Error(t, cons.ConsumeTraces(ctx, testdata.GenerateTraces(3)))
+	mockConsumer.err = errors.New("failed")
+	err = cons.ConsumeTraces(ctx, testdata.GenerateTraces(4))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsDownstream(err))
+
+	assert.Equal(t, obsconsumer.FlowCounts{Items: 9, Refused: 3, Failed: 4}, flow.Counts())
+}
+
+func TestFlowGateDisabled(t *testing.T) {
+	setGateForTest(t, false)
+
+	flow := &obsconsumer.Flow{}
+	mockConsumer := &mockTracesConsumer{err: errors.New("failed")}
+	cons := obsconsumer.NewTraces(mockConsumer, obsconsumer.Settings{
+		ItemCounter: noop.Int64Counter{},
+		SizeCounter: noop.Int64Counter{},
+		Logger:      zap.NewNop(),
+		Flow:        flow,
+	})
+
+	// The consumer is not instrumented, its errors are returned as is.
+	assert.Same(t, mockConsumer, cons)
+	err := cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsDownstream(err))
+	assert.Equal(t, obsconsumer.FlowCounts{}, flow.Counts())
+}
+
+func TestFlowNil(t *testing.T) {
+	var flow *obsconsumer.Flow
+	assert.Equal(t, obsconsumer.FlowCounts{}, flow.Counts())
+}
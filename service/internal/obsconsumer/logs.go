@@ -34,6 +34,7 @@ func NewLogs(cons consumer.Logs, set Settings, opts ...Option) consumer.Logs {
 		ItemCounter: set.ItemCounter,
 		SizeCounter: set.SizeCounter,
 		Logger:      set.Logger.With(telemetry.ToZapFields(o.staticDataPointAttributes)...),
+		Flow:        set.Flow,
 	}
 
 	return obsLogs{
@@ -67,6 +68,7 @@ func (c obsLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	}
 
 	err := c.consumer.ConsumeLogs(ctx, ld)
+	c.set.Flow.record(itemCount, err)
 	if err != nil {
 		if consumererror.IsDownstream(err) {
 			attrs = &c.withRefusedAttrs
@@ -34,6 +34,7 @@ func NewMetrics(cons consumer.Metrics, set Settings, opts ...Option) consumer.Me
 		ItemCounter: set.ItemCounter,
 		SizeCounter: set.SizeCounter,
 		Logger:      set.Logger.With(telemetry.ToZapFields(o.staticDataPointAttributes)...),
+		Flow:        set.Flow,
 	}
 
 	return obsMetrics{
@@ -67,6 +68,7 @@ func (c obsMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 	}
 
 	err := c.consumer.ConsumeMetrics(ctx, md)
+	c.set.Flow.record(itemCount, err)
 	if err != nil {
 		if consumererror.IsDownstream(err) {
 			attrs = &c.withRefusedAttrs
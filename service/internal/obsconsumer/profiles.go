@@ -35,6 +35,7 @@ func NewProfiles(cons xconsumer.Profiles, set Settings, opts ...Option) xconsume
 		ItemCounter: set.ItemCounter,
 		SizeCounter: set.SizeCounter,
 		Logger:      set.Logger.With(telemetry.ToZapFields(o.staticDataPointAttributes)...),
+		Flow:        set.Flow,
 	}
 
 	return obsProfiles{
@@ -68,6 +69,7 @@ func (c obsProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles)
 	}
 
 	err := c.consumer.ConsumeProfiles(ctx, pd)
+	c.set.Flow.record(itemCount, err)
 	if err != nil {
 		if consumererror.IsDownstream(err) {
 			attrs = &c.withRefusedAttrs
@@ -18,4 +18,8 @@ type Settings struct {
 
 	// Logger is the logger for the obsconsumer package.
 	Logger *zap.Logger
+
+	// Flow optionally counts the items flowing through the consumer. Like the counters, it is only updated
+	// if the telemetry.newPipelineTelemetry feature gate is enabled.
+	Flow *Flow
 }
@@ -34,6 +34,7 @@ func NewTraces(cons consumer.Traces, set Settings, opts ...Option) consumer.Trac
 		ItemCounter: set.ItemCounter,
 		SizeCounter: set.SizeCounter,
 		Logger:      set.Logger.With(telemetry.ToZapFields(o.staticDataPointAttributes)...),
+		Flow:        set.Flow,
 	}
 
 	return obsTraces{
@@ -67,6 +68,7 @@ func (c obsTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	}
 
 	err := c.consumer.ConsumeTraces(ctx, td)
+	c.set.Flow.record(itemCount, err)
 	if err != nil {
 		if consumererror.IsDownstream(err) {
 			attrs = &c.withRefusedAttrs
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestTraces(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+	sink := &consumertest.TracesSink{}
+	cons := NewTraces(sink, tap)
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 2, sink.SpanCount())
+	sample, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-sub.Samples())
+	require.NoError(t, err)
+	assert.Equal(t, td, sample)
+}
+
+func TestMetrics(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+	sink := &consumertest.MetricsSink{}
+	cons := NewMetrics(sink, tap)
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+
+	md := testdata.GenerateMetrics(2)
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, md.DataPointCount(), sink.DataPointCount())
+	sample, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(<-sub.Samples())
+	require.NoError(t, err)
+	assert.Equal(t, md, sample)
+}
+
+func TestLogs(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+	sink := &consumertest.LogsSink{}
+	cons := NewLogs(sink, tap)
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+
+	ld := testdata.GenerateLogs(2)
+	require.NoError(t, cons.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	sample, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(<-sub.Samples())
+	require.NoError(t, err)
+	assert.Equal(t, ld, sample)
+}
+
+func TestProfiles(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+	sink := &consumertest.ProfilesSink{}
+	cons := NewProfiles(sink, tap)
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+
+	pd := testdata.GenerateProfiles(2)
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), pd))
+	assert.Equal(t, 2, sink.SampleCount())
+	_, err := (&pprofile.JSONUnmarshaler{}).UnmarshalProfiles(<-sub.Samples())
+	require.NoError(t, err)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var logsMarshaler = &plog.JSONMarshaler{}
+
+// NewLogs returns a consumer sampling the logs passed to the next consumer for the subscribers of the tap.
+func NewLogs(cons consumer.Logs, tap *Tap) consumer.Logs {
+	return tapLogs{
+		consumer: cons,
+		tap:      tap,
+	}
+}
+
+type tapLogs struct {
+	consumer consumer.Logs
+	tap      *Tap
+}
+
+func (c tapLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.tap.sample(func() ([]byte, error) { return logsMarshaler.MarshalLogs(ld) })
+	return c.consumer.ConsumeLogs(ctx, ld)
+}
+
+func (c tapLogs) Capabilities() consumer.Capabilities {
+	return c.consumer.Capabilities()
+}
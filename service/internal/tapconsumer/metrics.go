@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var metricsMarshaler = &pmetric.JSONMarshaler{}
+
+// NewMetrics returns a consumer sampling the metrics passed to the next consumer for the subscribers of the tap.
+func NewMetrics(cons consumer.Metrics, tap *Tap) consumer.Metrics {
+	return tapMetrics{
+		consumer: cons,
+		tap:      tap,
+	}
+}
+
+type tapMetrics struct {
+	consumer consumer.Metrics
+	tap      *Tap
+}
+
+func (c tapMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.tap.sample(func() ([]byte, error) { return metricsMarshaler.MarshalMetrics(md) })
+	return c.consumer.ConsumeMetrics(ctx, md)
+}
+
+func (c tapMetrics) Capabilities() consumer.Capabilities {
+	return c.consumer.Capabilities()
+}
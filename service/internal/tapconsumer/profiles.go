@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+var profilesMarshaler = &pprofile.JSONMarshaler{}
+
+// NewProfiles returns a consumer sampling the profiles passed to the next consumer for the subscribers of the tap.
+func NewProfiles(cons xconsumer.Profiles, tap *Tap) xconsumer.Profiles {
+	return tapProfiles{
+		consumer: cons,
+		tap:      tap,
+	}
+}
+
+type tapProfiles struct {
+	consumer xconsumer.Profiles
+	tap      *Tap
+}
+
+func (c tapProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.tap.sample(func() ([]byte, error) { return profilesMarshaler.MarshalProfiles(pd) })
+	return c.consumer.ConsumeProfiles(ctx, pd)
+}
+
+func (c tapProfiles) Capabilities() consumer.Capabilities {
+	return c.consumer.Capabilities()
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tapconsumer samples the data flowing through the pipelines for the subscribers of a Tap, e.g. to
+// inspect the data between two components of a running Collector.
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Tap sends samples of the data passed to its consumers to its subscribers. The zero value is ready to use.
+// The consumers only check whether the tap has subscribers while nobody is subscribed.
+type Tap struct {
+	mu          sync.Mutex
+	closed      bool
+	subscribers atomic.Pointer[[]*Subscription]
+}
+
+// Subscription receives samples of the data passed to the consumers of a Tap, rendered as OTLP JSON.
+type Subscription struct {
+	tap      *Tap
+	interval int64
+	last     atomic.Int64
+
+	// mu guards sending the samples and closing the channel.
+	mu      sync.Mutex
+	closed  bool
+	samples chan []byte
+}
+
+// Subscribe returns a subscription receiving at most one sample every interval. The samples are dropped
+// while the previous ones are not received, the consumers never wait for the subscribers.
+// If the tap is closed, the subscription is returned closed.
+func (t *Tap) Subscribe(interval time.Duration) *Subscription {
+	s := &Subscription{
+		tap:      t,
+		interval: int64(interval),
+		samples:  make(chan []byte, 1),
+	}
+	s.last.Store(time.Now().Add(-interval).UnixNano())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		s.closeSamples()
+		return s
+	}
+	var subscribers []*Subscription
+	if current := t.subscribers.Load(); current != nil {
+		subscribers = slices.Clone(*current)
+	}
+	subscribers = append(subscribers, s)
+	t.subscribers.Store(&subscribers)
+	return s
+}
+
+// Close closes all the subscriptions of the tap, and the ones subscribed afterward, e.g. once the component
+// it samples is removed from the pipelines.
+func (t *Tap) Close() {
+	t.mu.Lock()
+	t.closed = true
+	current := t.subscribers.Swap(nil)
+	t.mu.Unlock()
+	if current == nil {
+		return
+	}
+	for _, s := range *current {
+		s.closeSamples()
+	}
+}
+
+// Samples returns the channel the samples are sent to. It is closed once the subscription or its tap is closed.
+func (s *Subscription) Samples() <-chan []byte {
+	return s.samples
+}
+
+// Close removes the subscription from its tap, no sample is sent once it returns.
+func (s *Subscription) Close() {
+	s.tap.mu.Lock()
+	if current := s.tap.subscribers.Load(); current != nil {
+		subscribers := slices.DeleteFunc(slices.Clone(*current), func(other *Subscription) bool { return other == s })
+		if len(subscribers) == 0 {
+			s.tap.subscribers.Store(nil)
+		} else {
+			s.tap.subscribers.Store(&subscribers)
+		}
+	}
+	s.tap.mu.Unlock()
+	s.closeSamples()
+}
+
+// send sends the sample unless the subscription is closed, or the previous sample is not received.
+func (s *Subscription) send(b []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.samples <- b:
+	default:
+	}
+}
+
+func (s *Subscription) closeSamples() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.samples)
+	}
+}
+
+// allow returns whether a sample can be sent to the subscription at the given time, and if so records it as
+// the time of its last sample.
+func (s *Subscription) allow(now int64) bool {
+	last := s.last.Load()
+	return now-last >= s.interval && s.last.CompareAndSwap(last, now)
+}
+
+// sample sends the data to the subscribers allowing a sample, rendered by marshal at most once. It must be
+// called before the data is passed to the next consumer, which may mutate it.
+func (t *Tap) sample(marshal func() ([]byte, error)) {
+	subscribers := t.subscribers.Load()
+	if subscribers == nil {
+		return
+	}
+	now := time.Now().UnixNano()
+	var b []byte
+	for _, s := range *subscribers {
+		if !s.allow(now) {
+			continue
+		}
+		if b == nil {
+			var err error
+			if b, err = marshal(); err != nil {
+				return
+			}
+		}
+		s.send(b)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTapWithoutSubscribers(t *testing.T) {
+	tap := &Tap{}
+	tap.sample(func() ([]byte, error) {
+		t.Fatal("the data must not be rendered without subscribers")
+		return nil, nil
+	})
+}
+
+func TestTapInterval(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(time.Hour)
+	defer sub.Close()
+
+	renders := 0
+	marshal := func() ([]byte, error) {
+		renders++
+		return []byte("sample"), nil
+	}
+	tap.sample(marshal)
+	tap.sample(marshal)
+	assert.Equal(t, 1, renders)
+	assert.Equal(t, []byte("sample"), <-sub.Samples())
+
+	// The next sample is only sent after the interval.
+	sub.last.Add(-int64(time.Hour))
+	tap.sample(marshal)
+	assert.Equal(t, 2, renders)
+	assert.Len(t, sub.Samples(), 1)
+}
+
+func TestTapSlowSubscriber(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+
+	// The samples are dropped while the subscriber does not receive them.
+	for range 3 {
+		tap.sample(func() ([]byte, error) { return []byte("sample"), nil })
+	}
+	assert.Len(t, sub.Samples(), 1)
+}
+
+func TestTapSubscribers(t *testing.T) {
+	tap := &Tap{}
+	first := tap.Subscribe(0)
+	second := tap.Subscribe(0)
+
+	renders := 0
+	marshal := func() ([]byte, error) {
+		renders++
+		return []byte("sample"), nil
+	}
+	tap.sample(marshal)
+	assert.Equal(t, 1, renders)
+	assert.Len(t, first.Samples(), 1)
+	assert.Len(t, second.Samples(), 1)
+	<-first.Samples()
+	<-second.Samples()
+
+	first.Close()
+	tap.sample(marshal)
+	assert.Empty(t, first.Samples())
+	assert.Len(t, second.Samples(), 1)
+
+	second.Close()
+	require.Nil(t, tap.subscribers.Load())
+	second.Close()
+}
+
+func TestTapMarshalError(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	defer sub.Close()
+	tap.sample(func() ([]byte, error) { return nil, errors.New("marshal failed") })
+	assert.Empty(t, sub.Samples())
+}
+
+func TestTapClose(t *testing.T) {
+	tap := &Tap{}
+	sub := tap.Subscribe(0)
+	tap.sample(func() ([]byte, error) { return []byte("sample"), nil })
+
+	// The samples already sent are still received, then the channel is closed.
+	tap.Close()
+	assert.Equal(t, []byte("sample"), <-sub.Samples())
+	_, ok := <-sub.Samples()
+	assert.False(t, ok)
+	tap.sample(func() ([]byte, error) {
+		t.Fatal("the data must not be rendered once the tap is closed")
+		return nil, nil
+	})
+	sub.Close()
+
+	// The subscriptions to a closed tap are closed.
+	_, ok = <-tap.Subscribe(0).Samples()
+	assert.False(t, ok)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var tracesMarshaler = &ptrace.JSONMarshaler{}
+
+// NewTraces returns a consumer sampling the traces passed to the next consumer for the subscribers of the tap.
+func NewTraces(cons consumer.Traces, tap *Tap) consumer.Traces {
+	return tapTraces{
+		consumer: cons,
+		tap:      tap,
+	}
+}
+
+type tapTraces struct {
+	consumer consumer.Traces
+	tap      *Tap
+}
+
+func (c tapTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c.tap.sample(func() ([]byte, error) { return tracesMarshaler.MarshalTraces(td) })
+	return c.consumer.ConsumeTraces(ctx, td)
+}
+
+func (c tapTraces) Capabilities() consumer.Capabilities {
+	return c.consumer.Capabilities()
+}
@@ -44,6 +44,10 @@ var (
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
+
+	//go:embed templates/flow_table.html
+	flowTableBytes    []byte
+	flowTableTemplate = parseTemplate("flow_table", flowTableBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
@@ -172,3 +176,33 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// FlowTableData contains data for the flow table template.
+type FlowTableData struct {
+	Rows []FlowTableRowData
+}
+
+// FlowTableRowData contains data for one node of the graph in the flow table template.
+type FlowTableRowData struct {
+	ID        string
+	Pipelines []string
+	// Consumed are the counts of the items consumed by the node, nil if it does not consume.
+	Consumed *FlowCountsData
+	// Produced are the counts of the items produced by the node, nil if it does not produce.
+	Produced *FlowCountsData
+}
+
+// FlowCountsData contains the counts of the items flowing in or out of a node.
+type FlowCountsData struct {
+	Items   int64
+	Refused int64
+	Failed  int64
+}
+
+// WriteHTMLFlowTable writes a table of the items flowing through the nodes of the graph, refreshed every
+// second with the JSON of the page, i.e. with the format=json query parameter.
+func WriteHTMLFlowTable(w io.Writer, ftd FlowTableData) {
+	if err := flowTableTemplate.Execute(w, ftd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
@@ -79,6 +79,23 @@ func TestNoCrash(t *testing.T) {
 			},
 		}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLFlowTable(buf, FlowTableData{Rows: []FlowTableRowData{
+			{ID: "receiver:traces:otlp", Pipelines: []string{"traces"}, Produced: &FlowCountsData{Items: 3}},
+			{ID: "exporter:traces:debug", Pipelines: []string{"traces"}, Consumed: &FlowCountsData{Items: 3, Failed: 1}},
+		}})
+	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
+
+func TestFlowTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	WriteHTMLFlowTable(buf, FlowTableData{Rows: []FlowTableRowData{
+		{ID: "processor:traces:batch", Pipelines: []string{"traces"}, Consumed: &FlowCountsData{Items: 5, Refused: 2}},
+	}})
+	assert.Contains(t, buf.String(), `<tr style="background: #eee" data-node="processor:traces:batch">`)
+	assert.Contains(t, buf.String(), `<td style="text-align: right" data-count="consumed.items">5</td>`)
+	assert.Contains(t, buf.String(), `<td style="text-align: right" data-count="consumed.refused">2</td>`)
+	assert.NotContains(t, buf.String(), `data-count="produced.items"`)
+}
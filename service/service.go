@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/configdiff"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/graph"
@@ -35,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/topology"
 )
 
 // ModuleInfo describes the Go module for a particular component.
@@ -60,6 +62,10 @@ type Settings struct {
 	// Deprecated [v0.155.0]: use ConfigSnapshot instead.
 	CollectorConf *confmap.Conf
 
+	// CompareConfig compares a candidate configuration, as YAML, to the Collector's running
+	// configuration. It is served by the configdiffz zPage, which is not registered if it is nil.
+	CompareConfig func(ctx context.Context, candidate []byte) (*configdiff.Diff, error)
+
 	// Receivers configuration to its builder.
 	ReceiversConfigs   map[component.ID]component.Config
 	ReceiversFactories map[component.Type]receiver.Factory
@@ -139,6 +145,8 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 			ModuleInfos:       set.ModuleInfos,
 			BuildInfo:         set.BuildInfo,
 			AsyncErrorChannel: set.AsyncErrorChannel,
+			CompareConfig:     set.CompareConfig,
+			InternalTelemetry: &telemetry.Sink{},
 		},
 		configSnapshot: configSnapshot,
 	}
@@ -149,7 +157,7 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 
 	// Create the resource first. This ensures all telemetry providers
 	// (logger, meter, tracer) use the same resource with a consistent service.instance.id.
-	telemetrySettings := telemetry.Settings{BuildInfo: set.BuildInfo}
+	telemetrySettings := telemetry.Settings{BuildInfo: set.BuildInfo, Sink: srv.host.InternalTelemetry}
 	resource, err := set.TelemetryFactory.CreateResource(ctx, telemetrySettings, cfg.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
@@ -292,6 +300,37 @@ func ReceiverPartialReloadEnabled() bool {
 		metadata.ServicePartialReloadReceiversFeatureGate.IsEnabled()
 }
 
+// PipelinePartialReloadEnabled reports whether the partial reload of
+// processors, exporters, connectors and pipelines is active. It requires both
+// the master partial reload gate (service.partialReload, Alpha) and the
+// pipeline phase gate (service.partialReloadPipelines, Alpha) to be enabled.
+func PipelinePartialReloadEnabled() bool {
+	return metadata.ServicePartialReloadFeatureGate.IsEnabled() &&
+		metadata.ServicePartialReloadPipelinesFeatureGate.IsEnabled()
+}
+
+// UpdatePipelines performs a partial reload of the pipelines to the
+// configuration of the settings. Only the components that are added, whose
+// configuration changed, or that are upstream of a rebuilt component in a
+// pipeline are restarted, and the removed components are stopped. All other
+// components remain running without interruption.
+//
+// The changed components are the IDs, by kind, of the receivers, processors,
+// exporters and connectors present before and after the reload whose
+// configuration changed. The telemetry and the extensions are not reloaded,
+// their changes require a new Service.
+func (srv *Service) UpdatePipelines(ctx context.Context, set Settings, cfg Config,
+	changed map[component.Kind]map[component.ID]bool,
+) error {
+	srv.telemetrySettings.Logger.Info("Performing partial pipelines reload")
+	srv.graphSettings.ReceiverBuilder = builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	srv.graphSettings.ProcessorBuilder = builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	srv.graphSettings.ExporterBuilder = builders.NewExporter(set.ExportersConfigs, set.ExportersFactories)
+	srv.graphSettings.ConnectorBuilder = builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+	srv.graphSettings.PipelineConfigs = cfg.Pipelines
+	return srv.host.Pipelines.UpdatePipelines(ctx, srv.graphSettings, changed, srv.host)
+}
+
 // UpdateReceivers performs a partial reload of receiver components.
 // Only receivers that have been added, removed, or whose configuration or
 // pipeline membership changed are restarted. All other components remain
@@ -389,13 +428,28 @@ func (srv *Service) Logger() *zap.Logger {
 
 // Validate verifies the graph by calling the internal graph.Build.
 func Validate(ctx context.Context, set Settings, cfg Config) error {
+	_, err := buildOffline(ctx, set, cfg)
+	return err
+}
+
+// Topology builds the pipelines of the configuration without starting them, and returns their topology.
+func Topology(ctx context.Context, set Settings, cfg Config) (*topology.Topology, error) {
+	g, err := buildOffline(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return g.Topology(), nil
+}
+
+// buildOffline builds the graph of the pipelines with no-op telemetry.
+func buildOffline(ctx context.Context, set Settings, cfg Config) (*graph.Graph, error) {
 	tel := component.TelemetrySettings{
 		Logger:         zap.NewNop(),
 		TracerProvider: nooptrace.NewTracerProvider(),
 		MeterProvider:  noopmetric.NewMeterProvider(),
 		Resource:       pcommon.NewResource(),
 	}
-	_, err := graph.Build(ctx, graph.Settings{
+	g, err := graph.Build(ctx, graph.Settings{
 		Telemetry:        tel,
 		BuildInfo:        set.BuildInfo,
 		ReceiverBuilder:  builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories),
@@ -405,9 +459,9 @@ func Validate(ctx context.Context, set Settings, cfg Config) error {
 		PipelineConfigs:  cfg.Pipelines,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to build pipelines: %w", err)
+		return nil, fmt.Errorf("failed to build pipelines: %w", err)
 	}
-	return nil
+	return g, nil
 }
 
 // registerProcessMetrics registers process metrics on supported operating systems.
@@ -800,6 +800,19 @@ func TestValidateGraph(t *testing.T) {
 	}
 }
 
+func TestTopology(t *testing.T) {
+	topo, err := Topology(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	// Each of the 4 pipelines has a receiver, a capabilities, a processor, a fanout and an exporter node.
+	assert.Len(t, topo.Nodes, 20)
+	assert.Len(t, topo.Edges, 16)
+
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)].Exporters = []component.ID{component.MustNewID("unknown")}
+	_, err = Topology(context.Background(), newNopSettings(), cfg)
+	require.ErrorContains(t, err, "failed to build pipelines")
+}
+
 func TestRegisterProcessMetrics_UnsupportedOS_Warns(t *testing.T) {
 	mockRegister := func(_ component.TelemetrySettings, _ ...proctelemetry.RegisterOption) error {
 		t.Fatalf("should not be called on unsupported OS")
@@ -897,3 +910,25 @@ func TestServiceUpdateReceivers(t *testing.T) {
 	rcvrCfgs, rcvrFactories := builders.NewNopReceiverConfigsAndFactories()
 	require.NoError(t, srv.UpdateReceivers(ctx, nil, rcvrCfgs, rcvrFactories, cfg.Pipelines))
 }
+
+func TestServiceUpdatePipelines(t *testing.T) {
+	ctx := context.Background()
+	cfg := newNopConfig()
+	set := newNopSettings()
+	srv, err := New(ctx, set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, srv.Shutdown(ctx))
+	})
+
+	// Remove the profiles pipeline and restart the nop exporter of the others.
+	newCfg := newNopConfig()
+	delete(newCfg.Pipelines, pipeline.NewID(xpipeline.SignalProfiles))
+	changed := map[component.Kind]map[component.ID]bool{
+		component.KindExporter: {component.NewID(nopType): true},
+	}
+	require.NoError(t, srv.UpdatePipelines(ctx, set, newCfg, changed))
+	assert.Empty(t, srv.host.Pipelines.GetExporters()[xpipeline.SignalProfiles])
+	assert.Len(t, srv.host.Pipelines.GetExporters()[pipeline.SignalTraces], 1)
+}
@@ -5,6 +5,7 @@ package otelconftelemetry // import "go.opentelemetry.io/collector/service/telem
 
 import (
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/migration"
@@ -24,6 +25,10 @@ type Config struct {
 	// Supports both the declarative config resource schema and the legacy inline
 	// attribute map format for backward compatibility.
 	Resource ResourceConfig `mapstructure:"resource,omitempty"`
+
+	// Receiver specifies the internal telemetry injected into the pipelines through the
+	// internaltelemetry receiver, in addition to the configured readers and processors.
+	Receiver ReceiverConfig `mapstructure:"receiver,omitempty"`
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -48,10 +53,27 @@ type TracesConfig = migration.TracesConfigV030
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type ResourceConfig = migration.ResourceConfigV030
 
+var errReceiverNotSupported = errors.New("service::telemetry::receiver is not supported by the host")
+
+// ReceiverConfig defines the internal telemetry injected into the pipelines through the internaltelemetry
+// receiver, so that it flows through their processors and exporters.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type ReceiverConfig struct {
+	// Logs injects the internal logs accepted by the level and the sampling of the logs.
+	Logs bool `mapstructure:"logs"`
+	// Metrics injects the internal metrics of the metrics level, collected every MetricsInterval.
+	Metrics bool `mapstructure:"metrics"`
+	// Traces injects the internal spans, unless the traces level is none.
+	Traces bool `mapstructure:"traces"`
+	// MetricsInterval is the interval between two collections of the internal metrics injected
+	// into the pipelines.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
+}
+
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 	// Check when service telemetry metric level is not none, the metrics readers should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && len(c.Metrics.Readers) == 0 {
+	if c.Metrics.Level != configtelemetry.LevelNone && len(c.Metrics.Readers) == 0 && !c.Receiver.Metrics {
 		return errors.New("collector telemetry metrics reader should exist when metric level is not none")
 	}
 
@@ -59,5 +81,9 @@ func (c *Config) Validate() error {
 		return errors.New("service::telemetry::metrics::views can only be set when service::telemetry::metrics::level is detailed")
 	}
 
+	if c.Receiver.Metrics && c.Receiver.MetricsInterval <= 0 {
+		return errors.New("service::telemetry::receiver::metrics_interval must be positive when service::telemetry::receiver::metrics is enabled")
+	}
+
 	return nil
 }
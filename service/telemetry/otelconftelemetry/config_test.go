@@ -89,6 +89,20 @@ func TestConfig(t *testing.T) {
 				return cfg
 			}(),
 		},
+		"config_receiver.yaml": {
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Metrics.Readers = []config.MetricReader{}
+				cfg.Receiver.Logs = true
+				cfg.Receiver.Metrics = true
+				cfg.Receiver.Traces = true
+				cfg.Receiver.MetricsInterval = 10 * time.Second
+				return cfg
+			}(),
+		},
+		"config_invalid_receiver_metrics_interval.yaml": {
+			validateErr: `service::telemetry::receiver::metrics_interval must be positive when service::telemetry::receiver::metrics is enabled`,
+		},
 		"config_invalid_unknown_field.yaml": {
 			unmarshalErr: `invalid keys: unknown`,
 		},
@@ -67,6 +67,9 @@ func createDefaultConfig() component.Config {
 				SchemaUrl: ptr(semconv.SchemaURL),
 			},
 		},
+		Receiver: ReceiverConfig{
+			MetricsInterval: time.Minute,
+		},
 	}
 }
 
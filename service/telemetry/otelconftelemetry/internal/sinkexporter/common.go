@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sinkexporter provides exporters of the OpenTelemetry SDK converting the internal telemetry
+// to pdata and passing it to a consumer, e.g. to inject it into the pipelines of the Collector.
+package sinkexporter // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// scopeKey identifies an instrumentation scope to group the telemetry by scope.
+type scopeKey struct {
+	name      string
+	version   string
+	schemaURL string
+	attrs     attribute.Distinct
+}
+
+func newScopeKey(scope instrumentation.Scope) scopeKey {
+	return scopeKey{
+		name:      scope.Name,
+		version:   scope.Version,
+		schemaURL: scope.SchemaURL,
+		attrs:     scope.Attributes.Equivalent(),
+	}
+}
+
+// putResource copies a resource to a pcommon.Resource and returns its schema URL.
+func putResource(res *resource.Resource, dest pcommon.Resource) string {
+	if res == nil {
+		return ""
+	}
+	putAttributes(res.Attributes(), dest.Attributes())
+	return res.SchemaURL()
+}
+
+// putScope copies an instrumentation scope to a pcommon.InstrumentationScope and returns its schema URL.
+func putScope(scope instrumentation.Scope, dest pcommon.InstrumentationScope) string {
+	dest.SetName(scope.Name)
+	dest.SetVersion(scope.Version)
+	putAttributes(scope.Attributes.ToSlice(), dest.Attributes())
+	return scope.SchemaURL
+}
+
+func putAttributes(attrs []attribute.KeyValue, dest pcommon.Map) {
+	dest.EnsureCapacity(len(attrs))
+	for _, kv := range attrs {
+		putValue(kv.Value, dest.PutEmpty(string(kv.Key)))
+	}
+}
+
+func putValue(v attribute.Value, dest pcommon.Value) {
+	switch v.Type() {
+	case attribute.BOOL:
+		dest.SetBool(v.AsBool())
+	case attribute.INT64:
+		dest.SetInt(v.AsInt64())
+	case attribute.FLOAT64:
+		dest.SetDouble(v.AsFloat64())
+	case attribute.STRING:
+		dest.SetStr(v.AsString())
+	case attribute.BOOLSLICE:
+		s := dest.SetEmptySlice()
+		for _, b := range v.AsBoolSlice() {
+			s.AppendEmpty().SetBool(b)
+		}
+	case attribute.INT64SLICE:
+		s := dest.SetEmptySlice()
+		for _, i := range v.AsInt64Slice() {
+			s.AppendEmpty().SetInt(i)
+		}
+	case attribute.FLOAT64SLICE:
+		s := dest.SetEmptySlice()
+		for _, f := range v.AsFloat64Slice() {
+			s.AppendEmpty().SetDouble(f)
+		}
+	case attribute.STRINGSLICE:
+		s := dest.SetEmptySlice()
+		for _, str := range v.AsStringSlice() {
+			s.AppendEmpty().SetStr(str)
+		}
+	case attribute.BYTESLICE:
+		dest.SetEmptyBytes().FromRaw(v.AsByteSlice())
+	case attribute.SLICE:
+		s := dest.SetEmptySlice()
+		for _, elem := range v.AsSlice() {
+			putValue(elem, s.AppendEmpty())
+		}
+	case attribute.MAP:
+		putAttributes(v.AsMap(), dest.SetEmptyMap())
+	}
+}
+
+func timestamp(t time.Time) pcommon.Timestamp {
+	if t.IsZero() {
+		return 0
+	}
+	return pcommon.NewTimestampFromTime(t)
+}
+
+// droppedCount converts a count of dropped attributes, events or links, never negative nor above the
+// limits of the SDK, to the type of pdata.
+func droppedCount(n int) uint32 {
+	return uint32(n) //nolint:gosec // the count is bounded by the limits of the SDK
+}
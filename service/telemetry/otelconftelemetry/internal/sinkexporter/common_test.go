@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var testResource = resource.NewWithAttributes("https://opentelemetry.io/schemas/1.26.0", attribute.String("service.name", "otelcol"))
+
+func TestPutValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    attribute.Value
+		expected any
+	}{
+		{name: "bool", value: attribute.BoolValue(true), expected: true},
+		{name: "int64", value: attribute.Int64Value(1), expected: int64(1)},
+		{name: "float64", value: attribute.Float64Value(1.5), expected: 1.5},
+		{name: "string", value: attribute.StringValue("a"), expected: "a"},
+		{name: "bool_slice", value: attribute.BoolSliceValue([]bool{true, false}), expected: []any{true, false}},
+		{name: "int64_slice", value: attribute.Int64SliceValue([]int64{1, 2}), expected: []any{int64(1), int64(2)}},
+		{name: "float64_slice", value: attribute.Float64SliceValue([]float64{1.5}), expected: []any{1.5}},
+		{name: "string_slice", value: attribute.StringSliceValue([]string{"a", "b"}), expected: []any{"a", "b"}},
+		{name: "bytes", value: attribute.ByteSliceValue([]byte("ab")), expected: []byte("ab")},
+		{
+			name:     "slice",
+			value:    attribute.SliceValue(attribute.StringValue("a"), attribute.Int64Value(1)),
+			expected: []any{"a", int64(1)},
+		},
+		{
+			name:     "map",
+			value:    attribute.MapValue(attribute.String("a", "b"), attribute.Bool("c", true)),
+			expected: map[string]any{"a": "b", "c": true},
+		},
+		{name: "empty", value: attribute.Value{}, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := pcommon.NewValueEmpty()
+			putValue(tt.value, v)
+			assert.Equal(t, tt.expected, v.AsRaw())
+		})
+	}
+}
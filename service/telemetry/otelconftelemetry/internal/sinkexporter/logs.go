@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logExporter struct {
+	consume consumer.ConsumeLogsFunc
+}
+
+// NewLogExporter returns a log exporter passing the log records to consume.
+func NewLogExporter(consume consumer.ConsumeLogsFunc) sdklog.Exporter {
+	return &logExporter{consume: consume}
+}
+
+func (e *logExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return e.consume(ctx, logsFromRecords(records))
+}
+
+func (*logExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (*logExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func logsFromRecords(records []sdklog.Record) plog.Logs {
+	ld := plog.NewLogs()
+	resourceLogs := make(map[*resource.Resource]plog.ResourceLogs)
+	scopeLogs := make(map[*resource.Resource]map[scopeKey]plog.ScopeLogs)
+	for i := range records {
+		record := &records[i]
+		res := record.Resource()
+		rl, ok := resourceLogs[res]
+		if !ok {
+			rl = ld.ResourceLogs().AppendEmpty()
+			rl.SetSchemaUrl(putResource(res, rl.Resource()))
+			resourceLogs[res] = rl
+			scopeLogs[res] = make(map[scopeKey]plog.ScopeLogs)
+		}
+		key := newScopeKey(record.InstrumentationScope())
+		sl, ok := scopeLogs[res][key]
+		if !ok {
+			sl = rl.ScopeLogs().AppendEmpty()
+			sl.SetSchemaUrl(putScope(record.InstrumentationScope(), sl.Scope()))
+			scopeLogs[res][key] = sl
+		}
+		putLogRecord(record, sl.LogRecords().AppendEmpty())
+	}
+	return ld
+}
+
+func putLogRecord(record *sdklog.Record, dest plog.LogRecord) {
+	dest.SetTimestamp(timestamp(record.Timestamp()))
+	dest.SetObservedTimestamp(timestamp(record.ObservedTimestamp()))
+	dest.SetEventName(record.EventName())
+	dest.SetSeverityNumber(plog.SeverityNumber(record.Severity()))
+	dest.SetSeverityText(record.SeverityText())
+	putValue(record.Body(), dest.Body())
+	dest.Attributes().EnsureCapacity(record.AttributesLen())
+	record.WalkAttributes(func(kv attribute.KeyValue) bool {
+		putValue(kv.Value, dest.Attributes().PutEmpty(string(kv.Key)))
+		return true
+	})
+	dest.SetDroppedAttributesCount(droppedCount(record.DroppedAttributes()))
+	if record.TraceID().IsValid() {
+		dest.SetTraceID(pcommon.TraceID(record.TraceID()))
+	}
+	if record.SpanID().IsValid() {
+		dest.SetSpanID(pcommon.SpanID(record.SpanID()))
+	}
+	dest.SetFlags(plog.LogRecordFlags(record.TraceFlags()))
+}
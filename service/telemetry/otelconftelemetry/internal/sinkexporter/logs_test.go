@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogExporter(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(NewLogExporter(sink.ConsumeLogs))),
+		sdklog.WithResource(testResource),
+	)
+	t.Cleanup(func() { require.NoError(t, lp.Shutdown(context.Background())) })
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	now := time.Now()
+	var record log.Record
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetEventName("event")
+	record.SetSeverity(log.SeverityWarn)
+	record.SetSeverityText("warn")
+	record.SetBody(attribute.MapValue(attribute.String("msg", "hello")))
+	record.AddAttributes(attribute.String("key", "value"), attribute.Int64("count", 2))
+	lp.Logger("go.opentelemetry.io/collector/service").Emit(ctx, record)
+
+	require.Len(t, sink.AllLogs(), 1)
+	ld := sink.AllLogs()[0]
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, testResource.SchemaURL(), rl.SchemaUrl())
+	assert.Equal(t, map[string]any{"service.name": "otelcol"}, rl.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rl.ScopeLogs().Len())
+	sl := rl.ScopeLogs().At(0)
+	assert.Equal(t, "go.opentelemetry.io/collector/service", sl.Scope().Name())
+	require.Equal(t, 1, sl.LogRecords().Len())
+
+	lr := sl.LogRecords().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), lr.Timestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), lr.ObservedTimestamp())
+	assert.Equal(t, "event", lr.EventName())
+	assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+	assert.Equal(t, "warn", lr.SeverityText())
+	assert.Equal(t, map[string]any{"msg": "hello"}, lr.Body().AsRaw())
+	assert.Equal(t, map[string]any{"key": "value", "count": int64(2)}, lr.Attributes().AsRaw())
+	assert.Equal(t, pcommon.TraceID(span.SpanContext().TraceID()), lr.TraceID())
+	assert.Equal(t, pcommon.SpanID(span.SpanContext().SpanID()), lr.SpanID())
+	assert.Equal(t, plog.DefaultLogRecordFlags.WithIsSampled(true), lr.Flags())
+}
+
+func TestLogsFromRecordsGroupsByScope(t *testing.T) {
+	exporter := &recordingLogExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)), sdklog.WithResource(testResource))
+	for _, name := range []string{"a", "b", "a"} {
+		var record log.Record
+		record.SetBody(attribute.StringValue(name))
+		lp.Logger(name).Emit(context.Background(), record)
+	}
+	require.NoError(t, lp.Shutdown(context.Background()))
+
+	ld := logsFromRecords(exporter.records)
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	scopes := ld.ResourceLogs().At(0).ScopeLogs()
+	require.Equal(t, 2, scopes.Len())
+	assert.Equal(t, "a", scopes.At(0).Scope().Name())
+	assert.Equal(t, 2, scopes.At(0).LogRecords().Len())
+	assert.Equal(t, "b", scopes.At(1).Scope().Name())
+	assert.Equal(t, 1, scopes.At(1).LogRecords().Len())
+}
+
+type recordingLogExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (*recordingLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (*recordingLogExporter) Shutdown(context.Context) error {
+	return nil
+}
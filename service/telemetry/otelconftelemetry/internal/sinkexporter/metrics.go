@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
+
+import (
+	"context"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type metricExporter struct {
+	consume consumer.ConsumeMetricsFunc
+}
+
+// NewMetricExporter returns a metric exporter passing the metrics to consume, with the default
+// temporality and aggregations of the SDK.
+func NewMetricExporter(consume consumer.ConsumeMetricsFunc) sdkmetric.Exporter {
+	return &metricExporter{consume: consume}
+}
+
+func (*metricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (*metricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *metricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	md := metricsFromResourceMetrics(rm)
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return e.consume(ctx, md)
+}
+
+func (*metricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (*metricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func metricsFromResourceMetrics(rm *metricdata.ResourceMetrics) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rms := md.ResourceMetrics().AppendEmpty()
+	rms.SetSchemaUrl(putResource(rm.Resource, rms.Resource()))
+	for _, sm := range rm.ScopeMetrics {
+		sms := rms.ScopeMetrics().AppendEmpty()
+		sms.SetSchemaUrl(putScope(sm.Scope, sms.Scope()))
+		for _, m := range sm.Metrics {
+			putMetric(m, sms.Metrics().AppendEmpty())
+		}
+	}
+	return md
+}
+
+func putMetric(m metricdata.Metrics, dest pmetric.Metric) {
+	dest.SetName(m.Name)
+	dest.SetDescription(m.Description)
+	dest.SetUnit(m.Unit)
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		putNumberDataPoints(data.DataPoints, dest.SetEmptyGauge().DataPoints())
+	case metricdata.Gauge[float64]:
+		putNumberDataPoints(data.DataPoints, dest.SetEmptyGauge().DataPoints())
+	case metricdata.Sum[int64]:
+		putSum(data, dest.SetEmptySum())
+	case metricdata.Sum[float64]:
+		putSum(data, dest.SetEmptySum())
+	case metricdata.Histogram[int64]:
+		putHistogram(data, dest.SetEmptyHistogram())
+	case metricdata.Histogram[float64]:
+		putHistogram(data, dest.SetEmptyHistogram())
+	case metricdata.ExponentialHistogram[int64]:
+		putExponentialHistogram(data, dest.SetEmptyExponentialHistogram())
+	case metricdata.ExponentialHistogram[float64]:
+		putExponentialHistogram(data, dest.SetEmptyExponentialHistogram())
+	case metricdata.Summary:
+		putSummary(data, dest.SetEmptySummary())
+	}
+}
+
+func putSum[N int64 | float64](sum metricdata.Sum[N], dest pmetric.Sum) {
+	dest.SetAggregationTemporality(temporality(sum.Temporality))
+	dest.SetIsMonotonic(sum.IsMonotonic)
+	putNumberDataPoints(sum.DataPoints, dest.DataPoints())
+}
+
+func putNumberDataPoints[N int64 | float64](dps []metricdata.DataPoint[N], dest pmetric.NumberDataPointSlice) {
+	dest.EnsureCapacity(len(dps))
+	for _, dp := range dps {
+		ndp := dest.AppendEmpty()
+		putAttributes(dp.Attributes.ToSlice(), ndp.Attributes())
+		ndp.SetStartTimestamp(timestamp(dp.StartTime))
+		ndp.SetTimestamp(timestamp(dp.Time))
+		switch v := any(dp.Value).(type) {
+		case int64:
+			ndp.SetIntValue(v)
+		case float64:
+			ndp.SetDoubleValue(v)
+		}
+		putExemplars(dp.Exemplars, ndp.Exemplars())
+	}
+}
+
+func putHistogram[N int64 | float64](histogram metricdata.Histogram[N], dest pmetric.Histogram) {
+	dest.SetAggregationTemporality(temporality(histogram.Temporality))
+	dest.DataPoints().EnsureCapacity(len(histogram.DataPoints))
+	for _, dp := range histogram.DataPoints {
+		hdp := dest.DataPoints().AppendEmpty()
+		putAttributes(dp.Attributes.ToSlice(), hdp.Attributes())
+		hdp.SetStartTimestamp(timestamp(dp.StartTime))
+		hdp.SetTimestamp(timestamp(dp.Time))
+		hdp.SetCount(dp.Count)
+		hdp.SetSum(float64(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			hdp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			hdp.SetMax(float64(v))
+		}
+		hdp.ExplicitBounds().FromRaw(dp.Bounds)
+		hdp.BucketCounts().FromRaw(dp.BucketCounts)
+		putExemplars(dp.Exemplars, hdp.Exemplars())
+	}
+}
+
+func putExponentialHistogram[N int64 | float64](histogram metricdata.ExponentialHistogram[N], dest pmetric.ExponentialHistogram) {
+	dest.SetAggregationTemporality(temporality(histogram.Temporality))
+	dest.DataPoints().EnsureCapacity(len(histogram.DataPoints))
+	for _, dp := range histogram.DataPoints {
+		edp := dest.DataPoints().AppendEmpty()
+		putAttributes(dp.Attributes.ToSlice(), edp.Attributes())
+		edp.SetStartTimestamp(timestamp(dp.StartTime))
+		edp.SetTimestamp(timestamp(dp.Time))
+		edp.SetCount(dp.Count)
+		edp.SetSum(float64(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			edp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			edp.SetMax(float64(v))
+		}
+		edp.SetScale(dp.Scale)
+		edp.SetZeroCount(dp.ZeroCount)
+		edp.SetZeroThreshold(dp.ZeroThreshold)
+		edp.Positive().SetOffset(dp.PositiveBucket.Offset)
+		edp.Positive().BucketCounts().FromRaw(dp.PositiveBucket.Counts)
+		edp.Negative().SetOffset(dp.NegativeBucket.Offset)
+		edp.Negative().BucketCounts().FromRaw(dp.NegativeBucket.Counts)
+		putExemplars(dp.Exemplars, edp.Exemplars())
+	}
+}
+
+func putSummary(summary metricdata.Summary, dest pmetric.Summary) {
+	dest.DataPoints().EnsureCapacity(len(summary.DataPoints))
+	for _, dp := range summary.DataPoints {
+		sdp := dest.DataPoints().AppendEmpty()
+		putAttributes(dp.Attributes.ToSlice(), sdp.Attributes())
+		sdp.SetStartTimestamp(timestamp(dp.StartTime))
+		sdp.SetTimestamp(timestamp(dp.Time))
+		sdp.SetCount(dp.Count)
+		sdp.SetSum(dp.Sum)
+		for _, qv := range dp.QuantileValues {
+			q := sdp.QuantileValues().AppendEmpty()
+			q.SetQuantile(qv.Quantile)
+			q.SetValue(qv.Value)
+		}
+	}
+}
+
+func putExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N], dest pmetric.ExemplarSlice) {
+	for _, exemplar := range exemplars {
+		e := dest.AppendEmpty()
+		putAttributes(exemplar.FilteredAttributes, e.FilteredAttributes())
+		e.SetTimestamp(timestamp(exemplar.Time))
+		switch v := any(exemplar.Value).(type) {
+		case int64:
+			e.SetIntValue(v)
+		case float64:
+			e.SetDoubleValue(v)
+		}
+		if len(exemplar.TraceID) == len(pcommon.TraceID{}) {
+			e.SetTraceID(pcommon.TraceID(exemplar.TraceID))
+		}
+		if len(exemplar.SpanID) == len(pcommon.SpanID{}) {
+			e.SetSpanID(pcommon.SpanID(exemplar.SpanID))
+		}
+	}
+}
+
+func temporality(t metricdata.Temporality) pmetric.AggregationTemporality {
+	switch t {
+	case metricdata.CumulativeTemporality:
+		return pmetric.AggregationTemporalityCumulative
+	case metricdata.DeltaTemporality:
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityUnspecified
+}
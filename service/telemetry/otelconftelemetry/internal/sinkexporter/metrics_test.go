@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetricExporter(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	reader := sdkmetric.NewPeriodicReader(NewMetricExporter(sink.ConsumeMetrics), sdkmetric.WithInterval(time.Hour))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(testResource))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	// Nothing is consumed while no metric is recorded.
+	require.NoError(t, reader.ForceFlush(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+
+	meter := mp.Meter("go.opentelemetry.io/collector/service")
+	counter, err := meter.Int64Counter("counter", metric.WithDescription("A counter"), metric.WithUnit("{item}"))
+	require.NoError(t, err)
+	counter.Add(context.Background(), 2, metric.WithAttributes(attribute.String("key", "value")))
+	upDownCounter, err := meter.Float64UpDownCounter("up_down_counter")
+	require.NoError(t, err)
+	upDownCounter.Add(context.Background(), -1.5)
+	gauge, err := meter.Int64Gauge("gauge")
+	require.NoError(t, err)
+	gauge.Record(context.Background(), 3)
+	histogram, err := meter.Float64Histogram("histogram", metric.WithExplicitBucketBoundaries(1, 10))
+	require.NoError(t, err)
+	histogram.Record(context.Background(), 0.5)
+	histogram.Record(context.Background(), 5)
+
+	require.NoError(t, reader.ForceFlush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, testResource.SchemaURL(), rm.SchemaUrl())
+	assert.Equal(t, map[string]any{"service.name": "otelcol"}, rm.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, "go.opentelemetry.io/collector/service", sm.Scope().Name())
+
+	metrics := make(map[string]pmetric.Metric)
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		metrics[sm.Metrics().At(i).Name()] = sm.Metrics().At(i)
+	}
+	require.Len(t, metrics, 4)
+
+	sum := metrics["counter"]
+	assert.Equal(t, "A counter", sum.Description())
+	assert.Equal(t, "{item}", sum.Unit())
+	require.Equal(t, pmetric.MetricTypeSum, sum.Type())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	require.Equal(t, 1, sum.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), sum.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{"key": "value"}, sum.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.NotZero(t, sum.Sum().DataPoints().At(0).StartTimestamp())
+	assert.NotZero(t, sum.Sum().DataPoints().At(0).Timestamp())
+
+	upDown := metrics["up_down_counter"]
+	require.Equal(t, pmetric.MetricTypeSum, upDown.Type())
+	assert.False(t, upDown.Sum().IsMonotonic())
+	assert.InDelta(t, -1.5, upDown.Sum().DataPoints().At(0).DoubleValue(), 0)
+
+	require.Equal(t, pmetric.MetricTypeGauge, metrics["gauge"].Type())
+	assert.Equal(t, int64(3), metrics["gauge"].Gauge().DataPoints().At(0).IntValue())
+
+	hist := metrics["histogram"]
+	require.Equal(t, pmetric.MetricTypeHistogram, hist.Type())
+	hdp := hist.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(2), hdp.Count())
+	assert.InDelta(t, 5.5, hdp.Sum(), 0)
+	assert.InDelta(t, 0.5, hdp.Min(), 0)
+	assert.InDelta(t, 5, hdp.Max(), 0)
+	assert.Equal(t, []float64{1, 10}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 1, 0}, hdp.BucketCounts().AsRaw())
+}
+
+func TestMetricsFromResourceMetrics(t *testing.T) {
+	now := time.Now()
+	rm := &metricdata.ResourceMetrics{
+		Resource: testResource,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "exponential_histogram",
+					Data: metricdata.ExponentialHistogram[int64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{
+							StartTime:      now,
+							Time:           now,
+							Count:          3,
+							Sum:            6,
+							Min:            metricdata.NewExtrema[int64](1),
+							Scale:          2,
+							ZeroCount:      1,
+							ZeroThreshold:  0.5,
+							PositiveBucket: metricdata.ExponentialBucket{Offset: 1, Counts: []uint64{1, 1}},
+							Exemplars: []metricdata.Exemplar[int64]{{
+								Time:    now,
+								Value:   2,
+								TraceID: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+								SpanID:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+							}},
+						}},
+					},
+				},
+				{
+					Name: "summary",
+					Data: metricdata.Summary{
+						DataPoints: []metricdata.SummaryDataPoint{{
+							Count:          2,
+							Sum:            3,
+							QuantileValues: []metricdata.QuantileValue{{Quantile: 0.5, Value: 1.5}},
+						}},
+					},
+				},
+			},
+		}},
+	}
+
+	md := metricsFromResourceMetrics(rm)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	eh := metrics.At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, eh.AggregationTemporality())
+	edp := eh.DataPoints().At(0)
+	assert.Equal(t, uint64(3), edp.Count())
+	assert.InDelta(t, 6, edp.Sum(), 0)
+	assert.True(t, edp.HasMin())
+	assert.False(t, edp.HasMax())
+	assert.Equal(t, int32(2), edp.Scale())
+	assert.Equal(t, uint64(1), edp.ZeroCount())
+	assert.InDelta(t, 0.5, edp.ZeroThreshold(), 0)
+	assert.Equal(t, int32(1), edp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1}, edp.Positive().BucketCounts().AsRaw())
+	require.Equal(t, 1, edp.Exemplars().Len())
+	assert.Equal(t, int64(2), edp.Exemplars().At(0).IntValue())
+	assert.False(t, edp.Exemplars().At(0).TraceID().IsEmpty())
+	assert.False(t, edp.Exemplars().At(0).SpanID().IsEmpty())
+
+	sdp := metrics.At(1).Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(2), sdp.Count())
+	assert.InDelta(t, 3, sdp.Sum(), 0)
+	assert.Equal(t, 1, sdp.QuantileValues().Len())
+	assert.InDelta(t, 0.5, sdp.QuantileValues().At(0).Quantile(), 0)
+	assert.InDelta(t, 1.5, sdp.QuantileValues().At(0).Value(), 0)
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type spanExporter struct {
+	consume consumer.ConsumeTracesFunc
+}
+
+// NewSpanExporter returns a span exporter passing the spans to consume.
+func NewSpanExporter(consume consumer.ConsumeTracesFunc) sdktrace.SpanExporter {
+	return &spanExporter{consume: consume}
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	return e.consume(ctx, tracesFromSpans(spans))
+}
+
+func (*spanExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func tracesFromSpans(spans []sdktrace.ReadOnlySpan) ptrace.Traces {
+	td := ptrace.NewTraces()
+	resourceSpans := make(map[*resource.Resource]ptrace.ResourceSpans)
+	scopeSpans := make(map[*resource.Resource]map[scopeKey]ptrace.ScopeSpans)
+	for _, span := range spans {
+		res := span.Resource()
+		rs, ok := resourceSpans[res]
+		if !ok {
+			rs = td.ResourceSpans().AppendEmpty()
+			rs.SetSchemaUrl(putResource(res, rs.Resource()))
+			resourceSpans[res] = rs
+			scopeSpans[res] = make(map[scopeKey]ptrace.ScopeSpans)
+		}
+		key := newScopeKey(span.InstrumentationScope())
+		ss, ok := scopeSpans[res][key]
+		if !ok {
+			ss = rs.ScopeSpans().AppendEmpty()
+			ss.SetSchemaUrl(putScope(span.InstrumentationScope(), ss.Scope()))
+			scopeSpans[res][key] = ss
+		}
+		putSpan(span, ss.Spans().AppendEmpty())
+	}
+	return td
+}
+
+func putSpan(span sdktrace.ReadOnlySpan, dest ptrace.Span) {
+	sc := span.SpanContext()
+	dest.SetTraceID(pcommon.TraceID(sc.TraceID()))
+	dest.SetSpanID(pcommon.SpanID(sc.SpanID()))
+	dest.TraceState().FromRaw(sc.TraceState().String())
+	dest.SetFlags(uint32(sc.TraceFlags()))
+	if parent := span.Parent(); parent.SpanID().IsValid() {
+		dest.SetParentSpanID(pcommon.SpanID(parent.SpanID()))
+	}
+	dest.SetName(span.Name())
+	dest.SetKind(spanKind(span.SpanKind()))
+	dest.SetStartTimestamp(timestamp(span.StartTime()))
+	dest.SetEndTimestamp(timestamp(span.EndTime()))
+	putAttributes(span.Attributes(), dest.Attributes())
+	dest.SetDroppedAttributesCount(droppedCount(span.DroppedAttributes()))
+
+	for _, event := range span.Events() {
+		e := dest.Events().AppendEmpty()
+		e.SetName(event.Name)
+		e.SetTimestamp(timestamp(event.Time))
+		putAttributes(event.Attributes, e.Attributes())
+		e.SetDroppedAttributesCount(droppedCount(event.DroppedAttributeCount))
+	}
+	dest.SetDroppedEventsCount(droppedCount(span.DroppedEvents()))
+
+	for _, link := range span.Links() {
+		l := dest.Links().AppendEmpty()
+		l.SetTraceID(pcommon.TraceID(link.SpanContext.TraceID()))
+		l.SetSpanID(pcommon.SpanID(link.SpanContext.SpanID()))
+		l.TraceState().FromRaw(link.SpanContext.TraceState().String())
+		l.SetFlags(uint32(link.SpanContext.TraceFlags()))
+		putAttributes(link.Attributes, l.Attributes())
+		l.SetDroppedAttributesCount(droppedCount(link.DroppedAttributeCount))
+	}
+	dest.SetDroppedLinksCount(droppedCount(span.DroppedLinks()))
+
+	status := span.Status()
+	switch status.Code {
+	case codes.Ok:
+		dest.Status().SetCode(ptrace.StatusCodeOk)
+	case codes.Error:
+		dest.Status().SetCode(ptrace.StatusCodeError)
+		dest.Status().SetMessage(status.Description)
+	}
+}
+
+func spanKind(kind trace.SpanKind) ptrace.SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return ptrace.SpanKindInternal
+	case trace.SpanKindServer:
+		return ptrace.SpanKindServer
+	case trace.SpanKindClient:
+		return ptrace.SpanKindClient
+	case trace.SpanKindProducer:
+		return ptrace.SpanKindProducer
+	case trace.SpanKindConsumer:
+		return ptrace.SpanKindConsumer
+	}
+	return ptrace.SpanKindUnspecified
+}
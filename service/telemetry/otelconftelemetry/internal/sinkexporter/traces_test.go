@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestSpanExporter(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(NewSpanExporter(sink.ConsumeTraces)),
+		sdktrace.WithResource(testResource),
+	)
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+
+	tracer := tp.Tracer("go.opentelemetry.io/collector/service", trace.WithInstrumentationVersion("v1.0.0"))
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("key", "value")),
+		trace.WithLinks(trace.Link{SpanContext: parent.SpanContext(), Attributes: []attribute.KeyValue{attribute.Int("link", 1)}}),
+	)
+	child.AddEvent("event", trace.WithAttributes(attribute.Bool("event", true)))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.SetStatus(codes.Ok, "")
+	parent.End()
+
+	require.Len(t, sink.AllTraces(), 2)
+	td := sink.AllTraces()[0]
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, testResource.SchemaURL(), rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"service.name": "otelcol"}, rs.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rs.ScopeSpans().Len())
+	ss := rs.ScopeSpans().At(0)
+	assert.Equal(t, "go.opentelemetry.io/collector/service", ss.Scope().Name())
+	assert.Equal(t, "v1.0.0", ss.Scope().Version())
+	require.Equal(t, 1, ss.Spans().Len())
+
+	span := ss.Spans().At(0)
+	assert.Equal(t, "child", span.Name())
+	assert.Equal(t, pcommon.TraceID(child.SpanContext().TraceID()), span.TraceID())
+	assert.Equal(t, pcommon.SpanID(child.SpanContext().SpanID()), span.SpanID())
+	assert.Equal(t, pcommon.SpanID(parent.SpanContext().SpanID()), span.ParentSpanID())
+	assert.Equal(t, uint32(trace.FlagsSampled), span.Flags())
+	assert.Equal(t, ptrace.SpanKindClient, span.Kind())
+	assert.NotZero(t, span.StartTimestamp())
+	assert.GreaterOrEqual(t, span.EndTimestamp(), span.StartTimestamp())
+	assert.Equal(t, map[string]any{"key": "value"}, span.Attributes().AsRaw())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "event", span.Events().At(0).Name())
+	assert.Equal(t, map[string]any{"event": true}, span.Events().At(0).Attributes().AsRaw())
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, pcommon.SpanID(parent.SpanContext().SpanID()), span.Links().At(0).SpanID())
+	assert.Equal(t, map[string]any{"link": int64(1)}, span.Links().At(0).Attributes().AsRaw())
+	assert.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "failed", span.Status().Message())
+
+	root := sink.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "parent", root.Name())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, ptrace.SpanKindServer, root.Kind())
+	assert.Equal(t, ptrace.StatusCodeOk, root.Status().Code())
+}
+
+func TestTracesFromSpansGroupsByScope(t *testing.T) {
+	exporter := &recordingSpanExporter{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(testResource))
+	for _, name := range []string{"a", "b", "a"} {
+		_, span := tp.Tracer(name).Start(context.Background(), name)
+		span.End()
+	}
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	td := tracesFromSpans(exporter.spans)
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	scopes := td.ResourceSpans().At(0).ScopeSpans()
+	require.Equal(t, 2, scopes.Len())
+	assert.Equal(t, "a", scopes.At(0).Scope().Name())
+	assert.Equal(t, 2, scopes.At(0).Spans().Len())
+	assert.Equal(t, "b", scopes.At(1).Scope().Name())
+	assert.Equal(t, 1, scopes.At(1).Spans().Len())
+}
+
+type recordingSpanExporter struct {
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *recordingSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (*recordingSpanExporter) Shutdown(context.Context) error {
+	return nil
+}
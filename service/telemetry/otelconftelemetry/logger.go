@@ -8,11 +8,13 @@ import (
 	"sort"
 
 	otelconf "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
 )
 
 // createLogger creates a Logger and a LoggerProvider from Config.
@@ -94,12 +96,21 @@ func createLogger(
 		}))
 	}
 
+	var lpOptions []sdklog.LoggerProviderOption
+	if cfg.Receiver.Logs {
+		if set.Sink == nil {
+			return nil, nil, errReceiverNotSupported
+		}
+		exporter := sinkexporter.NewLogExporter(set.Sink.ConsumeLogs)
+		lpOptions = append(lpOptions, sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+	}
+
 	sdk, err := otelconf.NewSDK(otelconf.WithContext(ctx), otelconf.WithOpenTelemetryConfiguration(otelconf.OpenTelemetryConfiguration{
 		Resource: resourceConfig,
 		LoggerProvider: &otelconf.LoggerProvider{
 			Processors: cfg.Logs.Processors,
 		},
-	}))
+	}), otelconf.WithLoggerProviderOptions(lpOptions...))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	internalTelemetry "go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/service/internal/componentattribute"
@@ -194,6 +195,45 @@ func TestCreateLogger_BuildZapLoggerError(t *testing.T) {
 	assert.Nil(t, shutdown)
 }
 
+func TestCreateLogger_Receiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Receiver.Logs = true
+	resource, err := createResource(t.Context(), telemetry.Settings{}, cfg)
+	require.NoError(t, err)
+
+	_, _, err = createLogger(t.Context(), telemetry.LoggerSettings{
+		Settings: telemetry.Settings{Resource: &resource},
+	}, cfg)
+	require.ErrorIs(t, err, errReceiverNotSupported)
+
+	sink := &telemetry.Sink{}
+	logs := new(consumertest.LogsSink)
+	unregister, err := sink.RegisterLogs(logs)
+	require.NoError(t, err)
+	defer unregister()
+
+	logger, shutdown, err := createLogger(t.Context(), telemetry.LoggerSettings{
+		Settings: telemetry.Settings{Resource: &resource, Sink: sink},
+		BuildZapLogger: func(_ zap.Config, opts ...zap.Option) (*zap.Logger, error) {
+			core, _ := observer.New(zapcore.InfoLevel)
+			return zap.New(core, opts...), nil
+		},
+	}, cfg)
+	require.NoError(t, err)
+	logger.Info("injected", zap.String("key", "value"))
+	logger.Debug("below the level")
+	require.NoError(t, shutdown.Shutdown(t.Context()))
+
+	require.Equal(t, 1, logs.LogRecordCount())
+	rl := logs.AllLogs()[0].ResourceLogs().At(0)
+	serviceName, ok := rl.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, resource.Attributes().AsRaw()["service.name"], serviceName.AsRaw())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "injected", lr.Body().Str())
+	assert.Equal(t, map[string]any{"key": "value"}, lr.Attributes().AsRaw())
+}
+
 func TestCreateLoggerWithResource(t *testing.T) {
 	tests := []struct {
 		name           string
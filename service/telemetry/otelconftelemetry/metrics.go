@@ -8,11 +8,13 @@ import (
 
 	otelconf "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
 )
 
 func createMeterProvider(
@@ -37,11 +39,22 @@ func createMeterProvider(
 		return nil, err
 	}
 
+	var mpOptions []sdkmetric.Option
+	if cfg.Receiver.Metrics {
+		if set.Sink == nil {
+			return nil, errReceiverNotSupported
+		}
+		exporter := sinkexporter.NewMetricExporter(set.Sink.ConsumeMetrics)
+		mpOptions = append(mpOptions, sdkmetric.WithReader(
+			sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(cfg.Receiver.MetricsInterval)),
+		))
+	}
+
 	mpConfig := cfg.Metrics.MeterProvider
 	sdk, err := otelconf.NewSDK(otelconf.WithContext(ctx), otelconf.WithOpenTelemetryConfiguration(otelconf.OpenTelemetryConfiguration{
 		Resource:      resourceConfig,
 		MeterProvider: &mpConfig,
-	}))
+	}), otelconf.WithMeterProviderOptions(mpOptions...))
 	if err != nil {
 		return nil, err
 	}
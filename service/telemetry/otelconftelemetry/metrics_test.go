@@ -25,6 +25,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/service/internal/promtest"
@@ -313,6 +314,42 @@ func TestCreateMeterProvider_Disabled(t *testing.T) {
 	assert.Equal(t, "Internal metrics telemetry disabled", observedLogs.All()[0].Message)
 }
 
+func TestCreateMeterProvider_Receiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.Readers = nil
+	cfg.Receiver.Metrics = true
+	resource, err := createResource(t.Context(), telemetry.Settings{}, cfg)
+	require.NoError(t, err)
+
+	_, err = createMeterProvider(t.Context(), telemetry.MeterSettings{
+		Settings: telemetry.Settings{Resource: &resource},
+		Logger:   zap.NewNop(),
+	}, cfg)
+	require.ErrorIs(t, err, errReceiverNotSupported)
+
+	sink := &telemetry.Sink{}
+	metrics := new(consumertest.MetricsSink)
+	unregister, err := sink.RegisterMetrics(metrics)
+	require.NoError(t, err)
+	defer unregister()
+
+	mp, err := createMeterProvider(t.Context(), telemetry.MeterSettings{
+		Settings: telemetry.Settings{Resource: &resource, Sink: sink},
+		Logger:   zap.NewNop(),
+	}, cfg)
+	require.NoError(t, err)
+	counter, err := mp.Meter("test").Int64Counter(counterName)
+	require.NoError(t, err)
+	counter.Add(t.Context(), 2)
+	// The metrics are collected on shutdown.
+	require.NoError(t, mp.Shutdown(t.Context()))
+
+	require.Len(t, metrics.AllMetrics(), 1)
+	m := metrics.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, counterName, m.Name())
+	assert.Equal(t, int64(2), m.Sum().DataPoints().At(0).IntValue())
+}
+
 // Test that the MeterProvider implements the 'Enabled' functionality.
 // See https://pkg.go.dev/go.opentelemetry.io/otel/sdk/metric/internal/x#readme-instrument-enabled.
 func TestInstrumentEnabled(t *testing.T) {
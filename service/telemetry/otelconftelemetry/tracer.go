@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/embedded"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -20,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry/internal/sinkexporter"
 )
 
 const (
@@ -43,11 +45,19 @@ func createTracerProvider(
 		return nil, err
 	}
 
-	if cfg.Traces.Level == configtelemetry.LevelNone || len(cfg.Traces.Processors) == 0 {
+	if cfg.Traces.Level == configtelemetry.LevelNone || (len(cfg.Traces.Processors) == 0 && !cfg.Receiver.Traces) {
 		set.Logger.Info("Internal trace telemetry disabled")
 		return &noopNoContextTracerProvider{}, nil
 	}
 
+	var tpOptions []sdktrace.TracerProviderOption
+	if cfg.Receiver.Traces {
+		if set.Sink == nil {
+			return nil, errReceiverNotSupported
+		}
+		tpOptions = append(tpOptions, sdktrace.WithBatcher(sinkexporter.NewSpanExporter(set.Sink.ConsumeTraces)))
+	}
+
 	propagator, err := textMapPropagatorFromConfig(cfg.Traces.Propagators)
 	if err != nil {
 		return nil, fmt.Errorf("error creating propagator: %w", err)
@@ -57,7 +67,7 @@ func createTracerProvider(
 	sdk, err := otelconf.NewSDK(otelconf.WithContext(ctx), otelconf.WithOpenTelemetryConfiguration(otelconf.OpenTelemetryConfiguration{
 		Resource:       resourceConfig,
 		TracerProvider: &cfg.Traces.TracerProvider,
-	}))
+	}), otelconf.WithTracerProviderOptions(tpOptions...))
 	if err != nil {
 		return nil, err
 	}
@@ -21,6 +21,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -254,6 +255,37 @@ func TestCreateTracerProvider_NoProcessors(t *testing.T) {
 	assert.Equal(t, 0, received)
 }
 
+func TestCreateTracerProvider_Receiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Receiver.Traces = true
+	resource, err := createResource(t.Context(), telemetry.Settings{}, cfg)
+	require.NoError(t, err)
+
+	_, err = createTracerProvider(t.Context(), telemetry.TracerSettings{
+		Settings: telemetry.Settings{Resource: &resource},
+		Logger:   zap.NewNop(),
+	}, cfg)
+	require.ErrorIs(t, err, errReceiverNotSupported)
+
+	sink := &telemetry.Sink{}
+	traces := new(consumertest.TracesSink)
+	unregister, err := sink.RegisterTraces(traces)
+	require.NoError(t, err)
+	defer unregister()
+
+	provider, err := createTracerProvider(t.Context(), telemetry.TracerSettings{
+		Settings: telemetry.Settings{Resource: &resource, Sink: sink},
+		Logger:   zap.NewNop(),
+	}, cfg)
+	require.NoError(t, err)
+	_, span := provider.Tracer("test_tracer").Start(context.Background(), "test_span")
+	span.End()
+	require.NoError(t, provider.Shutdown(t.Context()))
+
+	require.Equal(t, 1, traces.SpanCount())
+	assert.Equal(t, "test_span", traces.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
 func TestCreateTracerProvider_Disabled(t *testing.T) {
 	var received int
 	mux := http.NewServeMux()
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Sink passes the internal telemetry injected into the pipelines to the receiver consuming it,
+// see hostcapabilities.InternalTelemetry. The telemetry is dropped while no receiver consumes it.
+// The zero value is ready to use.
+//
+// NOTE This API is experimental and will change soon - use at your own risk.
+type Sink struct {
+	traces  sinkConsumer[consumer.Traces]
+	metrics sinkConsumer[consumer.Metrics]
+	logs    sinkConsumer[consumer.Logs]
+}
+
+// ConsumeTraces passes the internal traces to the receiver consuming them, if any.
+func (s *Sink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if c, ok := s.traces.load(); ok {
+		return c.ConsumeTraces(ctx, td)
+	}
+	return nil
+}
+
+// ConsumeMetrics passes the internal metrics to the receiver consuming them, if any.
+func (s *Sink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if c, ok := s.metrics.load(); ok {
+		return c.ConsumeMetrics(ctx, md)
+	}
+	return nil
+}
+
+// ConsumeLogs passes the internal logs to the receiver consuming them, if any.
+func (s *Sink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if c, ok := s.logs.load(); ok {
+		return c.ConsumeLogs(ctx, ld)
+	}
+	return nil
+}
+
+// RegisterTraces registers the consumer of the internal traces until the returned function is called.
+// It fails if another consumer is registered.
+func (s *Sink) RegisterTraces(c consumer.Traces) (func(), error) {
+	return s.traces.register(c, pipeline.SignalTraces)
+}
+
+// RegisterMetrics registers the consumer of the internal metrics until the returned function is called.
+// It fails if another consumer is registered.
+func (s *Sink) RegisterMetrics(c consumer.Metrics) (func(), error) {
+	return s.metrics.register(c, pipeline.SignalMetrics)
+}
+
+// RegisterLogs registers the consumer of the internal logs until the returned function is called.
+// It fails if another consumer is registered.
+func (s *Sink) RegisterLogs(c consumer.Logs) (func(), error) {
+	return s.logs.register(c, pipeline.SignalLogs)
+}
+
+// sinkConsumer holds the consumer of a signal registered to a Sink.
+type sinkConsumer[T any] struct {
+	consumer atomic.Pointer[T]
+}
+
+func (sc *sinkConsumer[T]) register(c T, signal pipeline.Signal) (func(), error) {
+	p := &c
+	if !sc.consumer.CompareAndSwap(nil, p) {
+		return nil, fmt.Errorf("the internal %s are already consumed by another receiver", signal)
+	}
+	return func() { sc.consumer.CompareAndSwap(p, nil) }, nil
+}
+
+func (sc *sinkConsumer[T]) load() (T, bool) {
+	if p := sc.consumer.Load(); p != nil {
+		return *p, true
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestSink(t *testing.T) {
+	sink := &Sink{}
+	traces := new(consumertest.TracesSink)
+	metrics := new(consumertest.MetricsSink)
+	logs := new(consumertest.LogsSink)
+
+	// The telemetry is dropped while no receiver consumes it.
+	require.NoError(t, sink.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, sink.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.NoError(t, sink.ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	unregisterTraces, err := sink.RegisterTraces(traces)
+	require.NoError(t, err)
+	unregisterMetrics, err := sink.RegisterMetrics(metrics)
+	require.NoError(t, err)
+	unregisterLogs, err := sink.RegisterLogs(logs)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, sink.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.NoError(t, sink.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, traces.AllTraces(), 1)
+	assert.Len(t, metrics.AllMetrics(), 1)
+	assert.Len(t, logs.AllLogs(), 1)
+
+	_, err = sink.RegisterTraces(new(consumertest.TracesSink))
+	require.EqualError(t, err, "the internal traces are already consumed by another receiver")
+	_, err = sink.RegisterMetrics(new(consumertest.MetricsSink))
+	require.EqualError(t, err, "the internal metrics are already consumed by another receiver")
+	_, err = sink.RegisterLogs(new(consumertest.LogsSink))
+	require.EqualError(t, err, "the internal logs are already consumed by another receiver")
+
+	unregisterTraces()
+	unregisterMetrics()
+	unregisterLogs()
+	require.NoError(t, sink.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, traces.AllTraces(), 1)
+
+	// Unregistering a consumer twice does not unregister the next one.
+	unregisterNext, err := sink.RegisterTraces(traces)
+	require.NoError(t, err)
+	defer unregisterNext()
+	unregisterTraces()
+	_, err = sink.RegisterTraces(new(consumertest.TracesSink))
+	require.Error(t, err)
+}
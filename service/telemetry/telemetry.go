@@ -74,6 +74,10 @@ type Settings struct {
 
 	// Resource is the telemetry resource that should be used by all telemetry providers.
 	Resource *pcommon.Resource
+
+	// Sink receives the internal telemetry the telemetry providers inject into the pipelines,
+	// if they support it. It is nil if the host does not support it.
+	Sink *Sink
 }
 
 // Factory is a factory interface for internal telemetry.
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package topology describes the graph of the pipelines built by the service: the nodes of the components,
+// and of the capabilities and fanout nodes of the pipelines, and the edges the data flows through.
+package topology // import "go.opentelemetry.io/collector/service/topology"
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// KindCapabilities is the kind of the node presenting the aggregated capabilities of a pipeline to its
+	// receivers, every pipeline has one after its receivers.
+	KindCapabilities = "capabilities"
+	// KindFanout is the kind of the node fanning out the data of a pipeline to its exporters, every pipeline
+	// has one before its exporters.
+	KindFanout = "fanout"
+
+	// FormatJSON is the format of the topology as a JSON document of its nodes and edges.
+	FormatJSON = "json"
+	// FormatDOT is the format of the topology as a Graphviz DOT graph.
+	FormatDOT = "dot"
+)
+
+// Topology is the graph of the pipelines. The nodes and edges are sorted by ID.
+type Topology struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node is a node of the graph of the pipelines. A receiver or an exporter has a node by signal, shared by
+// the pipelines of the signal using it, a connector has a node by pair of signals it connects, and a
+// processor has a node by pipeline.
+type Node struct {
+	// ID uniquely identifies the node, e.g. "processor:traces/2:batch".
+	ID string `json:"id"`
+	// Kind is the lowercase kind of the component, e.g. "receiver", or KindCapabilities or KindFanout.
+	Kind string `json:"kind"`
+	// ComponentID is the ID of the component of the node, empty for the capabilities and fanout nodes.
+	ComponentID string `json:"component_id,omitempty"`
+	// Signal is the signal the node consumes, or produces for a receiver.
+	Signal string `json:"signal"`
+	// OutputSignal is the signal a connector produces.
+	OutputSignal string `json:"output_signal,omitempty"`
+	// Pipelines are the sorted IDs of the pipelines the node is part of.
+	Pipelines []string `json:"pipelines"`
+	// MutatesData is whether the node mutates the data it consumes, for the capabilities node whether a
+	// consumer of the pipeline mutates the data. It is always false for the receivers.
+	MutatesData bool `json:"mutates_data"`
+}
+
+// Edge is an edge of the graph of the pipelines, the data flows from the node From to the node To.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Write writes the topology in the given format, FormatJSON or FormatDOT.
+func (t *Topology) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(t)
+	case FormatDOT:
+		return t.WriteDOT(w)
+	}
+	return fmt.Errorf("unknown topology format %q, must be %q or %q", format, FormatJSON, FormatDOT)
+}
+
+// ContentType returns the media type of the given format, FormatJSON or FormatDOT.
+func ContentType(format string) string {
+	if format == FormatDOT {
+		return "text/vnd.graphviz"
+	}
+	return "application/json"
+}
+
+// WriteDOT writes the topology as a Graphviz DOT graph, with a cluster by pipeline. The nodes shared by
+// several pipelines, i.e. the receivers, the exporters and the connectors, are drawn outside of the
+// clusters, and the nodes mutating the data are drawn bold.
+func (t *Topology) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph pipelines {\n")
+	bw.WriteString("  rankdir=LR;\n")
+	bw.WriteString("  node [shape=box];\n")
+
+	// The processors, capabilities and fanout nodes are part of a single pipeline.
+	clusters := map[string][]Node{}
+	for _, n := range t.Nodes {
+		switch n.Kind {
+		case "processor", KindCapabilities, KindFanout:
+			clusters[n.Pipelines[0]] = append(clusters[n.Pipelines[0]], n)
+		default:
+			writeDOTNode(bw, "  ", n)
+		}
+	}
+	for i, id := range slices.Sorted(maps.Keys(clusters)) {
+		fmt.Fprintf(bw, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(bw, "    label=%s;\n", dotQuote(id))
+		for _, n := range clusters[id] {
+			writeDOTNode(bw, "    ", n)
+		}
+		bw.WriteString("  }\n")
+	}
+	for _, e := range t.Edges {
+		fmt.Fprintf(bw, "  %s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func writeDOTNode(w io.Writer, indent string, n Node) {
+	var attrs []string
+	switch n.Kind {
+	case KindCapabilities, KindFanout:
+		attrs = append(attrs, "shape=point", "tooltip="+dotQuote(n.Kind))
+	case "connector":
+		attrs = append(attrs, "label="+dotQuote(n.Kind+"\n"+n.ComponentID+"\n"+n.Signal+" -> "+n.OutputSignal), "shape=diamond")
+	default:
+		attrs = append(attrs, "label="+dotQuote(n.Kind+"\n"+n.ComponentID+"\n"+n.Signal))
+	}
+	if n.MutatesData {
+		attrs = append(attrs, "style=bold")
+	}
+	fmt.Fprintf(w, "%s%s [%s];\n", indent, dotQuote(n.ID), strings.Join(attrs, ", "))
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotQuote returns the DOT string of s, a line break is escaped as a centered line break.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package topology
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTopology = &Topology{
+	Nodes: []Node{
+		{ID: "capabilities:traces", Kind: KindCapabilities, Signal: "traces", Pipelines: []string{"traces"}, MutatesData: true},
+		{ID: "connector:traces:metrics:count", Kind: "connector", ComponentID: "count", Signal: "traces", OutputSignal: "metrics", Pipelines: []string{"metrics", "traces"}},
+		{ID: "processor:traces:transform", Kind: "processor", ComponentID: "transform", Signal: "traces", Pipelines: []string{"traces"}, MutatesData: true},
+		{ID: "receiver:traces:otlp", Kind: "receiver", ComponentID: "otlp", Signal: "traces", Pipelines: []string{"traces"}},
+	},
+	Edges: []Edge{
+		{From: "capabilities:traces", To: "processor:traces:transform"},
+		{From: "receiver:traces:otlp", To: "capabilities:traces"},
+	},
+}
+
+func TestWriteDOT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, testTopology.Write(buf, FormatDOT))
+	assert.Equal(t, `digraph pipelines {
+  rankdir=LR;
+  node [shape=box];
+  "connector:traces:metrics:count" [label="connector\ncount\ntraces -> metrics", shape=diamond];
+  "receiver:traces:otlp" [label="receiver\notlp\ntraces"];
+  subgraph cluster_0 {
+    label="traces";
+    "capabilities:traces" [shape=point, tooltip="capabilities", style=bold];
+    "processor:traces:transform" [label="processor\ntransform\ntraces", style=bold];
+  }
+  "capabilities:traces" -> "processor:traces:transform";
+  "receiver:traces:otlp" -> "capabilities:traces";
+}
+`, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, testTopology.Write(buf, FormatJSON))
+	got := &Topology{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), got))
+	assert.Equal(t, testTopology, got)
+	assert.Contains(t, buf.String(), `"mutates_data": true`)
+	assert.Contains(t, buf.String(), `"output_signal": "metrics"`)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	require.ErrorContains(t, testTopology.Write(&bytes.Buffer{}, "svg"), `unknown topology format "svg"`)
+}